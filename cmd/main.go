@@ -1,9 +1,11 @@
 package main
 
 import (
+	"context"
 	"log"
 
 	"github.com/Hann-arc/task-management-backend/config"
+	"github.com/Hann-arc/task-management-backend/internal/jobs"
 	"github.com/Hann-arc/task-management-backend/internal/routes"
 	"github.com/gofiber/fiber/v2"
 	"github.com/gofiber/fiber/v2/middleware/cors"
@@ -11,13 +13,18 @@ import (
 
 func main() {
 	config.ConnnDB()
-	config.SetupCloudinary()
-	app := fiber.New()
+	config.SetupStorage()
+	config.SetupTrash()
+	config.SetupUploads()
+	jobs.Start(context.Background())
+	app := fiber.New(fiber.Config{
+		BodyLimit: int(config.UploadBodyLimit),
+	})
 
 	app.Use(cors.New(cors.Config{
 		AllowOrigins: "*",
 		AllowHeaders: "Origin, Content-Type, Accept, Authorization",
-		AllowMethods: "GET, POST, PATCH, DELETE",
+		AllowMethods: "GET, POST, PUT, PATCH, DELETE",
 	}))
 
 	app.Get("/", func(c *fiber.Ctx) error {
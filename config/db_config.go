@@ -14,6 +14,36 @@ import (
 
 var DB *gorm.DB
 
+// Models lists every model whose table is created and updated by AutoMigrate
+var Models = []interface{}{
+	&models.User{},
+	&models.Role{},
+	&models.Project{},
+	&models.ProjectMember{},
+	&models.Board{},
+	&models.Sprint{},
+	&models.Task{},
+	&models.TaskLabel{},
+	&models.TaskAssignee{},
+	&models.TaskWatcher{},
+	&models.TaskMention{},
+	&models.Comment{},
+	&models.CommentEdit{},
+	&models.CommentMention{},
+	&models.Attachment{},
+	&models.AttachmentVersion{},
+	&models.Notification{},
+	&models.ActivityLog{},
+	&models.Invitation{},
+	&models.TaskRecurrence{},
+	&models.TaskReminder{},
+	&models.TimeEntry{},
+	&models.CustomField{},
+	&models.TaskCustomFieldValue{},
+	&models.TaskTemplate{},
+	&models.ProjectUploadPolicy{},
+}
+
 func ConnnDB() {
 	err := godotenv.Load()
 
@@ -40,18 +70,109 @@ func ConnnDB() {
 
 	DB.Exec("CREATE EXTENSION IF NOT EXISTS \"uuid-ossp\";")
 
-	DB.AutoMigrate(
-		&models.User{},
-		&models.Role{},
-		&models.Project{},
-		&models.ProjectMember{},
-		&models.Board{},
-		&models.Task{},
-		&models.TaskLabel{},
-		&models.Comment{},
-		&models.Attachment{},
-		&models.Notification{},
-		&models.ActivityLog{},
-		&models.Invitation{},
-	)
+	// the legacy assignees are copied only when the assignees table is first created, so later
+	// changes to the assignees of a task are never overwritten
+	copyAssignees := !DB.Migrator().HasTable(&models.TaskAssignee{})
+
+	DB.AutoMigrate(Models...)
+
+	if copyAssignees {
+		migrateLegacyAssignees()
+	}
+	migrateAttachmentVersions()
+	backfillSoftDeletes()
+	setupSearchIndexes()
+}
+
+// migrateLegacyAssignees copies the former single tasks.assignee_id column into task_assignees.
+// The column is left in place and unused, so the data survives a rollback; dropping it is left
+// to an explicit migration
+func migrateLegacyAssignees() {
+	if !DB.Migrator().HasColumn("tasks", "assignee_id") {
+		return
+	}
+
+	err := DB.Exec(`INSERT INTO task_assignees (task_id, user_id, created_at)
+		SELECT id, assignee_id, NOW() FROM tasks WHERE assignee_id IS NOT NULL
+		ON CONFLICT DO NOTHING`).Error
+	if err != nil {
+		log.Println("Failed to migrate task assignees:", err)
+	}
+}
+
+// migrateAttachmentVersions records the file of attachments created before versioning as their
+// first version
+func migrateAttachmentVersions() {
+	err := DB.Exec(`INSERT INTO attachment_versions (attachment_id, version, file_url, file_name, file_size, mime_type, thumbnail_url, uploaded_by, created_at)
+		SELECT a.id, a.version, a.file_url, a.file_name, a.file_size, a.mime_type, a.thumbnail_url, a.uploaded_by, COALESCE(a.created_at, NOW())
+		FROM attachments a
+		WHERE NOT EXISTS (SELECT 1 FROM attachment_versions v WHERE v.attachment_id = a.id)`).Error
+	if err != nil {
+		log.Println("Failed to migrate attachment versions:", err)
+	}
+}
+
+// softDeleteBackfills stamp the live children of soft-deleted parents with the parent's deletion
+// time, as the cascade does; rows deleted before deletes cascaded would otherwise stay reachable.
+// They run parents first so that each level sees the one above it already stamped
+var softDeleteBackfills = []string{
+	`UPDATE boards SET deleted_at = p.deleted_at FROM projects p
+		WHERE boards.project_id = p.id AND p.deleted_at IS NOT NULL AND boards.deleted_at IS NULL`,
+	`UPDATE invitations SET deleted_at = p.deleted_at FROM projects p
+		WHERE invitations.project_id = p.id AND p.deleted_at IS NOT NULL AND invitations.deleted_at IS NULL`,
+	`UPDATE notifications SET deleted_at = p.deleted_at FROM projects p
+		WHERE notifications.reference_type = 'project' AND notifications.related_id = p.id
+		AND p.deleted_at IS NOT NULL AND notifications.deleted_at IS NULL`,
+	`UPDATE tasks SET deleted_at = b.deleted_at FROM boards b
+		WHERE tasks.board_id = b.id AND b.deleted_at IS NOT NULL AND tasks.deleted_at IS NULL`,
+	`UPDATE notifications SET deleted_at = t.deleted_at FROM tasks t
+		WHERE notifications.reference_type = 'task' AND notifications.related_id = t.id
+		AND t.deleted_at IS NOT NULL AND notifications.deleted_at IS NULL`,
+	`UPDATE comments SET deleted_at = t.deleted_at FROM tasks t
+		WHERE comments.task_id = t.id AND t.deleted_at IS NOT NULL AND comments.deleted_at IS NULL`,
+	`UPDATE comments SET deleted_at = parent.deleted_at FROM comments parent
+		WHERE comments.parent_id = parent.id AND parent.deleted_at IS NOT NULL AND comments.deleted_at IS NULL`,
+}
+
+// backfillSoftDeletes applies the soft-delete cascade to rows deleted before it existed
+func backfillSoftDeletes() {
+	err := DB.Transaction(func(tx *gorm.DB) error {
+		for _, statement := range softDeleteBackfills {
+			if err := tx.Exec(statement).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		log.Println("Failed to backfill soft deletes:", err)
+	}
+}
+
+// setupSearchIndexes adds generated tsvector columns and GIN indexes used by full-text search
+func setupSearchIndexes() {
+	statements := []string{
+		`ALTER TABLE tasks ADD COLUMN IF NOT EXISTS search_vector tsvector GENERATED ALWAYS AS (
+			setweight(to_tsvector('english', coalesce(title, '')), 'A') ||
+			setweight(to_tsvector('english', coalesce(description, '')), 'B')
+		) STORED`,
+		`CREATE INDEX IF NOT EXISTS idx_tasks_search_vector ON tasks USING GIN (search_vector)`,
+
+		`ALTER TABLE comments ADD COLUMN IF NOT EXISTS search_vector tsvector GENERATED ALWAYS AS (
+			to_tsvector('english', coalesce(content, ''))
+		) STORED`,
+		`CREATE INDEX IF NOT EXISTS idx_comments_search_vector ON comments USING GIN (search_vector)`,
+
+		`ALTER TABLE projects ADD COLUMN IF NOT EXISTS search_vector tsvector GENERATED ALWAYS AS (
+			setweight(to_tsvector('english', coalesce(name, '')), 'A') ||
+			setweight(to_tsvector('english', coalesce(description, '')), 'B')
+		) STORED`,
+		`CREATE INDEX IF NOT EXISTS idx_projects_search_vector ON projects USING GIN (search_vector)`,
+	}
+
+	for _, stmt := range statements {
+		if err := DB.Exec(stmt).Error; err != nil {
+			log.Println("Failed to set up search index:", err)
+		}
+	}
 }
@@ -0,0 +1,94 @@
+package config
+
+import (
+	"os"
+	"testing"
+	"time"
+
+	"github.com/Hann-arc/task-management-backend/internal/models"
+	"github.com/google/uuid"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// useTestDB points DB at the database named by TEST_DATABASE_URL inside a transaction that is
+// rolled back afterwards; the test is skipped when the variable is unset
+func useTestDB(t *testing.T) {
+	t.Helper()
+
+	dsn := os.Getenv("TEST_DATABASE_URL")
+	if dsn == "" {
+		t.Skip("TEST_DATABASE_URL is not set")
+	}
+
+	db, err := gorm.Open(postgres.Open(dsn), &gorm.Config{Logger: logger.Default.LogMode(logger.Silent)})
+	if err != nil {
+		t.Fatalf("failed to connect: %v", err)
+	}
+	if err := db.Exec(`CREATE EXTENSION IF NOT EXISTS "uuid-ossp"`).Error; err != nil {
+		t.Fatalf("failed to create extension: %v", err)
+	}
+	if err := db.AutoMigrate(Models...); err != nil {
+		t.Fatalf("failed to migrate: %v", err)
+	}
+
+	previous := DB
+	DB = db.Begin()
+	t.Cleanup(func() {
+		DB.Rollback()
+		DB = previous
+	})
+}
+
+func TestBackfillSoftDeletes(t *testing.T) {
+	useTestDB(t)
+
+	create := func(value interface{}) {
+		t.Helper()
+		if err := DB.Create(value).Error; err != nil {
+			t.Fatalf("failed to create %T: %v", value, err)
+		}
+	}
+
+	owner := models.User{Name: "Owner", Email: uuid.NewString() + "@example.com", PasswordHash: "hash"}
+	create(&owner)
+	project := models.Project{Name: "Project", OwnerID: owner.ID}
+	create(&project)
+	board := models.Board{Name: "Board", ProjectID: project.ID}
+	create(&board)
+	task := models.Task{BoardID: board.ID, Title: "Task", Priority: "medium", CreatedBy: owner.ID}
+	create(&task)
+	comment := models.Comment{TaskID: task.ID, UserID: owner.ID, Content: "Comment"}
+	create(&comment)
+	reply := models.Comment{TaskID: task.ID, UserID: owner.ID, ParentID: &comment.ID, Content: "Reply"}
+	create(&reply)
+
+	// a project deleted before deletes cascaded only has its own row stamped
+	deletedAt := time.Now().Add(-time.Hour).Truncate(time.Microsecond)
+	if err := DB.Model(&models.Project{}).Where("id = ?", project.ID).UpdateColumn("deleted_at", deletedAt).Error; err != nil {
+		t.Fatalf("failed to delete project: %v", err)
+	}
+
+	backfillSoftDeletes()
+
+	rows := []struct {
+		name  string
+		model interface{}
+		id    uuid.UUID
+	}{
+		{"board", &models.Board{}, board.ID},
+		{"task", &models.Task{}, task.ID},
+		{"comment", &models.Comment{}, comment.ID},
+		{"reply", &models.Comment{}, reply.ID},
+	}
+	for _, row := range rows {
+		var stamped *time.Time
+		if err := DB.Unscoped().Model(row.model).Select("deleted_at").Where("id = ?", row.id).Scan(&stamped).Error; err != nil {
+			t.Fatalf("failed to load %s: %v", row.name, err)
+		}
+		if stamped == nil || !stamped.Equal(deletedAt) {
+			t.Errorf("%s: expected deleted_at %v, got %v", row.name, deletedAt, stamped)
+		}
+	}
+}
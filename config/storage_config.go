@@ -0,0 +1,116 @@
+package config
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"strings"
+
+	"github.com/Hann-arc/task-management-backend/internal/storage"
+	"gorm.io/gorm"
+)
+
+// Storage is the backend attachment files are written to
+var Storage storage.Storage
+
+// SetupStorage initializes the storage backend selected by STORAGE_DRIVER (cloudinary, local
+// or s3); without it Cloudinary is used when configured and the local disk otherwise
+func SetupStorage() {
+	driver := os.Getenv("STORAGE_DRIVER")
+	if driver == "" {
+		driver = "local"
+		if os.Getenv("CLOUDINARY_CLOUD_NAME") != "" {
+			driver = "cloudinary"
+		}
+	}
+
+	switch driver {
+	case "cloudinary":
+		SetupCloudinary()
+		cloudinaryStorage := storage.NewCloudinaryStorage(Cld, "MgApp")
+		migrateCloudinaryAssets(cloudinaryStorage)
+		Storage = cloudinaryStorage
+
+	case "local":
+		dir := os.Getenv("STORAGE_LOCAL_DIR")
+		if dir == "" {
+			dir = "uploads"
+		}
+		baseURL := os.Getenv("STORAGE_LOCAL_URL")
+		if baseURL == "" {
+			baseURL = "/v1/api/files"
+		}
+
+		local, err := storage.NewLocalStorage(dir, baseURL)
+		if err != nil {
+			log.Fatal("Failed to initialize local storage:", err)
+		}
+		Storage = local
+
+	case "s3":
+		s3, err := storage.NewS3Storage(storage.S3Config{
+			Endpoint:  os.Getenv("S3_ENDPOINT"),
+			AccessKey: os.Getenv("S3_ACCESS_KEY"),
+			SecretKey: os.Getenv("S3_SECRET_KEY"),
+			Bucket:    os.Getenv("S3_BUCKET"),
+			Region:    os.Getenv("S3_REGION"),
+			UseSSL:    os.Getenv("S3_USE_SSL") == "true",
+			PublicURL: os.Getenv("S3_PUBLIC_URL"),
+		})
+		if err != nil {
+			log.Fatal("Failed to initialize S3 storage:", err)
+		}
+		Storage = s3
+
+	default:
+		log.Fatal("Unknown STORAGE_DRIVER:", driver)
+	}
+}
+
+// cloudinaryPrivateColumns lists the columns holding URLs of files that must not be public;
+// avatars are left out as their URLs are linked to directly
+var cloudinaryPrivateColumns = []struct{ table, column string }{
+	{"attachments", "file_url"},
+	{"attachments", "thumbnail_url"},
+	{"attachment_versions", "file_url"},
+	{"attachment_versions", "thumbnail_url"},
+}
+
+// migrateCloudinaryAssets moves attachment files uploaded while Cloudinary assets were public
+// to the authenticated delivery type and points the stored URLs at their new location
+func migrateCloudinaryAssets(store *storage.CloudinaryStorage) {
+	selects := make([]string, 0, len(cloudinaryPrivateColumns))
+	for _, c := range cloudinaryPrivateColumns {
+		selects = append(selects, fmt.Sprintf("SELECT %s AS url FROM %s WHERE %s LIKE '%%/upload/%%'", c.column, c.table, c.column))
+	}
+
+	var urls []string
+	if err := DB.Raw(strings.Join(selects, " UNION ")).Scan(&urls).Error; err != nil {
+		log.Println("Failed to list public Cloudinary assets:", err)
+		return
+	}
+
+	for _, oldURL := range urls {
+		newURL, err := store.MakePrivate(oldURL)
+		if err != nil {
+			log.Println("Failed to make Cloudinary asset private:", oldURL, err)
+			continue
+		}
+		if newURL == oldURL {
+			continue
+		}
+
+		err = DB.Transaction(func(tx *gorm.DB) error {
+			for _, c := range cloudinaryPrivateColumns {
+				query := fmt.Sprintf("UPDATE %s SET %s = ? WHERE %s = ?", c.table, c.column, c.column)
+				if err := tx.Exec(query, newURL, oldURL).Error; err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+		if err != nil {
+			log.Println("Failed to update the URL of Cloudinary asset:", oldURL, err)
+		}
+	}
+}
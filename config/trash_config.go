@@ -0,0 +1,25 @@
+package config
+
+import (
+	"log"
+	"os"
+	"strconv"
+	"time"
+)
+
+// TrashRetention is how long soft-deleted items stay restorable before they are purged
+var TrashRetention = 30 * 24 * time.Hour
+
+// SetupTrash reads the trash retention period from TRASH_RETENTION_DAYS
+func SetupTrash() {
+	value := os.Getenv("TRASH_RETENTION_DAYS")
+	if value == "" {
+		return
+	}
+
+	days, err := strconv.Atoi(value)
+	if err != nil || days < 1 {
+		log.Fatal("Invalid TRASH_RETENTION_DAYS:", value)
+	}
+	TrashRetention = time.Duration(days) * 24 * time.Hour
+}
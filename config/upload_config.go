@@ -0,0 +1,88 @@
+package config
+
+import (
+	"log"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Hann-arc/task-management-backend/internal/scanner"
+)
+
+const megabyte = 1024 * 1024
+
+var (
+	// UploadBodyLimit caps request bodies and therefore any per-project file size limit
+	UploadBodyLimit int64 = 100 * megabyte
+	// UploadMaxFileSize applies to projects that do not set their own limit
+	UploadMaxFileSize int64 = 10 * megabyte
+	// UploadStorageQuota applies to projects that do not set their own quota; 0 means unlimited
+	UploadStorageQuota int64 = 0
+
+	// Scanner checks uploads for malware; nil disables scanning
+	Scanner scanner.Scanner
+	// QuarantineInfected keeps infected uploads in the quarantine folder instead of dropping them
+	QuarantineInfected bool
+
+	// DownloadURLTTL is how long signed attachment download links stay valid
+	DownloadURLTTL = 15 * time.Minute
+	// DownloadBaseURL prefixes the signed download links handed to clients
+	DownloadBaseURL = "/v1/api"
+)
+
+// SetupUploads reads the upload limits, download link and malware scanner settings
+func SetupUploads() {
+	UploadBodyLimit = envMegabytes("UPLOAD_BODY_LIMIT_MB", UploadBodyLimit)
+	UploadMaxFileSize = envMegabytes("UPLOAD_MAX_FILE_SIZE_MB", UploadMaxFileSize)
+	UploadStorageQuota = envMegabytes("UPLOAD_PROJECT_QUOTA_MB", UploadStorageQuota)
+	if UploadMaxFileSize > UploadBodyLimit {
+		log.Fatal("UPLOAD_MAX_FILE_SIZE_MB cannot exceed UPLOAD_BODY_LIMIT_MB")
+	}
+
+	if value := os.Getenv("DOWNLOAD_URL_TTL"); value != "" {
+		ttl, err := time.ParseDuration(value)
+		if err != nil || ttl <= 0 {
+			log.Fatal("Invalid DOWNLOAD_URL_TTL:", value)
+		}
+		DownloadURLTTL = ttl
+	}
+	if value := os.Getenv("DOWNLOAD_BASE_URL"); value != "" {
+		DownloadBaseURL = strings.TrimSuffix(value, "/")
+	}
+
+	switch driver := os.Getenv("SCANNER_DRIVER"); driver {
+	case "":
+	case "clamav":
+		address := os.Getenv("CLAMAV_ADDRESS")
+		if address == "" {
+			address = "unix:/var/run/clamav/clamd.ctl"
+		}
+		Scanner = scanner.NewClamAVScanner(address)
+	default:
+		log.Fatal("Unknown SCANNER_DRIVER:", driver)
+	}
+
+	switch action := os.Getenv("SCANNER_ACTION"); action {
+	case "", "reject":
+		QuarantineInfected = false
+	case "quarantine":
+		QuarantineInfected = true
+	default:
+		log.Fatal("Invalid SCANNER_ACTION:", action)
+	}
+}
+
+// envMegabytes reads a non-negative size in megabytes, falling back to def when unset
+func envMegabytes(name string, def int64) int64 {
+	value := os.Getenv(name)
+	if value == "" {
+		return def
+	}
+
+	mb, err := strconv.ParseInt(value, 10, 64)
+	if err != nil || mb < 0 {
+		log.Fatalf("Invalid %s: %s", name, value)
+	}
+	return mb * megabyte
+}
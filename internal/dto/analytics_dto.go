@@ -0,0 +1,44 @@
+package dto
+
+import "time"
+
+type AnalyticsQuery struct {
+	From     string `query:"from"`
+	To       string `query:"to"`
+	Unit     string `query:"unit"`
+	TimeZone string `query:"tz"`
+}
+
+type BurndownPoint struct {
+	Date      string   `json:"date"`
+	Total     int      `json:"total"`
+	Remaining int      `json:"remaining"`
+	Completed int      `json:"completed"`
+	Ideal     *float64 `json:"ideal,omitempty"`
+}
+
+type CumulativeFlowPoint struct {
+	Date       string `json:"date"`
+	Todo       int    `json:"todo"`
+	InProgress int    `json:"in_progress"`
+	Done       int    `json:"done"`
+}
+
+type DurationStats struct {
+	Count        int     `json:"count"`
+	AverageHours float64 `json:"average_hours"`
+	MedianHours  float64 `json:"median_hours"`
+	P85Hours     float64 `json:"p85_hours"`
+}
+
+type AnalyticsResponse struct {
+	ProjectID      string                `json:"project_id"`
+	SprintID       *string               `json:"sprint_id,omitempty"`
+	From           time.Time             `json:"from"`
+	To             time.Time             `json:"to"`
+	Unit           string                `json:"unit"`
+	Burndown       []BurndownPoint       `json:"burndown"`
+	CumulativeFlow []CumulativeFlowPoint `json:"cumulative_flow"`
+	CycleTime      DurationStats         `json:"cycle_time"`
+	LeadTime       DurationStats         `json:"lead_time"`
+}
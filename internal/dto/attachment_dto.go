@@ -1,21 +1,54 @@
 package dto
 
+import "time"
+
 type GetAttachmentResponse struct {
-	ID         string `json:"id"`
-	TaskID     string `json:"task_id"`
-	FileUrl    string `json:"file_url"`
-	UploadedBy string `json:"uploaded_by"`
-	Uploader   struct {
+	ID           string    `json:"id"`
+	TaskID       string    `json:"task_id"`
+	CommentID    *string   `json:"comment_id,omitempty"`
+	FileUrl      string    `json:"file_url"`
+	FileName     string    `json:"file_name"`
+	FileSize     int64     `json:"file_size"`
+	MimeType     string    `json:"mime_type"`
+	ThumbnailUrl *string   `json:"thumbnail_url,omitempty"`
+	UrlExpiresAt time.Time `json:"url_expires_at"`
+	Version      int       `json:"version"`
+	UploadedBy   string    `json:"uploaded_by"`
+	CreatedAt    time.Time `json:"created_at"`
+	Uploader     struct {
 		ID   string `json:"id"`
 		Name string `json:"name"`
 	} `json:"uploader"`
 }
 
 type CreateAttachmentResponse struct {
-	ID         string `json:"id"`
-	TaskID     string `json:"task_id"`
-	FileUrl    string `json:"file_url"`
-	UploadedBy string `json:"uploaded_by"`
+	ID           string    `json:"id"`
+	TaskID       string    `json:"task_id"`
+	FileUrl      string    `json:"file_url"`
+	FileName     string    `json:"file_name"`
+	FileSize     int64     `json:"file_size"`
+	MimeType     string    `json:"mime_type"`
+	ThumbnailUrl *string   `json:"thumbnail_url,omitempty"`
+	UrlExpiresAt time.Time `json:"url_expires_at"`
+	Version      int       `json:"version"`
+	UploadedBy   string    `json:"uploaded_by"`
+	CreatedAt    time.Time `json:"created_at"`
+}
+
+type AttachmentVersionResponse struct {
+	Version      int       `json:"version"`
+	FileUrl      string    `json:"file_url"`
+	FileName     string    `json:"file_name"`
+	FileSize     int64     `json:"file_size"`
+	MimeType     string    `json:"mime_type"`
+	UrlExpiresAt time.Time `json:"url_expires_at"`
+	IsLatest     bool      `json:"is_latest"`
+	UploadedBy   string    `json:"uploaded_by"`
+	CreatedAt    time.Time `json:"created_at"`
+	Uploader     struct {
+		ID   string `json:"id"`
+		Name string `json:"name"`
+	} `json:"uploader"`
 }
 
 type UploadAttachmentRequest struct {
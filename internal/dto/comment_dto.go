@@ -11,6 +11,11 @@ type CommentResponse struct {
 	User      CommentUser `json:"user"`
 	CreatedAt time.Time   `json:"created_at"`
 	UpdatedAt time.Time   `json:"updated_at"`
+	IsEdited  bool        `json:"is_edited"`
+	EditedAt  *time.Time  `json:"edited_at,omitempty"`
+
+	Mentions    []MentionResponse       `json:"mentions"`
+	Attachments []GetAttachmentResponse `json:"attachments"`
 }
 
 type CommentUser struct {
@@ -20,5 +25,17 @@ type CommentUser struct {
 }
 
 type CreateCommentRequest struct {
+	Content string `json:"content" form:"content" validate:"required,min=1"`
+}
+
+type UpdateCommentRequest struct {
 	Content string `json:"content" validate:"required,min=1"`
 }
+
+type CommentEditResponse struct {
+	ID        string      `json:"id"`
+	CommentID string      `json:"comment_id"`
+	Content   string      `json:"content"`
+	Editor    CommentUser `json:"editor"`
+	EditedAt  time.Time   `json:"edited_at"`
+}
@@ -0,0 +1,41 @@
+package dto
+
+import (
+	"encoding/json"
+	"time"
+)
+
+type CreateCustomFieldRequest struct {
+	Name     string   `json:"name" validate:"required"`
+	Type     string   `json:"type" validate:"required,oneof=text number date single_select multi_select user checkbox"`
+	Options  []string `json:"options,omitempty"`
+	Required bool     `json:"required"`
+	Position *int     `json:"position,omitempty"`
+}
+
+type UpdateCustomFieldRequest struct {
+	Name     *string   `json:"name,omitempty"`
+	Options  *[]string `json:"options,omitempty"`
+	Required *bool     `json:"required,omitempty"`
+	Position *int      `json:"position,omitempty"`
+}
+
+type CustomFieldResponse struct {
+	ID        string    `json:"id"`
+	ProjectID string    `json:"project_id"`
+	Name      string    `json:"name"`
+	Type      string    `json:"type"`
+	Options   []string  `json:"options,omitempty"`
+	Required  bool      `json:"required"`
+	Position  int       `json:"position"`
+	CreatedBy string    `json:"created_by"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+type CustomFieldValueResponse struct {
+	FieldID string          `json:"field_id"`
+	Name    string          `json:"name"`
+	Type    string          `json:"type"`
+	Value   json.RawMessage `json:"value"`
+}
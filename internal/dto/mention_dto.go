@@ -0,0 +1,7 @@
+package dto
+
+type MentionResponse struct {
+	UserID string `json:"user_id"`
+	Handle string `json:"handle"`
+	Name   string `json:"name"`
+}
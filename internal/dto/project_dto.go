@@ -7,6 +7,8 @@ type ProjectResponse struct {
 	Name        string     `json:"name"`
 	Description string     `json:"description,omitempty"`
 	OwnerID     string     `json:"owner_id"`
+	IsTemplate  bool       `json:"is_template"`
+	ArchivedAt  *time.Time `json:"archived_at,omitempty"`
 	CreatedAt   time.Time  `json:"created_at"`
 	UpdatedAt   time.Time  `json:"updated_at"`
 	DeletedAt   *time.Time `json:"deleted_at,omitempty"`
@@ -17,6 +19,8 @@ type ProjectDetailResponse struct {
 	Name        string         `json:"name"`
 	Description string         `json:"description,omitempty"`
 	Owner       UserResponse   `json:"owner"`
+	IsTemplate  bool           `json:"is_template"`
+	ArchivedAt  *time.Time     `json:"archived_at,omitempty"`
 	Boards      []BoardSummary `json:"boards,omitempty"`
 	CreatedAt   time.Time      `json:"created_at"`
 	UpdatedAt   time.Time      `json:"updated_at"`
@@ -29,12 +33,67 @@ type BoardSummary struct {
 	OrderIndex int    `json:"order_index"`
 }
 
+type ProjectSummary struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
 type CreateProjectRequest struct {
 	Name        string `json:"name" validate:"required,min=1"`
 	Description string `json:"description,omitempty"`
 }
 
+// DuplicateProjectRequest copies a project; StartDate (YYYY-MM-DD) shifts the due dates of copied
+// tasks by the number of days between the source project's creation and the new start
+type DuplicateProjectRequest struct {
+	Name           string  `json:"name" validate:"required,min=1"`
+	Description    *string `json:"description,omitempty"`
+	IncludeTasks   bool    `json:"include_tasks"`
+	IncludeMembers bool    `json:"include_members"`
+	StartDate      *string `json:"start_date,omitempty"`
+}
+
+type SaveProjectTemplateRequest struct {
+	Name         string  `json:"name" validate:"required,min=1"`
+	Description  *string `json:"description,omitempty"`
+	IncludeTasks bool    `json:"include_tasks"`
+}
+
 type UpdateProjectRequest struct {
 	Name        *string `json:"name,omitempty"`
 	Description *string `json:"description,omitempty"`
 }
+
+type ProjectTaskTotals struct {
+	Total               int64 `json:"total"`
+	Open                int64 `json:"open"`
+	Done                int64 `json:"done"`
+	Overdue             int64 `json:"overdue"`
+	Unassigned          int64 `json:"unassigned"`
+	CompletedLast7Days  int64 `json:"completed_last_7_days"`
+	CompletedLast30Days int64 `json:"completed_last_30_days"`
+}
+
+type TaskCountStats struct {
+	ID      string `json:"id,omitempty"`
+	Name    string `json:"name"`
+	Total   int64  `json:"total"`
+	Open    int64  `json:"open"`
+	Overdue int64  `json:"overdue"`
+}
+
+type MemberActivityStats struct {
+	UserID  string `json:"user_id"`
+	Name    string `json:"name"`
+	Actions int64  `json:"actions"`
+}
+
+type ProjectStatsResponse struct {
+	ProjectID         string                `json:"project_id"`
+	Totals            ProjectTaskTotals     `json:"totals"`
+	ByBoard           []TaskCountStats      `json:"by_board"`
+	ByPriority        []TaskCountStats      `json:"by_priority"`
+	ByAssignee        []TaskCountStats      `json:"by_assignee"`
+	MostActiveMembers []MemberActivityStats `json:"most_active_members"`
+	GeneratedAt       time.Time             `json:"generated_at"`
+}
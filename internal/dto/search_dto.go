@@ -0,0 +1,38 @@
+package dto
+
+type SearchQuery struct {
+	Query string `query:"q"`
+	Types string `query:"types"`
+	Limit int    `query:"limit"`
+}
+
+type SearchResponse struct {
+	Tasks    []TaskSearchResult    `json:"tasks"`
+	Comments []CommentSearchResult `json:"comments"`
+	Projects []ProjectSearchResult `json:"projects"`
+}
+
+type TaskSearchResult struct {
+	ID        string  `json:"id"`
+	Title     string  `json:"title"`
+	BoardID   string  `json:"board_id"`
+	ProjectID string  `json:"project_id"`
+	Rank      float64 `json:"rank"`
+	Highlight string  `json:"highlight"`
+}
+
+type CommentSearchResult struct {
+	ID        string  `json:"id"`
+	TaskID    string  `json:"task_id"`
+	ProjectID string  `json:"project_id"`
+	UserID    string  `json:"user_id"`
+	Rank      float64 `json:"rank"`
+	Highlight string  `json:"highlight"`
+}
+
+type ProjectSearchResult struct {
+	ID        string  `json:"id"`
+	Name      string  `json:"name"`
+	Rank      float64 `json:"rank"`
+	Highlight string  `json:"highlight"`
+}
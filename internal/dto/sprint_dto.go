@@ -0,0 +1,74 @@
+package dto
+
+import "time"
+
+type CreateSprintRequest struct {
+	Name      string `json:"name" validate:"required"`
+	Goal      string `json:"goal"`
+	StartDate string `json:"start_date" validate:"required"`
+	EndDate   string `json:"end_date" validate:"required"`
+}
+
+type UpdateSprintRequest struct {
+	Name      *string `json:"name,omitempty"`
+	Goal      *string `json:"goal,omitempty"`
+	StartDate *string `json:"start_date,omitempty"`
+	EndDate   *string `json:"end_date,omitempty"`
+}
+
+type CloseSprintRequest struct {
+	NextSprintID *string `json:"next_sprint_id,omitempty"`
+}
+
+type SprintTasksRequest struct {
+	TaskIDs []string `json:"task_ids" validate:"required,dive,uuid"`
+}
+
+type SprintResponse struct {
+	ID              string     `json:"id"`
+	ProjectID       string     `json:"project_id"`
+	Name            string     `json:"name"`
+	Goal            string     `json:"goal,omitempty"`
+	StartDate       time.Time  `json:"start_date"`
+	EndDate         time.Time  `json:"end_date"`
+	State           string     `json:"state"`
+	TaskCount       int64      `json:"task_count"`
+	TotalPoints     int64      `json:"total_points"`
+	DonePoints      int64      `json:"done_points"`
+	CommittedPoints int        `json:"committed_points"`
+	CompletedPoints int        `json:"completed_points"`
+	ClosedAt        *time.Time `json:"closed_at,omitempty"`
+	CreatedBy       string     `json:"created_by"`
+	CreatedAt       time.Time  `json:"created_at"`
+	UpdatedAt       time.Time  `json:"updated_at"`
+}
+
+type CloseSprintResponse struct {
+	Sprint       SprintResponse `json:"sprint"`
+	MovedTasks   int64          `json:"moved_tasks"`
+	NextSprintID *string        `json:"next_sprint_id,omitempty"`
+}
+
+type SprintBoardColumn struct {
+	Status string         `json:"status"`
+	Points int            `json:"points"`
+	Tasks  []TaskResponse `json:"tasks"`
+}
+
+type SprintBoardResponse struct {
+	Sprint  SprintResponse      `json:"sprint"`
+	Columns []SprintBoardColumn `json:"columns"`
+}
+
+type VelocityEntry struct {
+	SprintID        string     `json:"sprint_id"`
+	Name            string     `json:"name"`
+	CommittedPoints int        `json:"committed_points"`
+	CompletedPoints int        `json:"completed_points"`
+	ClosedAt        *time.Time `json:"closed_at,omitempty"`
+}
+
+type VelocityResponse struct {
+	Sprints         []VelocityEntry `json:"sprints"`
+	AverageVelocity float64         `json:"average_velocity"`
+}
@@ -5,19 +5,29 @@ import (
 )
 
 type TaskResponse struct {
-	ID          string     `json:"id"`
-	BoardID     string     `json:"board_id"`
-	Title       string     `json:"title"`
-	Description string     `json:"description,omitempty"`
-	Priority    string     `json:"priority"`
-	DueDate     *time.Time `json:"due_date,omitempty"`
-	AssigneeID  *string    `json:"assignee_id,omitempty"`
-	Assignee    *UserBasic `json:"assignee,omitempty"`
-	CreatedBy   string     `json:"created_by"`
-	CreatedAt   time.Time  `json:"created_at"`
-	UpdatedAt   time.Time  `json:"updated_at"`
-	DeletedAt   *time.Time `json:"deleted_at,omitempty"`
-	Labels      []LabelDTO `json:"labels,omitempty"`
+	ID              string                     `json:"id"`
+	BoardID         string                     `json:"board_id"`
+	Title           string                     `json:"title"`
+	Description     string                     `json:"description,omitempty"`
+	Priority        string                     `json:"priority"`
+	Status          string                     `json:"status"`
+	DueDate         *time.Time                 `json:"due_date,omitempty"`
+	CompletedAt     *time.Time                 `json:"completed_at,omitempty"`
+	EstimateMinutes *int                       `json:"estimate_minutes,omitempty"`
+	StoryPoints     *int                       `json:"story_points,omitempty"`
+	SprintID        *string                    `json:"sprint_id,omitempty"`
+	AssigneeID      *string                    `json:"assignee_id,omitempty"`
+	Assignee        *UserBasic                 `json:"assignee,omitempty"`
+	AssigneeIDs     []string                   `json:"assignee_ids"`
+	Assignees       []UserBasic                `json:"assignees,omitempty"`
+	Watchers        []UserBasic                `json:"watchers,omitempty"`
+	Mentions        []MentionResponse          `json:"mentions"`
+	CreatedBy       string                     `json:"created_by"`
+	CreatedAt       time.Time                  `json:"created_at"`
+	UpdatedAt       time.Time                  `json:"updated_at"`
+	DeletedAt       *time.Time                 `json:"deleted_at,omitempty"`
+	Labels          []LabelDTO                 `json:"labels,omitempty"`
+	CustomFields    []CustomFieldValueResponse `json:"custom_fields,omitempty"`
 }
 
 type UserBasic struct {
@@ -32,19 +42,77 @@ type LabelDTO struct {
 }
 
 type CreateTaskRequest struct {
-	Title       string     `json:"title" validate:"required,min=1"`
-	Description string     `json:"description"`
-	Priority    string     `json:"priority" validate:"required,oneof=low medium high urgent"`
-	DueDate     *string    `json:"due_date" validate:"omitempty,datetime=2006-01-02T15:04:05Z07:00"`
-	AssigneeID  *string    `json:"assignee_id" validate:"omitempty,uuid"`
-	Labels      []LabelDTO `json:"labels" validate:"dive"`
+	Title           string                 `json:"title" validate:"required,min=1"`
+	Description     string                 `json:"description"`
+	Priority        string                 `json:"priority" validate:"required,oneof=low medium high urgent"`
+	Status          string                 `json:"status" validate:"omitempty,oneof=todo in_progress done"`
+	DueDate         *string                `json:"due_date" validate:"omitempty,datetime=2006-01-02T15:04:05Z07:00"`
+	AssigneeID      *string                `json:"assignee_id" validate:"omitempty,uuid"`
+	AssigneeIDs     []string               `json:"assignee_ids" validate:"dive,uuid"`
+	EstimateMinutes *int                   `json:"estimate_minutes" validate:"omitempty,min=0"`
+	StoryPoints     *int                   `json:"story_points" validate:"omitempty,min=0"`
+	Labels          []LabelDTO             `json:"labels" validate:"dive"`
+	CustomFields    map[string]interface{} `json:"custom_fields,omitempty"`
 }
 
 type UpdateTaskRequest struct {
-	Title       *string     `json:"title,omitempty"`
-	Description *string     `json:"description,omitempty"`
-	Priority    *string     `json:"priority,omitempty"`
-	DueDate     *string     `json:"due_date,omitempty"`
-	AssigneeID  *string     `json:"assignee_id,omitempty"`
-	Labels      *[]LabelDTO `json:"labels,omitempty"`
+	Title           *string                `json:"title,omitempty"`
+	Description     *string                `json:"description,omitempty"`
+	Priority        *string                `json:"priority,omitempty"`
+	Status          *string                `json:"status,omitempty"`
+	DueDate         *string                `json:"due_date,omitempty"`
+	AssigneeID      *string                `json:"assignee_id,omitempty"`
+	AssigneeIDs     *[]string              `json:"assignee_ids,omitempty"`
+	EstimateMinutes *int                   `json:"estimate_minutes,omitempty"`
+	StoryPoints     *int                   `json:"story_points,omitempty"`
+	Labels          *[]LabelDTO            `json:"labels,omitempty"`
+	CustomFields    map[string]interface{} `json:"custom_fields,omitempty"`
+}
+
+type TaskListQuery struct {
+	AssigneeID string `query:"assignee_id"`
+	CreatedBy  string `query:"created_by"`
+	Priority   string `query:"priority"`
+	Status     string `query:"status"`
+	Label      string `query:"label"`
+	SprintID   string `query:"sprint_id"`
+	DueFrom    string `query:"due_from"`
+	DueTo      string `query:"due_to"`
+	Overdue    bool   `query:"overdue"`
+	Search     string `query:"q"`
+	Sort       string `query:"sort"`
+	Order      string `query:"order"`
+	Cursor     string `query:"cursor"`
+	Limit      int    `query:"limit"`
+
+	// CustomFields holds the cf.<field_id>=value filters, collected by the handler
+	CustomFields map[string]string `query:"-"`
+}
+
+type TaskListResponse struct {
+	Tasks      []TaskResponse `json:"tasks"`
+	NextCursor *string        `json:"next_cursor,omitempty"`
+}
+
+type MyTaskListQuery struct {
+	Due      string `query:"due"`
+	Role     string `query:"role"`
+	Priority string `query:"priority"`
+	Status   string `query:"status"`
+	TimeZone string `query:"tz"`
+	Sort     string `query:"sort"`
+	Order    string `query:"order"`
+	Cursor   string `query:"cursor"`
+	Limit    int    `query:"limit"`
+}
+
+type MyTaskResponse struct {
+	TaskResponse
+	Board   BoardSummary   `json:"board"`
+	Project ProjectSummary `json:"project"`
+}
+
+type MyTaskListResponse struct {
+	Tasks      []MyTaskResponse `json:"tasks"`
+	NextCursor *string          `json:"next_cursor,omitempty"`
 }
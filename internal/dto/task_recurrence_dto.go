@@ -0,0 +1,26 @@
+package dto
+
+import "time"
+
+type SetRecurrenceRequest struct {
+	Frequency string   `json:"frequency" validate:"omitempty,oneof=daily weekly monthly"`
+	Interval  int      `json:"interval,omitempty"`
+	Weekdays  []string `json:"weekdays,omitempty"`
+	MonthDay  int      `json:"month_day,omitempty"`
+	RRule     string   `json:"rrule,omitempty"`
+	StartAt   *string  `json:"start_at,omitempty"`
+}
+
+type RecurrenceResponse struct {
+	ID         string    `json:"id"`
+	TemplateID string    `json:"template_id"`
+	BoardID    string    `json:"board_id"`
+	Rule       string    `json:"rule"`
+	StartAt    time.Time `json:"start_at"`
+	NextRunAt  time.Time `json:"next_run_at"`
+	LastTaskID *string   `json:"last_task_id,omitempty"`
+	IsActive   bool      `json:"is_active"`
+	CreatedBy  string    `json:"created_by"`
+	CreatedAt  time.Time `json:"created_at"`
+	UpdatedAt  time.Time `json:"updated_at"`
+}
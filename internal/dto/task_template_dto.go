@@ -0,0 +1,51 @@
+package dto
+
+import "time"
+
+type CreateTaskTemplateRequest struct {
+	Name              string     `json:"name" validate:"required"`
+	TitlePattern      string     `json:"title_pattern" validate:"required"`
+	Description       string     `json:"description"`
+	Priority          string     `json:"priority" validate:"omitempty,oneof=low medium high urgent"`
+	Labels            []LabelDTO `json:"labels" validate:"dive"`
+	Checklist         []string   `json:"checklist"`
+	DefaultAssigneeID *string    `json:"default_assignee_id" validate:"omitempty,uuid"`
+}
+
+type UpdateTaskTemplateRequest struct {
+	Name              *string     `json:"name,omitempty"`
+	TitlePattern      *string     `json:"title_pattern,omitempty"`
+	Description       *string     `json:"description,omitempty"`
+	Priority          *string     `json:"priority,omitempty"`
+	Labels            *[]LabelDTO `json:"labels,omitempty"`
+	Checklist         *[]string   `json:"checklist,omitempty"`
+	DefaultAssigneeID *string     `json:"default_assignee_id,omitempty"`
+}
+
+type TaskTemplateResponse struct {
+	ID              string     `json:"id"`
+	ProjectID       string     `json:"project_id"`
+	Name            string     `json:"name"`
+	TitlePattern    string     `json:"title_pattern"`
+	Description     string     `json:"description,omitempty"`
+	Priority        string     `json:"priority"`
+	Labels          []LabelDTO `json:"labels"`
+	Checklist       []string   `json:"checklist"`
+	DefaultAssignee *UserBasic `json:"default_assignee,omitempty"`
+	CreatedBy       string     `json:"created_by"`
+	CreatedAt       time.Time  `json:"created_at"`
+	UpdatedAt       time.Time  `json:"updated_at"`
+}
+
+// CreateTaskFromTemplateRequest creates a task from a template; Variables override or extend
+// the built-in template variables and the remaining fields override the template defaults
+type CreateTaskFromTemplateRequest struct {
+	TemplateID   string                 `json:"template_id" validate:"required,uuid"`
+	Variables    map[string]string      `json:"variables,omitempty"`
+	Title        *string                `json:"title,omitempty"`
+	Status       string                 `json:"status" validate:"omitempty,oneof=todo in_progress done"`
+	DueDate      *string                `json:"due_date" validate:"omitempty,datetime=2006-01-02T15:04:05Z07:00"`
+	AssigneeIDs  []string               `json:"assignee_ids" validate:"dive,uuid"`
+	TimeZone     string                 `json:"tz,omitempty"`
+	CustomFields map[string]interface{} `json:"custom_fields,omitempty"`
+}
@@ -0,0 +1,56 @@
+package dto
+
+import "time"
+
+type CreateTimeEntryRequest struct {
+	StartedAt       string  `json:"started_at" validate:"required"`
+	EndedAt         *string `json:"ended_at,omitempty"`
+	DurationMinutes *int    `json:"duration_minutes,omitempty"`
+	Note            string  `json:"note"`
+}
+
+type StartTimerRequest struct {
+	Note string `json:"note"`
+}
+
+type TimeEntryResponse struct {
+	ID              string     `json:"id"`
+	TaskID          string     `json:"task_id"`
+	User            UserBasic  `json:"user"`
+	StartedAt       time.Time  `json:"started_at"`
+	EndedAt         *time.Time `json:"ended_at,omitempty"`
+	DurationSeconds int64      `json:"duration_seconds"`
+	Note            string     `json:"note"`
+	IsRunning       bool       `json:"is_running"`
+	CreatedAt       time.Time  `json:"created_at"`
+}
+
+type TaskTimeEntriesResponse struct {
+	TaskID          string              `json:"task_id"`
+	EstimateMinutes *int                `json:"estimate_minutes,omitempty"`
+	TotalSeconds    int64               `json:"total_seconds"`
+	Entries         []TimeEntryResponse `json:"entries"`
+}
+
+type TimeReportQuery struct {
+	From    string `query:"from"`
+	To      string `query:"to"`
+	GroupBy string `query:"group_by"`
+	UserID  string `query:"user_id"`
+}
+
+type TimeReportGroup struct {
+	ID              string `json:"id"`
+	Name            string `json:"name"`
+	TotalSeconds    int64  `json:"total_seconds"`
+	EntryCount      int64  `json:"entry_count"`
+	EstimateMinutes *int   `json:"estimate_minutes,omitempty"`
+}
+
+type TimeReportResponse struct {
+	From         *time.Time        `json:"from,omitempty"`
+	To           *time.Time        `json:"to,omitempty"`
+	GroupBy      string            `json:"group_by"`
+	TotalSeconds int64             `json:"total_seconds"`
+	Groups       []TimeReportGroup `json:"groups"`
+}
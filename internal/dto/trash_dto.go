@@ -0,0 +1,14 @@
+package dto
+
+import "time"
+
+type TrashItemResponse struct {
+	ID        string    `json:"id"`
+	Type      string    `json:"type"`
+	Name      string    `json:"name"`
+	ProjectID string    `json:"project_id"`
+	BoardID   *string   `json:"board_id,omitempty"`
+	TaskID    *string   `json:"task_id,omitempty"`
+	DeletedAt time.Time `json:"deleted_at"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
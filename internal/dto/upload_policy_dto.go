@@ -0,0 +1,29 @@
+package dto
+
+import "time"
+
+type UpdateUploadPolicyRequest struct {
+	MaxFileSize      *int64    `json:"max_file_size,omitempty"`
+	StorageQuota     *int64    `json:"storage_quota,omitempty"`
+	AllowedMimeTypes *[]string `json:"allowed_mime_types,omitempty"`
+	DeniedMimeTypes  *[]string `json:"denied_mime_types,omitempty"`
+}
+
+// UploadPolicyResponse reports the effective limits of a project; sizes are in bytes and a
+// storage quota of 0 means unlimited
+type UploadPolicyResponse struct {
+	ProjectID        string               `json:"project_id"`
+	MaxFileSize      int64                `json:"max_file_size"`
+	StorageQuota     int64                `json:"storage_quota"`
+	AllowedMimeTypes []string             `json:"allowed_mime_types"`
+	DeniedMimeTypes  []string             `json:"denied_mime_types"`
+	IsDefault        bool                 `json:"is_default"`
+	UpdatedAt        *time.Time           `json:"updated_at,omitempty"`
+	Usage            StorageUsageResponse `json:"usage"`
+}
+
+type StorageUsageResponse struct {
+	UsedBytes       int64  `json:"used_bytes"`
+	AttachmentCount int64  `json:"attachment_count"`
+	RemainingBytes  *int64 `json:"remaining_bytes,omitempty"`
+}
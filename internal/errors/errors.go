@@ -21,11 +21,14 @@ var (
 )
 
 var (
-	ErrTaskNotFound     = errors.New("task not found")
-	ErrUnauthorizedTask = errors.New("unauthorized: only project members can manage tasks")
-	ErrInvalidTaskData  = errors.New("invalid task data")
-	ErrBoardNotFound    = errors.New("board not found")
-	ErrAssigneeNotFound = errors.New("assignee not found")
+	ErrTaskNotFound      = errors.New("task not found")
+	ErrUnauthorizedTask  = errors.New("unauthorized: only project members can manage tasks")
+	ErrInvalidTaskData   = errors.New("invalid task data")
+	ErrBoardNotFound     = errors.New("board not found")
+	ErrAssigneeNotFound  = errors.New("assignee not found")
+	ErrAssigneeNotMember = errors.New("assignee is not a member of this project")
+	ErrInvalidTaskQuery  = errors.New("invalid task query")
+	ErrInvalidCursor     = errors.New("invalid cursor")
 )
 
 var (
@@ -45,4 +48,85 @@ var (
 var (
 	ErrCommentNotFound    = errors.New("comment not found")
 	ErrCannotReplyToReply = errors.New("cannot reply to a reply directly")
+	ErrEmptyComment       = errors.New("comment content is required")
+	ErrNotCommentAuthor   = errors.New("only the author can edit this comment")
+)
+
+var (
+	ErrEmptySearchQuery  = errors.New("search query is required")
+	ErrInvalidSearchType = errors.New("invalid search type")
+)
+
+var (
+	ErrTimeEntryNotFound     = errors.New("time entry not found")
+	ErrInvalidTimeEntry      = errors.New("invalid time entry")
+	ErrTimerAlreadyRunning   = errors.New("a timer is already running")
+	ErrNoRunningTimer        = errors.New("no running timer")
+	ErrUnauthorizedTimeEntry = errors.New("unauthorized: only the author or project owner can manage this time entry")
+	ErrInvalidReportQuery    = errors.New("invalid report query")
+)
+
+var (
+	ErrSprintNotFound     = errors.New("sprint not found")
+	ErrInvalidSprintData  = errors.New("invalid sprint data")
+	ErrInvalidSprintState = errors.New("sprint is not in a valid state for this action")
+	ErrActiveSprintExists = errors.New("project already has an active sprint")
+	ErrTaskNotInProject   = errors.New("task does not belong to this project")
+)
+
+var (
+	ErrRecurrenceNotFound = errors.New("recurrence not found")
+	ErrInvalidRecurrence  = errors.New("invalid recurrence rule")
+)
+
+var (
+	ErrInvalidAnalyticsQuery = errors.New("invalid analytics query")
+)
+
+var (
+	ErrCustomFieldNotFound     = errors.New("custom field not found")
+	ErrInvalidCustomField      = errors.New("invalid custom field definition")
+	ErrCustomFieldExists       = errors.New("a custom field with this name already exists")
+	ErrInvalidCustomFieldValue = errors.New("invalid custom field value")
+)
+
+var (
+	ErrTaskTemplateNotFound = errors.New("task template not found")
+	ErrInvalidTaskTemplate  = errors.New("invalid task template")
+	ErrTaskTemplateExists   = errors.New("a task template with this name already exists")
+)
+
+var (
+	ErrInvalidProjectCopy = errors.New("invalid project copy request")
+)
+
+var (
+	ErrProjectArchived = errors.New("project or board is archived and read-only")
+)
+
+var (
+	ErrTrashItemNotFound  = errors.New("item not found in trash")
+	ErrTrashParentDeleted = errors.New("the item's parent is deleted and must be restored first")
+)
+
+var (
+	ErrFileNotFound = errors.New("file not found")
+)
+
+var (
+	ErrInvalidUploadPolicy  = errors.New("invalid upload policy")
+	ErrFileTooLarge         = errors.New("file exceeds the project's size limit")
+	ErrMimeTypeNotAllowed   = errors.New("file type is not allowed in this project")
+	ErrStorageQuotaExceeded = errors.New("project storage quota exceeded")
+	ErrFileInfected         = errors.New("file was flagged by the malware scanner")
+)
+
+var (
+	ErrAttachmentVersionNotFound = errors.New("attachment version not found")
+	ErrLastAttachmentVersion     = errors.New("the only version of an attachment cannot be deleted")
+)
+
+var (
+	ErrAvatarTooLarge    = errors.New("avatar exceeds the size limit")
+	ErrInvalidAvatarType = errors.New("avatar must be a JPEG, PNG, GIF or WebP image")
 )
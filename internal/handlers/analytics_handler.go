@@ -0,0 +1,77 @@
+package handlers
+
+import (
+	"errors"
+
+	"github.com/Hann-arc/task-management-backend/internal/dto"
+	apperrors "github.com/Hann-arc/task-management-backend/internal/errors"
+	"github.com/Hann-arc/task-management-backend/internal/services"
+	"github.com/Hann-arc/task-management-backend/internal/utils"
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+type AnalyticsHandler struct {
+	service *services.AnalyticsService
+}
+
+// NewAnalyticsHandler creates a new instance of AnalyticsHandler
+func NewAnalyticsHandler(service *services.AnalyticsService) *AnalyticsHandler {
+	return &AnalyticsHandler{service: service}
+}
+
+// GetProjectAnalytics returns the burndown, cumulative flow and cycle/lead time of a project
+func (h *AnalyticsHandler) GetProjectAnalytics(c *fiber.Ctx) error {
+	userID := c.Locals("user_id").(uuid.UUID)
+	projectID, err := uuid.Parse(c.Params("projectId"))
+	if err != nil {
+		return utils.Error(c, fiber.StatusBadRequest, "Invalid project ID", "")
+	}
+
+	var query dto.AnalyticsQuery
+	if err := c.QueryParser(&query); err != nil {
+		return utils.Error(c, fiber.StatusBadRequest, "Invalid query parameters", "")
+	}
+
+	analytics, err := h.service.GetProjectAnalytics(projectID, userID, &query)
+	if err != nil {
+		return h.handleError(c, err)
+	}
+
+	return utils.Success(c, "Analytics fetched successfully", analytics)
+}
+
+// GetSprintAnalytics returns the burndown, cumulative flow and cycle/lead time of a sprint
+func (h *AnalyticsHandler) GetSprintAnalytics(c *fiber.Ctx) error {
+	userID := c.Locals("user_id").(uuid.UUID)
+	sprintID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return utils.Error(c, fiber.StatusBadRequest, "Invalid sprint ID", "")
+	}
+
+	var query dto.AnalyticsQuery
+	if err := c.QueryParser(&query); err != nil {
+		return utils.Error(c, fiber.StatusBadRequest, "Invalid query parameters", "")
+	}
+
+	analytics, err := h.service.GetSprintAnalytics(sprintID, userID, &query)
+	if err != nil {
+		return h.handleError(c, err)
+	}
+
+	return utils.Success(c, "Analytics fetched successfully", analytics)
+}
+
+// handleError maps analytics errors to HTTP responses
+func (h *AnalyticsHandler) handleError(c *fiber.Ctx, err error) error {
+	switch {
+	case errors.Is(err, apperrors.ErrSprintNotFound):
+		return utils.Error(c, fiber.StatusNotFound, "Sprint not found", "")
+	case errors.Is(err, apperrors.ErrInvalidAnalyticsQuery):
+		return utils.Error(c, fiber.StatusBadRequest, "Invalid analytics query", "")
+	case errors.Is(err, apperrors.ErrUnauthorizedProject):
+		return utils.Error(c, fiber.StatusForbidden, "You are not a member of this project", "")
+	default:
+		return utils.Error(c, fiber.StatusInternalServerError, "Failed to compute analytics", err.Error())
+	}
+}
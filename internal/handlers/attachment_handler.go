@@ -2,6 +2,8 @@ package handlers
 
 import (
 	"errors"
+	"mime"
+	"strconv"
 
 	apperrors "github.com/Hann-arc/task-management-backend/internal/errors"
 	"github.com/Hann-arc/task-management-backend/internal/services"
@@ -33,21 +35,93 @@ func (h *AttachmentHandler) UploadAttachment(c *fiber.Ctx) error {
 		return utils.Error(c, fiber.StatusBadRequest, "File is required", "")
 	}
 
-	if file.Size > 10*1024*1024 {
-		return utils.Error(c, fiber.StatusBadRequest, "File too large (max 10MB)", "")
+	attachment, err := h.service.UploadAttachment(taskID, userID, file)
+	if err != nil {
+		return handleUploadError(c, err, "Failed to upload attachment")
 	}
 
-	attachment, err := h.service.UploadAttachment(taskID, userID, file)
+	return utils.Created(c, "Attachment uploaded successfully", attachment)
+}
+
+// UploadVersion handles the uploading of a new version of an attachment
+func (h *AttachmentHandler) UploadVersion(c *fiber.Ctx) error {
+	userID := c.Locals("user_id").(uuid.UUID)
+	attachmentID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return utils.Error(c, fiber.StatusBadRequest, "Invalid attachment ID", "")
+	}
+
+	file, err := c.FormFile("file")
+	if err != nil {
+		return utils.Error(c, fiber.StatusBadRequest, "File is required", "")
+	}
+
+	version, err := h.service.UploadVersion(attachmentID, userID, file)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return utils.Error(c, fiber.StatusNotFound, "Attachment not found", "")
+		}
+		return handleUploadError(c, err, "Failed to upload attachment version")
+	}
+
+	return utils.Created(c, "Attachment version uploaded successfully", version)
+}
+
+// GetVersions retrieves the version history of an attachment
+func (h *AttachmentHandler) GetVersions(c *fiber.Ctx) error {
+	userID := c.Locals("user_id").(uuid.UUID)
+	attachmentID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return utils.Error(c, fiber.StatusBadRequest, "Invalid attachment ID", "")
+	}
+
+	versions, err := h.service.GetVersions(attachmentID, userID)
 	if err != nil {
 		switch {
+		case errors.Is(err, gorm.ErrRecordNotFound):
+			return utils.Error(c, fiber.StatusNotFound, "Attachment not found", "")
 		case errors.Is(err, apperrors.ErrUnauthorizedProject):
 			return utils.Error(c, fiber.StatusForbidden, "You are not a member of this project", "")
 		default:
-			return utils.Error(c, fiber.StatusInternalServerError, "Failed to upload attachment", err.Error())
+			return utils.Error(c, fiber.StatusInternalServerError, "Failed to fetch attachment versions", err.Error())
 		}
 	}
 
-	return utils.Created(c, "Attachment uploaded successfully", attachment)
+	return utils.Success(c, "Attachment versions fetched successfully", versions)
+}
+
+// DeleteVersion handles the deletion of a single version of an attachment
+func (h *AttachmentHandler) DeleteVersion(c *fiber.Ctx) error {
+	userID := c.Locals("user_id").(uuid.UUID)
+	attachmentID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return utils.Error(c, fiber.StatusBadRequest, "Invalid attachment ID", "")
+	}
+	number, err := strconv.Atoi(c.Params("version"))
+	if err != nil {
+		return utils.Error(c, fiber.StatusBadRequest, "Invalid version number", "")
+	}
+
+	if err := h.service.DeleteVersion(attachmentID, userID, number); err != nil {
+		switch {
+		case errors.Is(err, gorm.ErrRecordNotFound):
+			return utils.Error(c, fiber.StatusNotFound, "Attachment not found", "")
+		case errors.Is(err, apperrors.ErrAttachmentVersionNotFound):
+			return utils.Error(c, fiber.StatusNotFound, "Attachment version not found", "")
+		case errors.Is(err, apperrors.ErrUnauthorizedProject):
+			return utils.Error(c, fiber.StatusForbidden, "You are not a member of this project", "")
+		case errors.Is(err, apperrors.ErrUnauthorizedOwnerOnly):
+			return utils.Error(c, fiber.StatusForbidden, "You can only delete your own versions or you are not project owner", "")
+		case errors.Is(err, apperrors.ErrLastAttachmentVersion):
+			return utils.Error(c, fiber.StatusConflict, "The only version of an attachment cannot be deleted", "")
+		case errors.Is(err, apperrors.ErrProjectArchived):
+			return utils.Error(c, fiber.StatusConflict, "Project or board is archived", "")
+		default:
+			return utils.Error(c, fiber.StatusInternalServerError, "Failed to delete attachment version", err.Error())
+		}
+	}
+
+	return utils.Success(c, "Attachment version deleted successfully", nil)
 }
 
 // GetAttachments retrieves all attachments for a specific task
@@ -83,8 +157,12 @@ func (h *AttachmentHandler) DeleteAttachment(c *fiber.Ctx) error {
 		switch {
 		case errors.Is(err, gorm.ErrRecordNotFound):
 			return utils.Error(c, fiber.StatusNotFound, "Attachment not found", "")
+		case errors.Is(err, apperrors.ErrUnauthorizedProject):
+			return utils.Error(c, fiber.StatusForbidden, "You are not a member of this project", "")
 		case errors.Is(err, apperrors.ErrUnauthorizedOwnerOnly):
 			return utils.Error(c, fiber.StatusForbidden, "You can only delete your own attachments or you are not project owner", "")
+		case errors.Is(err, apperrors.ErrProjectArchived):
+			return utils.Error(c, fiber.StatusConflict, "Project or board is archived", "")
 		default:
 			return utils.Error(c, fiber.StatusInternalServerError, "Failed to delete attachment", err.Error())
 		}
@@ -92,3 +170,126 @@ func (h *AttachmentHandler) DeleteAttachment(c *fiber.Ctx) error {
 
 	return utils.Success(c, "Attachment deleted successfully", nil)
 }
+
+// DownloadAttachment streams the file of an attachment
+func (h *AttachmentHandler) DownloadAttachment(c *fiber.Ctx) error {
+	return h.download(c, services.DownloadVariantFile)
+}
+
+// DownloadThumbnail streams the thumbnail of an image attachment
+func (h *AttachmentHandler) DownloadThumbnail(c *fiber.Ctx) error {
+	return h.download(c, services.DownloadVariantThumbnail)
+}
+
+// download serves an attachment variant to an authenticated user or the holder of a signed link
+func (h *AttachmentHandler) download(c *fiber.Ctx, variant string) error {
+	attachmentID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return utils.Error(c, fiber.StatusBadRequest, "Invalid attachment ID", "")
+	}
+
+	userID, ok := c.Locals("user_id").(uuid.UUID)
+	if !ok {
+		userID, err = utils.VerifyDownloadSignature(attachmentID, variant, c.Query("uid"), c.Query("expires"), c.Query("sig"))
+		if err != nil {
+			return utils.Error(c, fiber.StatusForbidden, "Invalid or expired download link", "")
+		}
+	}
+
+	content, attachment, err := h.service.OpenAttachment(attachmentID, userID, variant)
+	if err != nil {
+		switch {
+		case errors.Is(err, apperrors.ErrFileNotFound):
+			return utils.Error(c, fiber.StatusNotFound, "File not found", "")
+		case errors.Is(err, apperrors.ErrUnauthorizedProject):
+			return utils.Error(c, fiber.StatusForbidden, "You are not a member of this project", "")
+		default:
+			return utils.Error(c, fiber.StatusInternalServerError, "Failed to download attachment", err.Error())
+		}
+	}
+
+	// files are always downloaded so that uploaded HTML or SVG never renders in our origin
+	contentType, fileName, disposition := attachment.MimeType, attachment.FileName, "attachment"
+	if variant == services.DownloadVariantThumbnail {
+		contentType, fileName, disposition = "image/jpeg", "thumbnail.jpg", "inline"
+	}
+	if contentType == "" {
+		contentType = "application/octet-stream"
+	}
+
+	c.Set(fiber.HeaderContentType, contentType)
+	c.Set(fiber.HeaderCacheControl, "private, no-store")
+	c.Set("X-Content-Type-Options", "nosniff")
+	if fileName != "" {
+		c.Set(fiber.HeaderContentDisposition, mime.FormatMediaType(disposition, map[string]string{"filename": fileName}))
+	}
+
+	return c.SendStream(content)
+}
+
+// DownloadVersion streams the file of a specific version of an attachment to an authenticated
+// user or the holder of a signed link
+func (h *AttachmentHandler) DownloadVersion(c *fiber.Ctx) error {
+	attachmentID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return utils.Error(c, fiber.StatusBadRequest, "Invalid attachment ID", "")
+	}
+	number, err := strconv.Atoi(c.Params("version"))
+	if err != nil {
+		return utils.Error(c, fiber.StatusBadRequest, "Invalid version number", "")
+	}
+
+	userID, ok := c.Locals("user_id").(uuid.UUID)
+	if !ok {
+		userID, err = utils.VerifyDownloadSignature(attachmentID, services.VersionDownloadVariant(number), c.Query("uid"), c.Query("expires"), c.Query("sig"))
+		if err != nil {
+			return utils.Error(c, fiber.StatusForbidden, "Invalid or expired download link", "")
+		}
+	}
+
+	content, version, err := h.service.OpenAttachmentVersion(attachmentID, userID, number)
+	if err != nil {
+		switch {
+		case errors.Is(err, apperrors.ErrFileNotFound):
+			return utils.Error(c, fiber.StatusNotFound, "File not found", "")
+		case errors.Is(err, apperrors.ErrUnauthorizedProject):
+			return utils.Error(c, fiber.StatusForbidden, "You are not a member of this project", "")
+		default:
+			return utils.Error(c, fiber.StatusInternalServerError, "Failed to download attachment", err.Error())
+		}
+	}
+
+	contentType := version.MimeType
+	if contentType == "" {
+		contentType = "application/octet-stream"
+	}
+
+	c.Set(fiber.HeaderContentType, contentType)
+	c.Set(fiber.HeaderCacheControl, "private, no-store")
+	c.Set("X-Content-Type-Options", "nosniff")
+	if version.FileName != "" {
+		c.Set(fiber.HeaderContentDisposition, mime.FormatMediaType("attachment", map[string]string{"filename": version.FileName}))
+	}
+
+	return c.SendStream(content)
+}
+
+// handleUploadError maps the errors of an attachment upload to HTTP responses
+func handleUploadError(c *fiber.Ctx, err error, message string) error {
+	switch {
+	case errors.Is(err, apperrors.ErrUnauthorizedProject):
+		return utils.Error(c, fiber.StatusForbidden, "You are not a member of this project", "")
+	case errors.Is(err, apperrors.ErrProjectArchived):
+		return utils.Error(c, fiber.StatusConflict, "Project or board is archived", "")
+	case errors.Is(err, apperrors.ErrFileTooLarge):
+		return utils.Error(c, fiber.StatusRequestEntityTooLarge, "File exceeds the project's size limit", "")
+	case errors.Is(err, apperrors.ErrStorageQuotaExceeded):
+		return utils.Error(c, fiber.StatusRequestEntityTooLarge, "Project storage quota exceeded", "")
+	case errors.Is(err, apperrors.ErrMimeTypeNotAllowed):
+		return utils.Error(c, fiber.StatusUnsupportedMediaType, "File type is not allowed in this project", "")
+	case errors.Is(err, apperrors.ErrFileInfected):
+		return utils.Error(c, fiber.StatusUnprocessableEntity, "File was flagged by the malware scanner", "")
+	default:
+		return utils.Error(c, fiber.StatusInternalServerError, message, err.Error())
+	}
+}
@@ -8,6 +8,7 @@ import (
 	"github.com/Hann-arc/task-management-backend/internal/utils"
 	"github.com/gofiber/fiber/v2"
 	"github.com/google/uuid"
+	"gorm.io/gorm"
 )
 
 type BoardHandler struct {
@@ -39,20 +40,23 @@ func (h *BoardHandler) CreateBoard(c *fiber.Ctx) error {
 		if errors.Is(err, apperrors.ErrUnauthorizedBoardAction) {
 			return utils.Error(c, fiber.StatusForbidden, "You are not authorized to create boards in this project", "")
 		}
+		if errors.Is(err, apperrors.ErrProjectArchived) {
+			return utils.Error(c, fiber.StatusConflict, "Project is archived", "")
+		}
 		return utils.Error(c, fiber.StatusInternalServerError, "Failed to create board", err.Error())
 	}
 
 	return utils.Created(c, "Board created successfully", board)
 }
 
-// GetBoards retrieves all boards for a given project
+// GetBoards retrieves the boards of a given project; ?archived=true lists the archived ones
 func (h *BoardHandler) GetBoards(c *fiber.Ctx) error {
 	projectID, err := uuid.Parse(c.Params("projectId"))
 	if err != nil {
 		return utils.Error(c, fiber.StatusBadRequest, "Invalid project ID", "")
 	}
 
-	boards, err := h.service.GetBoards(projectID)
+	boards, err := h.service.GetBoards(projectID, c.QueryBool("archived"))
 	if err != nil {
 		return utils.Error(c, fiber.StatusInternalServerError, "Failed to fetch boards", err.Error())
 	}
@@ -84,6 +88,8 @@ func (h *BoardHandler) UpdateBoard(c *fiber.Ctx) error {
 			return utils.Error(c, fiber.StatusForbidden, "You are not authorized to update this board", "")
 		case errors.Is(err, apperrors.ErrInvalidOrderIndex), errors.Is(err, apperrors.ErrNoFieldsToUpdate):
 			return utils.Error(c, fiber.StatusBadRequest, "Invalid request", err.Error())
+		case errors.Is(err, apperrors.ErrProjectArchived):
+			return utils.Error(c, fiber.StatusConflict, "Board or project is archived", "")
 		default:
 			return utils.Error(c, fiber.StatusInternalServerError, "Failed to update board", err.Error())
 		}
@@ -105,8 +111,59 @@ func (h *BoardHandler) DeleteBoard(c *fiber.Ctx) error {
 		if errors.Is(err, apperrors.ErrUnauthorizedBoardAction) {
 			return utils.Error(c, fiber.StatusForbidden, "You are not authorized to delete this board", "")
 		}
+		if errors.Is(err, apperrors.ErrProjectArchived) {
+			return utils.Error(c, fiber.StatusConflict, "Board or project is archived", "")
+		}
 		return utils.Error(c, fiber.StatusInternalServerError, "Failed to delete board", err.Error())
 	}
 
 	return utils.Success(c, "Board deleted successfully", nil)
 }
+
+// ArchiveBoard archives a board
+func (h *BoardHandler) ArchiveBoard(c *fiber.Ctx) error {
+	boardID, err := uuid.Parse(c.Params("boardId"))
+	if err != nil {
+		return utils.Error(c, fiber.StatusBadRequest, "Invalid board ID", "")
+	}
+
+	userID := c.Locals("user_id").(uuid.UUID)
+
+	board, err := h.service.ArchiveBoard(boardID, userID)
+	if err != nil {
+		return h.handleArchiveError(c, err, "Failed to archive board")
+	}
+
+	return utils.Success(c, "Board archived successfully", board)
+}
+
+// UnarchiveBoard restores an archived board
+func (h *BoardHandler) UnarchiveBoard(c *fiber.Ctx) error {
+	boardID, err := uuid.Parse(c.Params("boardId"))
+	if err != nil {
+		return utils.Error(c, fiber.StatusBadRequest, "Invalid board ID", "")
+	}
+
+	userID := c.Locals("user_id").(uuid.UUID)
+
+	board, err := h.service.UnarchiveBoard(boardID, userID)
+	if err != nil {
+		return h.handleArchiveError(c, err, "Failed to unarchive board")
+	}
+
+	return utils.Success(c, "Board unarchived successfully", board)
+}
+
+// handleArchiveError maps board archiving errors to HTTP responses
+func (h *BoardHandler) handleArchiveError(c *fiber.Ctx, err error, message string) error {
+	switch {
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		return utils.Error(c, fiber.StatusNotFound, "Board not found", "")
+	case errors.Is(err, apperrors.ErrUnauthorizedBoardAction):
+		return utils.Error(c, fiber.StatusForbidden, "You are not authorized to archive this board", "")
+	case errors.Is(err, apperrors.ErrProjectArchived):
+		return utils.Error(c, fiber.StatusConflict, "Project is archived", "")
+	default:
+		return utils.Error(c, fiber.StatusInternalServerError, message, err.Error())
+	}
+}
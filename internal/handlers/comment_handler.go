@@ -2,6 +2,7 @@ package handlers
 
 import (
 	"errors"
+	"mime/multipart"
 
 	"github.com/Hann-arc/task-management-backend/internal/dto"
 	apperrors "github.com/Hann-arc/task-management-backend/internal/errors"
@@ -35,17 +36,10 @@ func (h *CommentHandler) CreateMainComment(c *fiber.Ctx) error {
 		return utils.Error(c, fiber.StatusBadRequest, "Invalid body request", "")
 	}
 
-	comment, err := h.service.CreateMainComment(taskID, userID, req.Content)
+	comment, err := h.service.CreateMainComment(taskID, userID, req.Content, commentFiles(c))
 
 	if err != nil {
-		switch {
-
-		case errors.Is(err, apperrors.ErrUnauthorizedProject):
-			return utils.Error(c, fiber.StatusForbidden, "You are not a member of this project", "")
-		default:
-			return utils.Error(c, fiber.StatusInternalServerError, "Failed to create comment", err.Error())
-		}
-
+		return handleUploadError(c, err, "Failed to create comment")
 	}
 
 	return utils.Created(c, "Comment created successfully", comment)
@@ -66,19 +60,43 @@ func (h *CommentHandler) CreateReply(c *fiber.Ctx) error {
 		return utils.Error(c, fiber.StatusBadRequest, "Invalid request body", "")
 	}
 
-	comment, err := h.service.CreateReply(commentID, userID, req.Content)
+	comment, err := h.service.CreateReply(commentID, userID, req.Content, commentFiles(c))
+	if err != nil {
+		if errors.Is(err, apperrors.ErrCommentNotFound) {
+			return utils.Error(c, fiber.StatusNotFound, "Comment not found", "")
+		}
+		return handleUploadError(c, err, "Failed to create reply")
+	}
+
+	return utils.Created(c, "Reply created successfully", comment)
+}
+
+// AddAttachments handles attaching files to an existing comment
+func (h *CommentHandler) AddAttachments(c *fiber.Ctx) error {
+	userID := c.Locals("user_id").(uuid.UUID)
+	commentID, err := uuid.Parse(c.Params("commentId"))
+	if err != nil {
+		return utils.Error(c, fiber.StatusBadRequest, "Invalid comment id", "")
+	}
+
+	files := commentFiles(c)
+	if len(files) == 0 {
+		return utils.Error(c, fiber.StatusBadRequest, "At least one file is required", "")
+	}
+
+	attachments, err := h.service.AddAttachments(commentID, userID, files)
 	if err != nil {
 		switch {
 		case errors.Is(err, apperrors.ErrCommentNotFound):
 			return utils.Error(c, fiber.StatusNotFound, "Comment not found", "")
-		case errors.Is(err, apperrors.ErrUnauthorizedProject):
-			return utils.Error(c, fiber.StatusForbidden, "You are not a member of this project", "")
+		case errors.Is(err, apperrors.ErrUnauthorizedOwnerOnly):
+			return utils.Error(c, fiber.StatusForbidden, "You can only attach files to your own comments", "")
 		default:
-			return utils.Error(c, fiber.StatusInternalServerError, "Failed to create reply", err.Error())
+			return handleUploadError(c, err, "Failed to attach files")
 		}
 	}
 
-	return utils.Created(c, "Reply created successfully", comment)
+	return utils.Created(c, "Files attached successfully", attachments)
 }
 
 // GetComments retrieves all comments for a given task
@@ -102,6 +120,63 @@ func (h *CommentHandler) GetComments(c *fiber.Ctx) error {
 	return utils.Success(c, "Comments fetched successfully", comments)
 }
 
+// UpdateComment handles editing the content of a comment
+func (h *CommentHandler) UpdateComment(c *fiber.Ctx) error {
+	userID := c.Locals("user_id").(uuid.UUID)
+	commentID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return utils.Error(c, fiber.StatusBadRequest, "Invalid comment ID", "")
+	}
+
+	var req dto.UpdateCommentRequest
+	if err := c.BodyParser(&req); err != nil {
+		return utils.Error(c, fiber.StatusBadRequest, "Invalid request body", "")
+	}
+
+	comment, err := h.service.UpdateComment(commentID, userID, req.Content)
+	if err != nil {
+		switch {
+		case errors.Is(err, apperrors.ErrEmptyComment):
+			return utils.Error(c, fiber.StatusBadRequest, "Comment content is required", "")
+		case errors.Is(err, apperrors.ErrCommentNotFound):
+			return utils.Error(c, fiber.StatusNotFound, "Comment not found", "")
+		case errors.Is(err, apperrors.ErrUnauthorizedProject):
+			return utils.Error(c, fiber.StatusForbidden, "You are not a member of this project", "")
+		case errors.Is(err, apperrors.ErrNotCommentAuthor):
+			return utils.Error(c, fiber.StatusForbidden, "You can only edit your own comments", "")
+		case errors.Is(err, apperrors.ErrProjectArchived):
+			return utils.Error(c, fiber.StatusConflict, "Project or board is archived", "")
+		default:
+			return utils.Error(c, fiber.StatusInternalServerError, "Failed to update comment", err.Error())
+		}
+	}
+
+	return utils.Success(c, "Comment updated successfully", comment)
+}
+
+// GetCommentHistory retrieves the edit history of a comment
+func (h *CommentHandler) GetCommentHistory(c *fiber.Ctx) error {
+	userID := c.Locals("user_id").(uuid.UUID)
+	commentID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return utils.Error(c, fiber.StatusBadRequest, "Invalid comment ID", "")
+	}
+
+	history, err := h.service.GetCommentHistory(commentID, userID)
+	if err != nil {
+		switch {
+		case errors.Is(err, apperrors.ErrCommentNotFound):
+			return utils.Error(c, fiber.StatusNotFound, "Comment not found", "")
+		case errors.Is(err, apperrors.ErrUnauthorizedOwnerOnly):
+			return utils.Error(c, fiber.StatusForbidden, "Only the project owner can view the edit history", "")
+		default:
+			return utils.Error(c, fiber.StatusInternalServerError, "Failed to fetch comment history", err.Error())
+		}
+	}
+
+	return utils.Success(c, "Comment history fetched successfully", history)
+}
+
 // DeleteComment handles the deletion of a comment
 func (h *CommentHandler) DeleteComment(c *fiber.Ctx) error {
 	userID := c.Locals("user_id").(uuid.UUID)
@@ -116,6 +191,8 @@ func (h *CommentHandler) DeleteComment(c *fiber.Ctx) error {
 			return utils.Error(c, fiber.StatusNotFound, "Comment not found", "")
 		case errors.Is(err, apperrors.ErrUnauthorizedOwnerOnly):
 			return utils.Error(c, fiber.StatusForbidden, "You can only delete your own comments or you are not project owner", "")
+		case errors.Is(err, apperrors.ErrProjectArchived):
+			return utils.Error(c, fiber.StatusConflict, "Project or board is archived", "")
 		default:
 			return utils.Error(c, fiber.StatusInternalServerError, "Failed to delete comment", err.Error())
 		}
@@ -123,3 +200,12 @@ func (h *CommentHandler) DeleteComment(c *fiber.Ctx) error {
 
 	return utils.Success(c, "Comment deleted successfully", nil)
 }
+
+// commentFiles returns the files sent with a multipart comment request under "files" or "file"
+func commentFiles(c *fiber.Ctx) []*multipart.FileHeader {
+	form, err := c.MultipartForm()
+	if err != nil {
+		return nil
+	}
+	return append(form.File["files"], form.File["file"]...)
+}
@@ -0,0 +1,116 @@
+package handlers
+
+import (
+	"errors"
+
+	"github.com/Hann-arc/task-management-backend/internal/dto"
+	apperrors "github.com/Hann-arc/task-management-backend/internal/errors"
+	"github.com/Hann-arc/task-management-backend/internal/services"
+	"github.com/Hann-arc/task-management-backend/internal/utils"
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+type CustomFieldHandler struct {
+	service *services.CustomFieldService
+}
+
+// NewCustomFieldHandler creates a new instance of CustomFieldHandler
+func NewCustomFieldHandler(service *services.CustomFieldService) *CustomFieldHandler {
+	return &CustomFieldHandler{service: service}
+}
+
+// CreateField defines a new custom field in a project
+func (h *CustomFieldHandler) CreateField(c *fiber.Ctx) error {
+	userID := c.Locals("user_id").(uuid.UUID)
+	projectID, err := uuid.Parse(c.Params("projectId"))
+	if err != nil {
+		return utils.Error(c, fiber.StatusBadRequest, "Invalid project ID", "")
+	}
+
+	var req dto.CreateCustomFieldRequest
+	if err := c.BodyParser(&req); err != nil {
+		return utils.Error(c, fiber.StatusBadRequest, "Invalid request body", "")
+	}
+
+	field, err := h.service.CreateField(projectID, userID, &req)
+	if err != nil {
+		return h.handleError(c, err, "Failed to create custom field")
+	}
+
+	return utils.Created(c, "Custom field created successfully", field)
+}
+
+// GetFields retrieves the custom fields of a project
+func (h *CustomFieldHandler) GetFields(c *fiber.Ctx) error {
+	userID := c.Locals("user_id").(uuid.UUID)
+	projectID, err := uuid.Parse(c.Params("projectId"))
+	if err != nil {
+		return utils.Error(c, fiber.StatusBadRequest, "Invalid project ID", "")
+	}
+
+	fields, err := h.service.GetFields(projectID, userID)
+	if err != nil {
+		return h.handleError(c, err, "Failed to fetch custom fields")
+	}
+
+	return utils.Success(c, "Custom fields fetched successfully", fields)
+}
+
+// UpdateField modifies a custom field definition
+func (h *CustomFieldHandler) UpdateField(c *fiber.Ctx) error {
+	userID := c.Locals("user_id").(uuid.UUID)
+	fieldID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return utils.Error(c, fiber.StatusBadRequest, "Invalid custom field ID", "")
+	}
+
+	var req dto.UpdateCustomFieldRequest
+	if err := c.BodyParser(&req); err != nil {
+		return utils.Error(c, fiber.StatusBadRequest, "Invalid request body", "")
+	}
+
+	field, err := h.service.UpdateField(fieldID, userID, &req)
+	if err != nil {
+		return h.handleError(c, err, "Failed to update custom field")
+	}
+
+	return utils.Success(c, "Custom field updated successfully", field)
+}
+
+// DeleteField removes a custom field and its values
+func (h *CustomFieldHandler) DeleteField(c *fiber.Ctx) error {
+	userID := c.Locals("user_id").(uuid.UUID)
+	fieldID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return utils.Error(c, fiber.StatusBadRequest, "Invalid custom field ID", "")
+	}
+
+	if err := h.service.DeleteField(fieldID, userID); err != nil {
+		return h.handleError(c, err, "Failed to delete custom field")
+	}
+
+	return utils.Success(c, "Custom field deleted successfully", nil)
+}
+
+// handleError maps custom field errors to HTTP responses
+func (h *CustomFieldHandler) handleError(c *fiber.Ctx, err error, message string) error {
+	switch {
+	case errors.Is(err, apperrors.ErrCustomFieldNotFound):
+		return utils.Error(c, fiber.StatusNotFound, "Custom field not found", "")
+	case errors.Is(err, apperrors.ErrInvalidCustomField):
+		return utils.Error(c, fiber.StatusBadRequest, "Invalid custom field data", "")
+	case errors.Is(err, apperrors.ErrNoFieldsToUpdate):
+		return utils.Error(c, fiber.StatusBadRequest, "No fields to update", "")
+	case errors.Is(err, apperrors.ErrCustomFieldExists):
+		return utils.Error(c, fiber.StatusConflict, "A custom field with this name already exists", "")
+	case errors.Is(err, apperrors.ErrUnauthorizedOwnerOnly):
+		return utils.Error(c, fiber.StatusForbidden, "Only the project owner can manage custom fields", "")
+	case errors.Is(err, apperrors.ErrProjectArchived):
+		return utils.Error(c, fiber.StatusConflict, "Project or board is archived", "")
+	case errors.Is(err, apperrors.ErrUnauthorizedProject):
+		return utils.Error(c, fiber.StatusForbidden, "You are not a member of this project", "")
+	default:
+		return utils.Error(c, fiber.StatusInternalServerError, message, err.Error())
+	}
+}
@@ -0,0 +1,39 @@
+package handlers
+
+import (
+	"errors"
+
+	apperrors "github.com/Hann-arc/task-management-backend/internal/errors"
+	"github.com/Hann-arc/task-management-backend/internal/services"
+	"github.com/Hann-arc/task-management-backend/internal/utils"
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+type FileHandler struct {
+	service *services.FileService
+}
+
+// NewFileHandler creates a new instance of FileHandler
+func NewFileHandler(service *services.FileService) *FileHandler {
+	return &FileHandler{service: service}
+}
+
+// DownloadFile serves a file kept by the local storage driver
+func (h *FileHandler) DownloadFile(c *fiber.Ctx) error {
+	userID := c.Locals("user_id").(uuid.UUID)
+
+	filePath, err := h.service.ResolveLocalFile(c.Params("*"), userID)
+	if err != nil {
+		switch {
+		case errors.Is(err, apperrors.ErrFileNotFound):
+			return utils.Error(c, fiber.StatusNotFound, "File not found", "")
+		case errors.Is(err, apperrors.ErrUnauthorizedProject):
+			return utils.Error(c, fiber.StatusForbidden, "You are not a member of this project", "")
+		default:
+			return utils.Error(c, fiber.StatusInternalServerError, "Failed to download file", err.Error())
+		}
+	}
+
+	return c.SendFile(filePath)
+}
@@ -37,11 +37,11 @@ func (h *ProjectHandler) CreateProject(c *fiber.Ctx) error {
 	return utils.Created(c, "Project created successfully", project)
 }
 
-// ListProjects retrieves all projects for the authenticated user
+// ListProjects retrieves the projects of the authenticated user; ?archived=true lists the archived ones
 func (h *ProjectHandler) ListProjects(c *fiber.Ctx) error {
 	userID := c.Locals("user_id").(uuid.UUID)
 
-	projects, err := h.service.GetProjects(userID)
+	projects, err := h.service.GetProjects(userID, c.QueryBool("archived"))
 	if err != nil {
 		return utils.Error(c, fiber.StatusInternalServerError, "Failed to fetch projects", err.Error())
 	}
@@ -73,6 +73,28 @@ func (h *ProjectHandler) GetProject(c *fiber.Ctx) error {
 	return utils.Success(c, "Project fetched successfully", project)
 }
 
+// GetProjectStats returns the dashboard statistics of a project
+func (h *ProjectHandler) GetProjectStats(c *fiber.Ctx) error {
+	projectID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return utils.Error(c, fiber.StatusBadRequest, "Invalid project ID", "")
+	}
+
+	userID := c.Locals("user_id").(uuid.UUID)
+
+	stats, err := h.service.GetProjectStats(projectID, userID)
+	if err != nil {
+		switch {
+		case errors.Is(err, apperrors.ErrUnauthorizedProject):
+			return utils.Error(c, fiber.StatusForbidden, "You are not a member of this project", "")
+		default:
+			return utils.Error(c, fiber.StatusInternalServerError, "Failed to fetch project statistics", err.Error())
+		}
+	}
+
+	return utils.Success(c, "Project statistics fetched successfully", stats)
+}
+
 // UpdateProject updates the details of a specific project
 func (h *ProjectHandler) UpdateProject(c *fiber.Ctx) error {
 	projectID, err := uuid.Parse(c.Params("id"))
@@ -96,6 +118,8 @@ func (h *ProjectHandler) UpdateProject(c *fiber.Ctx) error {
 			return utils.Error(c, fiber.StatusBadRequest, "No fields to update", "")
 		case errors.Is(err, apperrors.ErrProjectNotFound):
 			return utils.Error(c, fiber.StatusNotFound, "Project not found", "")
+		case errors.Is(err, apperrors.ErrProjectArchived):
+			return utils.Error(c, fiber.StatusConflict, "Project is archived", "")
 		default:
 			return utils.Error(c, fiber.StatusInternalServerError, "Failed to update project", err.Error())
 		}
@@ -104,6 +128,52 @@ func (h *ProjectHandler) UpdateProject(c *fiber.Ctx) error {
 	return utils.Success(c, "Project updated successfully", project)
 }
 
+// ArchiveProject archives a specific project
+func (h *ProjectHandler) ArchiveProject(c *fiber.Ctx) error {
+	projectID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return utils.Error(c, fiber.StatusBadRequest, "Invalid project ID", "")
+	}
+
+	userID := c.Locals("user_id").(uuid.UUID)
+
+	project, err := h.service.ArchiveProject(projectID, userID)
+	if err != nil {
+		return h.handleArchiveError(c, err, "Failed to archive project")
+	}
+
+	return utils.Success(c, "Project archived successfully", project)
+}
+
+// UnarchiveProject restores an archived project
+func (h *ProjectHandler) UnarchiveProject(c *fiber.Ctx) error {
+	projectID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return utils.Error(c, fiber.StatusBadRequest, "Invalid project ID", "")
+	}
+
+	userID := c.Locals("user_id").(uuid.UUID)
+
+	project, err := h.service.UnarchiveProject(projectID, userID)
+	if err != nil {
+		return h.handleArchiveError(c, err, "Failed to unarchive project")
+	}
+
+	return utils.Success(c, "Project unarchived successfully", project)
+}
+
+// handleArchiveError maps project archiving errors to HTTP responses
+func (h *ProjectHandler) handleArchiveError(c *fiber.Ctx, err error, message string) error {
+	switch {
+	case errors.Is(err, apperrors.ErrUnauthorizedOwnerOnly):
+		return utils.Error(c, fiber.StatusForbidden, "Only project owner can archive this project", "")
+	case errors.Is(err, apperrors.ErrProjectNotFound):
+		return utils.Error(c, fiber.StatusNotFound, "Project not found", "")
+	default:
+		return utils.Error(c, fiber.StatusInternalServerError, message, err.Error())
+	}
+}
+
 // DeleteProject deletes a specific project
 func (h *ProjectHandler) DeleteProject(c *fiber.Ctx) error {
 	projectID, err := uuid.Parse(c.Params("id"))
@@ -126,3 +196,75 @@ func (h *ProjectHandler) DeleteProject(c *fiber.Ctx) error {
 
 	return utils.Success(c, "Project deleted successfully", nil)
 }
+
+// DuplicateProject copies a project, or creates a project from a template
+func (h *ProjectHandler) DuplicateProject(c *fiber.Ctx) error {
+	projectID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return utils.Error(c, fiber.StatusBadRequest, "Invalid project ID", "")
+	}
+
+	userID := c.Locals("user_id").(uuid.UUID)
+
+	var req dto.DuplicateProjectRequest
+	if err := c.BodyParser(&req); err != nil {
+		return utils.Error(c, fiber.StatusBadRequest, "Invalid request body", "")
+	}
+
+	project, err := h.service.DuplicateProject(projectID, userID, &req)
+	if err != nil {
+		return h.handleCopyError(c, err, "Failed to duplicate project")
+	}
+
+	return utils.Created(c, "Project duplicated successfully", project)
+}
+
+// SaveAsTemplate saves a copy of a project as a project template
+func (h *ProjectHandler) SaveAsTemplate(c *fiber.Ctx) error {
+	projectID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return utils.Error(c, fiber.StatusBadRequest, "Invalid project ID", "")
+	}
+
+	userID := c.Locals("user_id").(uuid.UUID)
+
+	var req dto.SaveProjectTemplateRequest
+	if err := c.BodyParser(&req); err != nil {
+		return utils.Error(c, fiber.StatusBadRequest, "Invalid request body", "")
+	}
+
+	template, err := h.service.SaveAsTemplate(projectID, userID, &req)
+	if err != nil {
+		return h.handleCopyError(c, err, "Failed to save project template")
+	}
+
+	return utils.Created(c, "Project template saved successfully", template)
+}
+
+// ListTemplates retrieves the project templates available to the authenticated user
+func (h *ProjectHandler) ListTemplates(c *fiber.Ctx) error {
+	userID := c.Locals("user_id").(uuid.UUID)
+
+	templates, err := h.service.GetTemplates(userID)
+	if err != nil {
+		return utils.Error(c, fiber.StatusInternalServerError, "Failed to fetch project templates", err.Error())
+	}
+
+	return utils.Success(c, "Project templates fetched successfully", templates)
+}
+
+// handleCopyError maps project copy errors to HTTP responses
+func (h *ProjectHandler) handleCopyError(c *fiber.Ctx, err error, message string) error {
+	switch {
+	case errors.Is(err, apperrors.ErrProjectNotFound):
+		return utils.Error(c, fiber.StatusNotFound, "Project not found", "")
+	case errors.Is(err, apperrors.ErrInvalidProjectCopy):
+		return utils.Error(c, fiber.StatusBadRequest, "Invalid name or start date", "")
+	case errors.Is(err, apperrors.ErrUnauthorizedOwnerOnly):
+		return utils.Error(c, fiber.StatusForbidden, "Only project owner can copy this project", "")
+	case errors.Is(err, apperrors.ErrUnauthorizedProject):
+		return utils.Error(c, fiber.StatusForbidden, "You are not a member of this project", "")
+	default:
+		return utils.Error(c, fiber.StatusInternalServerError, message, err.Error())
+	}
+}
@@ -0,0 +1,45 @@
+package handlers
+
+import (
+	"errors"
+
+	"github.com/Hann-arc/task-management-backend/internal/dto"
+	apperrors "github.com/Hann-arc/task-management-backend/internal/errors"
+	"github.com/Hann-arc/task-management-backend/internal/services"
+	"github.com/Hann-arc/task-management-backend/internal/utils"
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+type SearchHandler struct {
+	service *services.SearchService
+}
+
+// NewSearchHandler creates a new instance of SearchHandler
+func NewSearchHandler(service *services.SearchService) *SearchHandler {
+	return &SearchHandler{service: service}
+}
+
+// Search runs a full-text search over tasks, comments and projects of the authenticated user
+func (h *SearchHandler) Search(c *fiber.Ctx) error {
+	userID := c.Locals("user_id").(uuid.UUID)
+
+	var query dto.SearchQuery
+	if err := c.QueryParser(&query); err != nil {
+		return utils.Error(c, fiber.StatusBadRequest, "Invalid query parameters", "")
+	}
+
+	results, err := h.service.Search(userID, &query)
+	if err != nil {
+		switch {
+		case errors.Is(err, apperrors.ErrEmptySearchQuery):
+			return utils.Error(c, fiber.StatusBadRequest, "Search query is required", "")
+		case errors.Is(err, apperrors.ErrInvalidSearchType):
+			return utils.Error(c, fiber.StatusBadRequest, "Invalid search type", "")
+		default:
+			return utils.Error(c, fiber.StatusInternalServerError, "Failed to search", err.Error())
+		}
+	}
+
+	return utils.Success(c, "Search results fetched successfully", results)
+}
@@ -0,0 +1,249 @@
+package handlers
+
+import (
+	"errors"
+
+	"github.com/Hann-arc/task-management-backend/internal/dto"
+	apperrors "github.com/Hann-arc/task-management-backend/internal/errors"
+	"github.com/Hann-arc/task-management-backend/internal/services"
+	"github.com/Hann-arc/task-management-backend/internal/utils"
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+type SprintHandler struct {
+	service *services.SprintService
+}
+
+// NewSprintHandler creates a new instance of SprintHandler
+func NewSprintHandler(service *services.SprintService) *SprintHandler {
+	return &SprintHandler{service: service}
+}
+
+// CreateSprint plans a new sprint in a project
+func (h *SprintHandler) CreateSprint(c *fiber.Ctx) error {
+	userID := c.Locals("user_id").(uuid.UUID)
+	projectID, err := uuid.Parse(c.Params("projectId"))
+	if err != nil {
+		return utils.Error(c, fiber.StatusBadRequest, "Invalid project ID", "")
+	}
+
+	var req dto.CreateSprintRequest
+	if err := c.BodyParser(&req); err != nil {
+		return utils.Error(c, fiber.StatusBadRequest, "Invalid request body", "")
+	}
+
+	sprint, err := h.service.CreateSprint(projectID, userID, &req)
+	if err != nil {
+		return h.handleError(c, err, "Failed to create sprint")
+	}
+
+	return utils.Created(c, "Sprint created successfully", sprint)
+}
+
+// GetSprints retrieves all sprints of a project
+func (h *SprintHandler) GetSprints(c *fiber.Ctx) error {
+	userID := c.Locals("user_id").(uuid.UUID)
+	projectID, err := uuid.Parse(c.Params("projectId"))
+	if err != nil {
+		return utils.Error(c, fiber.StatusBadRequest, "Invalid project ID", "")
+	}
+
+	sprints, err := h.service.GetSprints(projectID, userID)
+	if err != nil {
+		return h.handleError(c, err, "Failed to fetch sprints")
+	}
+
+	return utils.Success(c, "Sprints fetched successfully", sprints)
+}
+
+// GetVelocity retrieves the velocity of the closed sprints of a project
+func (h *SprintHandler) GetVelocity(c *fiber.Ctx) error {
+	userID := c.Locals("user_id").(uuid.UUID)
+	projectID, err := uuid.Parse(c.Params("projectId"))
+	if err != nil {
+		return utils.Error(c, fiber.StatusBadRequest, "Invalid project ID", "")
+	}
+
+	velocity, err := h.service.GetVelocity(projectID, userID)
+	if err != nil {
+		return h.handleError(c, err, "Failed to fetch velocity")
+	}
+
+	return utils.Success(c, "Velocity fetched successfully", velocity)
+}
+
+// GetSprint retrieves a single sprint
+func (h *SprintHandler) GetSprint(c *fiber.Ctx) error {
+	userID := c.Locals("user_id").(uuid.UUID)
+	sprintID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return utils.Error(c, fiber.StatusBadRequest, "Invalid sprint ID", "")
+	}
+
+	sprint, err := h.service.GetSprint(sprintID, userID)
+	if err != nil {
+		return h.handleError(c, err, "Failed to fetch sprint")
+	}
+
+	return utils.Success(c, "Sprint fetched successfully", sprint)
+}
+
+// GetSprintBoard retrieves the tasks of a sprint grouped by status
+func (h *SprintHandler) GetSprintBoard(c *fiber.Ctx) error {
+	userID := c.Locals("user_id").(uuid.UUID)
+	sprintID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return utils.Error(c, fiber.StatusBadRequest, "Invalid sprint ID", "")
+	}
+
+	board, err := h.service.GetSprintBoard(sprintID, userID)
+	if err != nil {
+		return h.handleError(c, err, "Failed to fetch sprint board")
+	}
+
+	return utils.Success(c, "Sprint board fetched successfully", board)
+}
+
+// UpdateSprint modifies the details of a sprint
+func (h *SprintHandler) UpdateSprint(c *fiber.Ctx) error {
+	userID := c.Locals("user_id").(uuid.UUID)
+	sprintID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return utils.Error(c, fiber.StatusBadRequest, "Invalid sprint ID", "")
+	}
+
+	var req dto.UpdateSprintRequest
+	if err := c.BodyParser(&req); err != nil {
+		return utils.Error(c, fiber.StatusBadRequest, "Invalid request body", "")
+	}
+
+	sprint, err := h.service.UpdateSprint(sprintID, userID, &req)
+	if err != nil {
+		return h.handleError(c, err, "Failed to update sprint")
+	}
+
+	return utils.Success(c, "Sprint updated successfully", sprint)
+}
+
+// DeleteSprint removes a planned sprint
+func (h *SprintHandler) DeleteSprint(c *fiber.Ctx) error {
+	userID := c.Locals("user_id").(uuid.UUID)
+	sprintID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return utils.Error(c, fiber.StatusBadRequest, "Invalid sprint ID", "")
+	}
+
+	if err := h.service.DeleteSprint(sprintID, userID); err != nil {
+		return h.handleError(c, err, "Failed to delete sprint")
+	}
+
+	return utils.Success(c, "Sprint deleted successfully", nil)
+}
+
+// StartSprint activates a planned sprint
+func (h *SprintHandler) StartSprint(c *fiber.Ctx) error {
+	userID := c.Locals("user_id").(uuid.UUID)
+	sprintID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return utils.Error(c, fiber.StatusBadRequest, "Invalid sprint ID", "")
+	}
+
+	sprint, err := h.service.StartSprint(sprintID, userID)
+	if err != nil {
+		return h.handleError(c, err, "Failed to start sprint")
+	}
+
+	return utils.Success(c, "Sprint started successfully", sprint)
+}
+
+// CloseSprint closes an active sprint and rolls over its unfinished tasks
+func (h *SprintHandler) CloseSprint(c *fiber.Ctx) error {
+	userID := c.Locals("user_id").(uuid.UUID)
+	sprintID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return utils.Error(c, fiber.StatusBadRequest, "Invalid sprint ID", "")
+	}
+
+	var req dto.CloseSprintRequest
+	if len(c.Body()) > 0 {
+		if err := c.BodyParser(&req); err != nil {
+			return utils.Error(c, fiber.StatusBadRequest, "Invalid request body", "")
+		}
+	}
+
+	result, err := h.service.CloseSprint(sprintID, userID, &req)
+	if err != nil {
+		return h.handleError(c, err, "Failed to close sprint")
+	}
+
+	return utils.Success(c, "Sprint closed successfully", result)
+}
+
+// AddTasks plans tasks into a sprint
+func (h *SprintHandler) AddTasks(c *fiber.Ctx) error {
+	userID := c.Locals("user_id").(uuid.UUID)
+	sprintID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return utils.Error(c, fiber.StatusBadRequest, "Invalid sprint ID", "")
+	}
+
+	var req dto.SprintTasksRequest
+	if err := c.BodyParser(&req); err != nil {
+		return utils.Error(c, fiber.StatusBadRequest, "Invalid request body", "")
+	}
+
+	sprint, err := h.service.AddTasks(sprintID, userID, &req)
+	if err != nil {
+		return h.handleError(c, err, "Failed to add tasks to sprint")
+	}
+
+	return utils.Success(c, "Tasks added to sprint successfully", sprint)
+}
+
+// RemoveTask moves a task of the sprint back to the backlog
+func (h *SprintHandler) RemoveTask(c *fiber.Ctx) error {
+	userID := c.Locals("user_id").(uuid.UUID)
+	sprintID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return utils.Error(c, fiber.StatusBadRequest, "Invalid sprint ID", "")
+	}
+	taskID, err := uuid.Parse(c.Params("taskId"))
+	if err != nil {
+		return utils.Error(c, fiber.StatusBadRequest, "Invalid task ID", "")
+	}
+
+	if err := h.service.RemoveTask(sprintID, taskID, userID); err != nil {
+		return h.handleError(c, err, "Failed to remove task from sprint")
+	}
+
+	return utils.Success(c, "Task removed from sprint successfully", nil)
+}
+
+// handleError maps sprint errors to HTTP responses
+func (h *SprintHandler) handleError(c *fiber.Ctx, err error, message string) error {
+	switch {
+	case errors.Is(err, apperrors.ErrSprintNotFound):
+		return utils.Error(c, fiber.StatusNotFound, "Sprint not found", "")
+	case errors.Is(err, apperrors.ErrTaskNotFound):
+		return utils.Error(c, fiber.StatusNotFound, "Task not found in this sprint", "")
+	case errors.Is(err, apperrors.ErrInvalidSprintData):
+		return utils.Error(c, fiber.StatusBadRequest, "Invalid sprint data", "")
+	case errors.Is(err, apperrors.ErrNoFieldsToUpdate):
+		return utils.Error(c, fiber.StatusBadRequest, "No fields to update", "")
+	case errors.Is(err, apperrors.ErrTaskNotInProject):
+		return utils.Error(c, fiber.StatusBadRequest, "All tasks must belong to the sprint's project", "")
+	case errors.Is(err, apperrors.ErrInvalidSprintState):
+		return utils.Error(c, fiber.StatusConflict, "Sprint is not in a valid state for this action", "")
+	case errors.Is(err, apperrors.ErrActiveSprintExists):
+		return utils.Error(c, fiber.StatusConflict, "Project already has an active sprint", "")
+	case errors.Is(err, apperrors.ErrUnauthorizedOwnerOnly):
+		return utils.Error(c, fiber.StatusForbidden, "Only the project owner can manage sprints", "")
+	case errors.Is(err, apperrors.ErrUnauthorizedProject):
+		return utils.Error(c, fiber.StatusForbidden, "You are not a member of this project", "")
+	case errors.Is(err, apperrors.ErrProjectArchived):
+		return utils.Error(c, fiber.StatusConflict, "Project or board is archived", "")
+	default:
+		return utils.Error(c, fiber.StatusInternalServerError, message, err.Error())
+	}
+}
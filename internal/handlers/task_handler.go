@@ -2,6 +2,7 @@ package handlers
 
 import (
 	"errors"
+	"strings"
 
 	"github.com/Hann-arc/task-management-backend/internal/dto"
 	apperrors "github.com/Hann-arc/task-management-backend/internal/errors"
@@ -59,8 +60,14 @@ func (h *TaskHandler) CreateTask(c *fiber.Ctx) error {
 			return utils.Error(c, fiber.StatusNotFound, "Board not found", "")
 		case errors.Is(err, apperrors.ErrAssigneeNotFound):
 			return utils.Error(c, fiber.StatusBadRequest, "Assignee not found", "")
+		case errors.Is(err, apperrors.ErrAssigneeNotMember):
+			return utils.Error(c, fiber.StatusBadRequest, "Assignee is not a member of this project", "")
 		case errors.Is(err, apperrors.ErrInvalidTaskData):
 			return utils.Error(c, fiber.StatusBadRequest, "Invalid task data", "")
+		case errors.Is(err, apperrors.ErrInvalidCustomFieldValue):
+			return utils.Error(c, fiber.StatusBadRequest, "Invalid or missing custom field value", "")
+		case errors.Is(err, apperrors.ErrProjectArchived):
+			return utils.Error(c, fiber.StatusConflict, "Project or board is archived", "")
 		default:
 			return utils.Error(c, fiber.StatusInternalServerError, "Failed to create task", err.Error())
 		}
@@ -69,7 +76,7 @@ func (h *TaskHandler) CreateTask(c *fiber.Ctx) error {
 	return utils.Created(c, "Task created successfully", task)
 }
 
-// GetTasksByBoard retrieves all tasks for a given board
+// GetTasksByBoard retrieves the tasks of a given board with optional filters, sorting and pagination
 func (h *TaskHandler) GetTasksByBoard(c *fiber.Ctx) error {
 	userID := c.Locals("user_id").(uuid.UUID)
 	boardID, err := uuid.Parse(c.Params("boardId"))
@@ -77,19 +84,86 @@ func (h *TaskHandler) GetTasksByBoard(c *fiber.Ctx) error {
 		return utils.Error(c, fiber.StatusBadRequest, "Invalid board ID", "")
 	}
 
-	tasks, err := h.service.GetTasksByBoard(boardID, userID)
+	var query dto.TaskListQuery
+	if err := c.QueryParser(&query); err != nil {
+		return utils.Error(c, fiber.StatusBadRequest, "Invalid query parameters", "")
+	}
+	query.CustomFields = parseCustomFieldQuery(c)
+
+	tasks, err := h.service.GetTasksByBoard(boardID, userID, &query)
 	if err != nil {
-		switch {
-		case errors.Is(err, apperrors.ErrUnauthorizedTask):
-			return utils.Error(c, fiber.StatusForbidden, "You are not a member of this project", "")
-		default:
-			return utils.Error(c, fiber.StatusInternalServerError, "Failed to fetch tasks", err.Error())
-		}
+		return h.handleListError(c, err)
 	}
 
 	return utils.Success(c, "Tasks fetched successfully", tasks)
 }
 
+// GetTasksByProject retrieves the tasks of every board in a project with optional filters, sorting and pagination
+func (h *TaskHandler) GetTasksByProject(c *fiber.Ctx) error {
+	userID := c.Locals("user_id").(uuid.UUID)
+	projectID, err := uuid.Parse(c.Params("projectId"))
+	if err != nil {
+		return utils.Error(c, fiber.StatusBadRequest, "Invalid project ID", "")
+	}
+
+	var query dto.TaskListQuery
+	if err := c.QueryParser(&query); err != nil {
+		return utils.Error(c, fiber.StatusBadRequest, "Invalid query parameters", "")
+	}
+	query.CustomFields = parseCustomFieldQuery(c)
+
+	tasks, err := h.service.GetTasksByProject(projectID, userID, &query)
+	if err != nil {
+		return h.handleListError(c, err)
+	}
+
+	return utils.Success(c, "Tasks fetched successfully", tasks)
+}
+
+// GetMyTasks retrieves the tasks assigned to or created by the authenticated user across all projects
+func (h *TaskHandler) GetMyTasks(c *fiber.Ctx) error {
+	userID := c.Locals("user_id").(uuid.UUID)
+
+	var query dto.MyTaskListQuery
+	if err := c.QueryParser(&query); err != nil {
+		return utils.Error(c, fiber.StatusBadRequest, "Invalid query parameters", "")
+	}
+
+	tasks, err := h.service.GetMyTasks(userID, &query)
+	if err != nil {
+		return h.handleListError(c, err)
+	}
+
+	return utils.Success(c, "Tasks fetched successfully", tasks)
+}
+
+// parseCustomFieldQuery collects the cf.<field_id>=value filters from the query string
+func parseCustomFieldQuery(c *fiber.Ctx) map[string]string {
+	filters := map[string]string{}
+	c.Context().QueryArgs().VisitAll(func(key, value []byte) {
+		if name := string(key); strings.HasPrefix(name, "cf.") {
+			filters[strings.TrimPrefix(name, "cf.")] = string(value)
+		}
+	})
+	return filters
+}
+
+// handleListError maps task listing errors to HTTP responses
+func (h *TaskHandler) handleListError(c *fiber.Ctx, err error) error {
+	switch {
+	case errors.Is(err, apperrors.ErrUnauthorizedTask):
+		return utils.Error(c, fiber.StatusForbidden, "You are not a member of this project", "")
+	case errors.Is(err, apperrors.ErrBoardNotFound):
+		return utils.Error(c, fiber.StatusNotFound, "Board not found", "")
+	case errors.Is(err, apperrors.ErrInvalidTaskQuery):
+		return utils.Error(c, fiber.StatusBadRequest, "Invalid task query", "")
+	case errors.Is(err, apperrors.ErrInvalidCursor):
+		return utils.Error(c, fiber.StatusBadRequest, "Invalid cursor", "")
+	default:
+		return utils.Error(c, fiber.StatusInternalServerError, "Failed to fetch tasks", err.Error())
+	}
+}
+
 // UpdateTask handles updating a task's details
 func (h *TaskHandler) UpdateTask(c *fiber.Ctx) error {
 	userID := c.Locals("user_id").(uuid.UUID)
@@ -112,8 +186,14 @@ func (h *TaskHandler) UpdateTask(c *fiber.Ctx) error {
 			return utils.Error(c, fiber.StatusForbidden, "You are not a member of this project", "")
 		case errors.Is(err, apperrors.ErrAssigneeNotFound):
 			return utils.Error(c, fiber.StatusBadRequest, "Assignee not found", "")
+		case errors.Is(err, apperrors.ErrAssigneeNotMember):
+			return utils.Error(c, fiber.StatusBadRequest, "Assignee is not a member of this project", "")
 		case errors.Is(err, apperrors.ErrInvalidTaskData):
 			return utils.Error(c, fiber.StatusBadRequest, "No valid fields to update", "")
+		case errors.Is(err, apperrors.ErrInvalidCustomFieldValue):
+			return utils.Error(c, fiber.StatusBadRequest, "Invalid or missing custom field value", "")
+		case errors.Is(err, apperrors.ErrProjectArchived):
+			return utils.Error(c, fiber.StatusConflict, "Project or board is archived", "")
 		default:
 			return utils.Error(c, fiber.StatusInternalServerError, "Failed to update task", err.Error())
 		}
@@ -136,6 +216,8 @@ func (h *TaskHandler) DeleteTask(c *fiber.Ctx) error {
 			return utils.Error(c, fiber.StatusNotFound, "Task not found", "")
 		case errors.Is(err, apperrors.ErrUnauthorizedTask):
 			return utils.Error(c, fiber.StatusForbidden, "You are not a member of this project", "")
+		case errors.Is(err, apperrors.ErrProjectArchived):
+			return utils.Error(c, fiber.StatusConflict, "Project or board is archived", "")
 		default:
 			return utils.Error(c, fiber.StatusInternalServerError, "Failed to delete task", err.Error())
 		}
@@ -143,3 +225,51 @@ func (h *TaskHandler) DeleteTask(c *fiber.Ctx) error {
 
 	return utils.Success(c, "Task deleted successfully", nil)
 }
+
+// WatchTask subscribes the current user to notifications about a task
+func (h *TaskHandler) WatchTask(c *fiber.Ctx) error {
+	userID := c.Locals("user_id").(uuid.UUID)
+	taskID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return utils.Error(c, fiber.StatusBadRequest, "Invalid task ID", "")
+	}
+
+	if err := h.service.WatchTask(taskID, userID); err != nil {
+		switch {
+		case errors.Is(err, apperrors.ErrTaskNotFound):
+			return utils.Error(c, fiber.StatusNotFound, "Task not found", "")
+		case errors.Is(err, apperrors.ErrUnauthorizedTask):
+			return utils.Error(c, fiber.StatusForbidden, "You are not a member of this project", "")
+		case errors.Is(err, apperrors.ErrProjectArchived):
+			return utils.Error(c, fiber.StatusConflict, "Project or board is archived", "")
+		default:
+			return utils.Error(c, fiber.StatusInternalServerError, "Failed to watch task", err.Error())
+		}
+	}
+
+	return utils.Success(c, "Task watched successfully", nil)
+}
+
+// UnwatchTask unsubscribes the current user from notifications about a task
+func (h *TaskHandler) UnwatchTask(c *fiber.Ctx) error {
+	userID := c.Locals("user_id").(uuid.UUID)
+	taskID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return utils.Error(c, fiber.StatusBadRequest, "Invalid task ID", "")
+	}
+
+	if err := h.service.UnwatchTask(taskID, userID); err != nil {
+		switch {
+		case errors.Is(err, apperrors.ErrTaskNotFound):
+			return utils.Error(c, fiber.StatusNotFound, "Task not found", "")
+		case errors.Is(err, apperrors.ErrUnauthorizedTask):
+			return utils.Error(c, fiber.StatusForbidden, "You are not a member of this project", "")
+		case errors.Is(err, apperrors.ErrProjectArchived):
+			return utils.Error(c, fiber.StatusConflict, "Project or board is archived", "")
+		default:
+			return utils.Error(c, fiber.StatusInternalServerError, "Failed to unwatch task", err.Error())
+		}
+	}
+
+	return utils.Success(c, "Task unwatched successfully", nil)
+}
@@ -0,0 +1,91 @@
+package handlers
+
+import (
+	"errors"
+
+	"github.com/Hann-arc/task-management-backend/internal/dto"
+	apperrors "github.com/Hann-arc/task-management-backend/internal/errors"
+	"github.com/Hann-arc/task-management-backend/internal/services"
+	"github.com/Hann-arc/task-management-backend/internal/utils"
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+type TaskRecurrenceHandler struct {
+	service *services.TaskRecurrenceService
+}
+
+// NewTaskRecurrenceHandler creates a new instance of TaskRecurrenceHandler
+func NewTaskRecurrenceHandler(service *services.TaskRecurrenceService) *TaskRecurrenceHandler {
+	return &TaskRecurrenceHandler{service: service}
+}
+
+// SetRecurrence creates or replaces the recurrence rule of a task
+func (h *TaskRecurrenceHandler) SetRecurrence(c *fiber.Ctx) error {
+	userID := c.Locals("user_id").(uuid.UUID)
+	taskID, err := uuid.Parse(c.Params("taskId"))
+	if err != nil {
+		return utils.Error(c, fiber.StatusBadRequest, "Invalid task ID", "")
+	}
+
+	var req dto.SetRecurrenceRequest
+	if err := c.BodyParser(&req); err != nil {
+		return utils.Error(c, fiber.StatusBadRequest, "Invalid request body", "")
+	}
+
+	recurrence, err := h.service.SetRecurrence(taskID, userID, &req)
+	if err != nil {
+		return h.handleError(c, err, "Failed to set recurrence")
+	}
+
+	return utils.Success(c, "Recurrence saved successfully", recurrence)
+}
+
+// GetRecurrence retrieves the recurrence rule of a task
+func (h *TaskRecurrenceHandler) GetRecurrence(c *fiber.Ctx) error {
+	userID := c.Locals("user_id").(uuid.UUID)
+	taskID, err := uuid.Parse(c.Params("taskId"))
+	if err != nil {
+		return utils.Error(c, fiber.StatusBadRequest, "Invalid task ID", "")
+	}
+
+	recurrence, err := h.service.GetRecurrence(taskID, userID)
+	if err != nil {
+		return h.handleError(c, err, "Failed to fetch recurrence")
+	}
+
+	return utils.Success(c, "Recurrence fetched successfully", recurrence)
+}
+
+// DeleteRecurrence stops a recurring series
+func (h *TaskRecurrenceHandler) DeleteRecurrence(c *fiber.Ctx) error {
+	userID := c.Locals("user_id").(uuid.UUID)
+	taskID, err := uuid.Parse(c.Params("taskId"))
+	if err != nil {
+		return utils.Error(c, fiber.StatusBadRequest, "Invalid task ID", "")
+	}
+
+	if err := h.service.DeleteRecurrence(taskID, userID); err != nil {
+		return h.handleError(c, err, "Failed to delete recurrence")
+	}
+
+	return utils.Success(c, "Recurrence deleted successfully", nil)
+}
+
+// handleError maps recurrence errors to HTTP responses
+func (h *TaskRecurrenceHandler) handleError(c *fiber.Ctx, err error, message string) error {
+	switch {
+	case errors.Is(err, apperrors.ErrTaskNotFound):
+		return utils.Error(c, fiber.StatusNotFound, "Task not found", "")
+	case errors.Is(err, apperrors.ErrRecurrenceNotFound):
+		return utils.Error(c, fiber.StatusNotFound, "Recurrence not found", "")
+	case errors.Is(err, apperrors.ErrUnauthorizedTask):
+		return utils.Error(c, fiber.StatusForbidden, "You are not a member of this project", "")
+	case errors.Is(err, apperrors.ErrInvalidRecurrence):
+		return utils.Error(c, fiber.StatusBadRequest, "Invalid recurrence rule", "")
+	case errors.Is(err, apperrors.ErrProjectArchived):
+		return utils.Error(c, fiber.StatusConflict, "Project or board is archived", "")
+	default:
+		return utils.Error(c, fiber.StatusInternalServerError, message, err.Error())
+	}
+}
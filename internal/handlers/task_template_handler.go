@@ -0,0 +1,161 @@
+package handlers
+
+import (
+	"errors"
+
+	"github.com/Hann-arc/task-management-backend/internal/dto"
+	apperrors "github.com/Hann-arc/task-management-backend/internal/errors"
+	"github.com/Hann-arc/task-management-backend/internal/services"
+	"github.com/Hann-arc/task-management-backend/internal/utils"
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+type TaskTemplateHandler struct {
+	service *services.TaskTemplateService
+}
+
+// NewTaskTemplateHandler creates a new instance of TaskTemplateHandler
+func NewTaskTemplateHandler(service *services.TaskTemplateService) *TaskTemplateHandler {
+	return &TaskTemplateHandler{service: service}
+}
+
+// CreateTemplate saves a new task template in a project
+func (h *TaskTemplateHandler) CreateTemplate(c *fiber.Ctx) error {
+	userID := c.Locals("user_id").(uuid.UUID)
+	projectID, err := uuid.Parse(c.Params("projectId"))
+	if err != nil {
+		return utils.Error(c, fiber.StatusBadRequest, "Invalid project ID", "")
+	}
+
+	var req dto.CreateTaskTemplateRequest
+	if err := c.BodyParser(&req); err != nil {
+		return utils.Error(c, fiber.StatusBadRequest, "Invalid request body", "")
+	}
+
+	template, err := h.service.CreateTemplate(projectID, userID, &req)
+	if err != nil {
+		return h.handleError(c, err, "Failed to create task template")
+	}
+
+	return utils.Created(c, "Task template created successfully", template)
+}
+
+// GetTemplates retrieves the task templates of a project
+func (h *TaskTemplateHandler) GetTemplates(c *fiber.Ctx) error {
+	userID := c.Locals("user_id").(uuid.UUID)
+	projectID, err := uuid.Parse(c.Params("projectId"))
+	if err != nil {
+		return utils.Error(c, fiber.StatusBadRequest, "Invalid project ID", "")
+	}
+
+	templates, err := h.service.GetTemplates(projectID, userID)
+	if err != nil {
+		return h.handleError(c, err, "Failed to fetch task templates")
+	}
+
+	return utils.Success(c, "Task templates fetched successfully", templates)
+}
+
+// GetTemplate retrieves a single task template
+func (h *TaskTemplateHandler) GetTemplate(c *fiber.Ctx) error {
+	userID := c.Locals("user_id").(uuid.UUID)
+	templateID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return utils.Error(c, fiber.StatusBadRequest, "Invalid task template ID", "")
+	}
+
+	template, err := h.service.GetTemplate(templateID, userID)
+	if err != nil {
+		return h.handleError(c, err, "Failed to fetch task template")
+	}
+
+	return utils.Success(c, "Task template fetched successfully", template)
+}
+
+// UpdateTemplate modifies a task template
+func (h *TaskTemplateHandler) UpdateTemplate(c *fiber.Ctx) error {
+	userID := c.Locals("user_id").(uuid.UUID)
+	templateID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return utils.Error(c, fiber.StatusBadRequest, "Invalid task template ID", "")
+	}
+
+	var req dto.UpdateTaskTemplateRequest
+	if err := c.BodyParser(&req); err != nil {
+		return utils.Error(c, fiber.StatusBadRequest, "Invalid request body", "")
+	}
+
+	template, err := h.service.UpdateTemplate(templateID, userID, &req)
+	if err != nil {
+		return h.handleError(c, err, "Failed to update task template")
+	}
+
+	return utils.Success(c, "Task template updated successfully", template)
+}
+
+// DeleteTemplate removes a task template
+func (h *TaskTemplateHandler) DeleteTemplate(c *fiber.Ctx) error {
+	userID := c.Locals("user_id").(uuid.UUID)
+	templateID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return utils.Error(c, fiber.StatusBadRequest, "Invalid task template ID", "")
+	}
+
+	if err := h.service.DeleteTemplate(templateID, userID); err != nil {
+		return h.handleError(c, err, "Failed to delete task template")
+	}
+
+	return utils.Success(c, "Task template deleted successfully", nil)
+}
+
+// CreateTaskFromTemplate creates a task on a board from a task template
+func (h *TaskTemplateHandler) CreateTaskFromTemplate(c *fiber.Ctx) error {
+	userID := c.Locals("user_id").(uuid.UUID)
+	boardID, err := uuid.Parse(c.Params("boardId"))
+	if err != nil {
+		return utils.Error(c, fiber.StatusBadRequest, "Invalid board ID", "")
+	}
+
+	var req dto.CreateTaskFromTemplateRequest
+	if err := c.BodyParser(&req); err != nil {
+		return utils.Error(c, fiber.StatusBadRequest, "Invalid request body", "")
+	}
+
+	task, err := h.service.CreateTaskFromTemplate(boardID, userID, &req)
+	if err != nil {
+		return h.handleError(c, err, "Failed to create task")
+	}
+
+	return utils.Created(c, "Task created successfully", task)
+}
+
+// handleError maps task template errors to HTTP responses
+func (h *TaskTemplateHandler) handleError(c *fiber.Ctx, err error, message string) error {
+	switch {
+	case errors.Is(err, apperrors.ErrTaskTemplateNotFound):
+		return utils.Error(c, fiber.StatusNotFound, "Task template not found", "")
+	case errors.Is(err, apperrors.ErrBoardNotFound):
+		return utils.Error(c, fiber.StatusNotFound, "Board not found", "")
+	case errors.Is(err, apperrors.ErrInvalidTaskTemplate):
+		return utils.Error(c, fiber.StatusBadRequest, "Invalid task template data", "")
+	case errors.Is(err, apperrors.ErrInvalidTaskData):
+		return utils.Error(c, fiber.StatusBadRequest, "Invalid task data", "")
+	case errors.Is(err, apperrors.ErrInvalidCustomFieldValue):
+		return utils.Error(c, fiber.StatusBadRequest, "Invalid or missing custom field value", "")
+	case errors.Is(err, apperrors.ErrNoFieldsToUpdate):
+		return utils.Error(c, fiber.StatusBadRequest, "No fields to update", "")
+	case errors.Is(err, apperrors.ErrAssigneeNotFound):
+		return utils.Error(c, fiber.StatusBadRequest, "Assignee not found", "")
+	case errors.Is(err, apperrors.ErrAssigneeNotMember):
+		return utils.Error(c, fiber.StatusBadRequest, "Assignee is not a member of this project", "")
+	case errors.Is(err, apperrors.ErrProjectArchived):
+		return utils.Error(c, fiber.StatusConflict, "Project or board is archived", "")
+	case errors.Is(err, apperrors.ErrTaskTemplateExists):
+		return utils.Error(c, fiber.StatusConflict, "A task template with this name already exists", "")
+	case errors.Is(err, apperrors.ErrUnauthorizedTask), errors.Is(err, apperrors.ErrUnauthorizedProject):
+		return utils.Error(c, fiber.StatusForbidden, "You are not a member of this project", "")
+	default:
+		return utils.Error(c, fiber.StatusInternalServerError, message, err.Error())
+	}
+}
@@ -0,0 +1,189 @@
+package handlers
+
+import (
+	"errors"
+
+	"github.com/Hann-arc/task-management-backend/internal/dto"
+	apperrors "github.com/Hann-arc/task-management-backend/internal/errors"
+	"github.com/Hann-arc/task-management-backend/internal/services"
+	"github.com/Hann-arc/task-management-backend/internal/utils"
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+type TimeEntryHandler struct {
+	service *services.TimeEntryService
+}
+
+// NewTimeEntryHandler creates a new instance of TimeEntryHandler
+func NewTimeEntryHandler(service *services.TimeEntryService) *TimeEntryHandler {
+	return &TimeEntryHandler{service: service}
+}
+
+// StartTimer starts a timer on a task for the current user
+func (h *TimeEntryHandler) StartTimer(c *fiber.Ctx) error {
+	userID := c.Locals("user_id").(uuid.UUID)
+	taskID, err := uuid.Parse(c.Params("taskId"))
+	if err != nil {
+		return utils.Error(c, fiber.StatusBadRequest, "Invalid task ID", "")
+	}
+
+	var req dto.StartTimerRequest
+	if len(c.Body()) > 0 {
+		if err := c.BodyParser(&req); err != nil {
+			return utils.Error(c, fiber.StatusBadRequest, "Invalid request body", "")
+		}
+	}
+
+	entry, err := h.service.StartTimer(taskID, userID, &req)
+	if err != nil {
+		return h.handleError(c, err, "Failed to start timer")
+	}
+
+	return utils.Created(c, "Timer started successfully", entry)
+}
+
+// StopTimer stops the running timer of the current user
+func (h *TimeEntryHandler) StopTimer(c *fiber.Ctx) error {
+	userID := c.Locals("user_id").(uuid.UUID)
+
+	entry, err := h.service.StopTimer(userID)
+	if err != nil {
+		return h.handleError(c, err, "Failed to stop timer")
+	}
+
+	return utils.Success(c, "Timer stopped successfully", entry)
+}
+
+// GetRunningTimer retrieves the running timer of the current user
+func (h *TimeEntryHandler) GetRunningTimer(c *fiber.Ctx) error {
+	userID := c.Locals("user_id").(uuid.UUID)
+
+	entry, err := h.service.GetRunningTimer(userID)
+	if err != nil {
+		return h.handleError(c, err, "Failed to fetch timer")
+	}
+
+	return utils.Success(c, "Timer fetched successfully", entry)
+}
+
+// CreateTimeEntry records a finished time entry on a task
+func (h *TimeEntryHandler) CreateTimeEntry(c *fiber.Ctx) error {
+	userID := c.Locals("user_id").(uuid.UUID)
+	taskID, err := uuid.Parse(c.Params("taskId"))
+	if err != nil {
+		return utils.Error(c, fiber.StatusBadRequest, "Invalid task ID", "")
+	}
+
+	var req dto.CreateTimeEntryRequest
+	if err := c.BodyParser(&req); err != nil {
+		return utils.Error(c, fiber.StatusBadRequest, "Invalid request body", "")
+	}
+
+	entry, err := h.service.CreateTimeEntry(taskID, userID, &req)
+	if err != nil {
+		return h.handleError(c, err, "Failed to create time entry")
+	}
+
+	return utils.Created(c, "Time entry created successfully", entry)
+}
+
+// GetTaskTimeEntries retrieves the time entries of a task
+func (h *TimeEntryHandler) GetTaskTimeEntries(c *fiber.Ctx) error {
+	userID := c.Locals("user_id").(uuid.UUID)
+	taskID, err := uuid.Parse(c.Params("taskId"))
+	if err != nil {
+		return utils.Error(c, fiber.StatusBadRequest, "Invalid task ID", "")
+	}
+
+	var query dto.TimeReportQuery
+	if err := c.QueryParser(&query); err != nil {
+		return utils.Error(c, fiber.StatusBadRequest, "Invalid query parameters", "")
+	}
+
+	entries, err := h.service.GetTaskTimeEntries(taskID, userID, &query)
+	if err != nil {
+		return h.handleError(c, err, "Failed to fetch time entries")
+	}
+
+	return utils.Success(c, "Time entries fetched successfully", entries)
+}
+
+// DeleteTimeEntry removes a time entry
+func (h *TimeEntryHandler) DeleteTimeEntry(c *fiber.Ctx) error {
+	userID := c.Locals("user_id").(uuid.UUID)
+	entryID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return utils.Error(c, fiber.StatusBadRequest, "Invalid time entry ID", "")
+	}
+
+	if err := h.service.DeleteTimeEntry(entryID, userID); err != nil {
+		return h.handleError(c, err, "Failed to delete time entry")
+	}
+
+	return utils.Success(c, "Time entry deleted successfully", nil)
+}
+
+// GetProjectReport aggregates the time logged in a project
+func (h *TimeEntryHandler) GetProjectReport(c *fiber.Ctx) error {
+	userID := c.Locals("user_id").(uuid.UUID)
+	projectID, err := uuid.Parse(c.Params("projectId"))
+	if err != nil {
+		return utils.Error(c, fiber.StatusBadRequest, "Invalid project ID", "")
+	}
+
+	var query dto.TimeReportQuery
+	if err := c.QueryParser(&query); err != nil {
+		return utils.Error(c, fiber.StatusBadRequest, "Invalid query parameters", "")
+	}
+
+	report, err := h.service.GetProjectReport(projectID, userID, &query)
+	if err != nil {
+		return h.handleError(c, err, "Failed to build time report")
+	}
+
+	return utils.Success(c, "Time report fetched successfully", report)
+}
+
+// GetUserReport aggregates the time logged by the current user
+func (h *TimeEntryHandler) GetUserReport(c *fiber.Ctx) error {
+	userID := c.Locals("user_id").(uuid.UUID)
+
+	var query dto.TimeReportQuery
+	if err := c.QueryParser(&query); err != nil {
+		return utils.Error(c, fiber.StatusBadRequest, "Invalid query parameters", "")
+	}
+
+	report, err := h.service.GetUserReport(userID, &query)
+	if err != nil {
+		return h.handleError(c, err, "Failed to build time report")
+	}
+
+	return utils.Success(c, "Time report fetched successfully", report)
+}
+
+// handleError maps time tracking errors to HTTP responses
+func (h *TimeEntryHandler) handleError(c *fiber.Ctx, err error, message string) error {
+	switch {
+	case errors.Is(err, apperrors.ErrTaskNotFound):
+		return utils.Error(c, fiber.StatusNotFound, "Task not found", "")
+	case errors.Is(err, apperrors.ErrTimeEntryNotFound):
+		return utils.Error(c, fiber.StatusNotFound, "Time entry not found", "")
+	case errors.Is(err, apperrors.ErrNoRunningTimer):
+		return utils.Error(c, fiber.StatusNotFound, "No running timer", "")
+	case errors.Is(err, apperrors.ErrTimerAlreadyRunning):
+		return utils.Error(c, fiber.StatusConflict, "A timer is already running", "")
+	case errors.Is(err, apperrors.ErrInvalidTimeEntry):
+		return utils.Error(c, fiber.StatusBadRequest, "Invalid time entry", "")
+	case errors.Is(err, apperrors.ErrInvalidReportQuery):
+		return utils.Error(c, fiber.StatusBadRequest, "Invalid report query", "")
+	case errors.Is(err, apperrors.ErrUnauthorizedTask), errors.Is(err, apperrors.ErrUnauthorizedProject):
+		return utils.Error(c, fiber.StatusForbidden, "You are not a member of this project", "")
+	case errors.Is(err, apperrors.ErrUnauthorizedTimeEntry):
+		return utils.Error(c, fiber.StatusForbidden, "Only the author or project owner can manage this time entry", "")
+	case errors.Is(err, apperrors.ErrProjectArchived):
+		return utils.Error(c, fiber.StatusConflict, "Project or board is archived", "")
+	default:
+		return utils.Error(c, fiber.StatusInternalServerError, message, err.Error())
+	}
+}
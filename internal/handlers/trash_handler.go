@@ -0,0 +1,101 @@
+package handlers
+
+import (
+	"errors"
+
+	apperrors "github.com/Hann-arc/task-management-backend/internal/errors"
+	"github.com/Hann-arc/task-management-backend/internal/services"
+	"github.com/Hann-arc/task-management-backend/internal/utils"
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+type TrashHandler struct {
+	service *services.TrashService
+}
+
+// NewTrashHandler creates a new instance of TrashHandler
+func NewTrashHandler(service *services.TrashService) *TrashHandler {
+	return &TrashHandler{service: service}
+}
+
+// GetProjectTrash lists the deleted boards, tasks and comments of a project
+func (h *TrashHandler) GetProjectTrash(c *fiber.Ctx) error {
+	userID := c.Locals("user_id").(uuid.UUID)
+	projectID, err := uuid.Parse(c.Params("projectId"))
+	if err != nil {
+		return utils.Error(c, fiber.StatusBadRequest, "Invalid project ID", "")
+	}
+
+	items, err := h.service.GetProjectTrash(projectID, userID)
+	if err != nil {
+		return h.handleError(c, err, "Failed to fetch trash")
+	}
+
+	return utils.Success(c, "Trash fetched successfully", items)
+}
+
+// GetDeletedProjects lists the deleted projects of the current user
+func (h *TrashHandler) GetDeletedProjects(c *fiber.Ctx) error {
+	userID := c.Locals("user_id").(uuid.UUID)
+
+	items, err := h.service.GetDeletedProjects(userID)
+	if err != nil {
+		return h.handleError(c, err, "Failed to fetch deleted projects")
+	}
+
+	return utils.Success(c, "Deleted projects fetched successfully", items)
+}
+
+// RestoreProject restores a deleted project
+func (h *TrashHandler) RestoreProject(c *fiber.Ctx) error {
+	return h.restore(c, "Project", h.service.RestoreProject)
+}
+
+// RestoreBoard restores a deleted board and its tasks
+func (h *TrashHandler) RestoreBoard(c *fiber.Ctx) error {
+	return h.restore(c, "Board", h.service.RestoreBoard)
+}
+
+// RestoreTask restores a deleted task and its comments
+func (h *TrashHandler) RestoreTask(c *fiber.Ctx) error {
+	return h.restore(c, "Task", h.service.RestoreTask)
+}
+
+// RestoreComment restores a deleted comment and its replies
+func (h *TrashHandler) RestoreComment(c *fiber.Ctx) error {
+	return h.restore(c, "Comment", h.service.RestoreComment)
+}
+
+// restore parses the item ID and runs the given restore action
+func (h *TrashHandler) restore(c *fiber.Ctx, kind string, action func(id, userID uuid.UUID) error) error {
+	userID := c.Locals("user_id").(uuid.UUID)
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return utils.Error(c, fiber.StatusBadRequest, "Invalid "+kind+" ID", "")
+	}
+
+	if err := action(id, userID); err != nil {
+		return h.handleError(c, err, "Failed to restore "+kind)
+	}
+
+	return utils.Success(c, kind+" restored successfully", nil)
+}
+
+// handleError maps trash errors to HTTP responses
+func (h *TrashHandler) handleError(c *fiber.Ctx, err error, message string) error {
+	switch {
+	case errors.Is(err, apperrors.ErrTrashItemNotFound):
+		return utils.Error(c, fiber.StatusNotFound, "Item not found in trash", "")
+	case errors.Is(err, apperrors.ErrTrashParentDeleted):
+		return utils.Error(c, fiber.StatusConflict, "The parent of this item is deleted and must be restored first", "")
+	case errors.Is(err, apperrors.ErrProjectArchived):
+		return utils.Error(c, fiber.StatusConflict, "Project or board is archived", "")
+	case errors.Is(err, apperrors.ErrUnauthorizedProject), errors.Is(err, apperrors.ErrUnauthorizedTask):
+		return utils.Error(c, fiber.StatusForbidden, "You are not a member of this project", "")
+	case errors.Is(err, apperrors.ErrUnauthorizedOwnerOnly), errors.Is(err, apperrors.ErrUnauthorizedBoardAction):
+		return utils.Error(c, fiber.StatusForbidden, "Only project owner can restore this item", "")
+	default:
+		return utils.Error(c, fiber.StatusInternalServerError, message, err.Error())
+	}
+}
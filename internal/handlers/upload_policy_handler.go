@@ -0,0 +1,74 @@
+package handlers
+
+import (
+	"errors"
+
+	"github.com/Hann-arc/task-management-backend/internal/dto"
+	apperrors "github.com/Hann-arc/task-management-backend/internal/errors"
+	"github.com/Hann-arc/task-management-backend/internal/services"
+	"github.com/Hann-arc/task-management-backend/internal/utils"
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+type UploadPolicyHandler struct {
+	service *services.UploadPolicyService
+}
+
+// NewUploadPolicyHandler creates a new instance of UploadPolicyHandler
+func NewUploadPolicyHandler(service *services.UploadPolicyService) *UploadPolicyHandler {
+	return &UploadPolicyHandler{service: service}
+}
+
+// GetPolicy returns the upload limits and storage usage of a project
+func (h *UploadPolicyHandler) GetPolicy(c *fiber.Ctx) error {
+	userID := c.Locals("user_id").(uuid.UUID)
+	projectID, err := uuid.Parse(c.Params("projectId"))
+	if err != nil {
+		return utils.Error(c, fiber.StatusBadRequest, "Invalid project ID", "")
+	}
+
+	policy, err := h.service.GetPolicy(projectID, userID)
+	if err != nil {
+		return h.handleError(c, err, "Failed to fetch upload policy")
+	}
+
+	return utils.Success(c, "Upload policy fetched successfully", policy)
+}
+
+// UpdatePolicy changes the upload limits of a project
+func (h *UploadPolicyHandler) UpdatePolicy(c *fiber.Ctx) error {
+	userID := c.Locals("user_id").(uuid.UUID)
+	projectID, err := uuid.Parse(c.Params("projectId"))
+	if err != nil {
+		return utils.Error(c, fiber.StatusBadRequest, "Invalid project ID", "")
+	}
+
+	var req dto.UpdateUploadPolicyRequest
+	if err := c.BodyParser(&req); err != nil {
+		return utils.Error(c, fiber.StatusBadRequest, "Invalid request body", "")
+	}
+
+	policy, err := h.service.UpdatePolicy(projectID, userID, &req)
+	if err != nil {
+		return h.handleError(c, err, "Failed to update upload policy")
+	}
+
+	return utils.Success(c, "Upload policy updated successfully", policy)
+}
+
+// handleError maps upload policy errors to HTTP responses
+func (h *UploadPolicyHandler) handleError(c *fiber.Ctx, err error, message string) error {
+	switch {
+	case errors.Is(err, apperrors.ErrInvalidUploadPolicy):
+		return utils.Error(c, fiber.StatusBadRequest, "Invalid upload policy", "")
+	case errors.Is(err, apperrors.ErrUnauthorizedProject):
+		return utils.Error(c, fiber.StatusForbidden, "You are not a member of this project", "")
+	case errors.Is(err, apperrors.ErrUnauthorizedOwnerOnly):
+		return utils.Error(c, fiber.StatusForbidden, "Only project owner can change the upload policy", "")
+	case errors.Is(err, apperrors.ErrProjectArchived):
+		return utils.Error(c, fiber.StatusConflict, "Project or board is archived", "")
+	default:
+		return utils.Error(c, fiber.StatusInternalServerError, message, err.Error())
+	}
+}
@@ -51,21 +51,18 @@ func (h *UserHandler) UpdateProfile(c *fiber.Ctx) error {
 		Name: &name,
 	}
 
-	// If avatar file exists, upload to cloudinary
+	// If avatar file exists, upload it to storage
 	if avatarFile != nil {
-		if avatarFile.Size > 5*1024*1024 {
-			return utils.Error(c, fiber.StatusBadRequest, "Avatar file too large (max 5MB)", "")
-		}
-
-		// Validate file type
-		contentType := avatarFile.Header.Get("Content-Type")
-		if !isImageFile(contentType) {
-			return utils.Error(c, fiber.StatusBadRequest, "Invalid file type. Only images are allowed", "")
-		}
-
-		avatarURL, err := utils.UploadToCloudinary(avatarFile)
+		avatarURL, err := h.service.UploadAvatar(avatarFile)
 		if err != nil {
-			return utils.Error(c, fiber.StatusInternalServerError, "Failed to upload avatar", err.Error())
+			switch {
+			case errors.Is(err, apperrors.ErrAvatarTooLarge):
+				return utils.Error(c, fiber.StatusBadRequest, "Avatar file too large (max 5MB)", "")
+			case errors.Is(err, apperrors.ErrInvalidAvatarType):
+				return utils.Error(c, fiber.StatusBadRequest, "Invalid file type. Only images are allowed", "")
+			default:
+				return utils.Error(c, fiber.StatusInternalServerError, "Failed to upload avatar", err.Error())
+			}
 		}
 		req.AvatarUrl = &avatarURL
 	}
@@ -85,13 +82,6 @@ func (h *UserHandler) UpdateProfile(c *fiber.Ctx) error {
 	return utils.Success(c, "User profile updated successfully", user)
 }
 
-func isImageFile(contentType string) bool {
-	return contentType == "image/jpeg" ||
-		contentType == "image/png" ||
-		contentType == "image/gif" ||
-		contentType == "image/webp"
-}
-
 func (h *UserHandler) GetAllUsers(c *fiber.Ctx) error {
 	users, err := h.service.GetAllUsers()
 	if err != nil {
@@ -0,0 +1,63 @@
+package jobs
+
+import (
+	"context"
+	"log"
+	"os"
+	"time"
+
+	"github.com/Hann-arc/task-management-backend/config"
+	"github.com/Hann-arc/task-management-backend/internal/repository"
+	"github.com/Hann-arc/task-management-backend/internal/services"
+)
+
+// Start launches the background jobs; they stop when the context is cancelled
+func Start(ctx context.Context) {
+	taskRepo := repository.NewTaskRepository(config.DB)
+	projectRepo := repository.NewProjectRepository(config.DB)
+	activityLogRepo := repository.NewActivityLogRepository(config.DB)
+	notificationRepo := repository.NewNotificationRepository(config.DB)
+	recurrenceRepo := repository.NewTaskRecurrenceRepository(config.DB)
+	reminderRepo := repository.NewTaskReminderRepository(config.DB)
+
+	activityLogService := services.NewActivityLogService(activityLogRepo)
+	notificationService := services.NewNotificationService(notificationRepo)
+	recurrenceService := services.NewTaskRecurrenceService(recurrenceRepo, taskRepo, projectRepo, activityLogService, notificationService)
+
+	go runEvery(ctx, "recurring tasks", time.Minute, recurrenceService.ProcessDueRecurrences)
+
+	leadTimes := []time.Duration{24 * time.Hour, time.Hour}
+	if value := os.Getenv("REMINDER_LEAD_TIMES"); value != "" {
+		parsed, err := services.ParseLeadTimes(value)
+		if err != nil {
+			log.Fatal("Invalid REMINDER_LEAD_TIMES:", err)
+		}
+		leadTimes = parsed
+	}
+	reminderService := services.NewTaskReminderService(reminderRepo, notificationService, leadTimes)
+
+	go runEvery(ctx, "due date reminders", time.Minute, reminderService.ProcessReminders)
+
+	trashRepo := repository.NewTrashRepository(config.DB)
+	trashService := services.NewTrashService(trashRepo, projectRepo, config.Storage, activityLogService)
+
+	go runEvery(ctx, "trash purge", time.Hour, trashService.PurgeExpired)
+}
+
+// runEvery calls job immediately and then on every tick until the context is done
+func runEvery(ctx context.Context, name string, interval time.Duration, job func() error) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if err := job(); err != nil {
+			log.Printf("Job %s failed: %v", name, err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
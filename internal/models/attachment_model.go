@@ -1,6 +1,10 @@
 package models
 
-import "github.com/google/uuid"
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
 
 type Attachment struct {
 	ID         uuid.UUID `json:"id" gorm:"type:uuid;default:uuid_generate_v4();primaryKey"`
@@ -8,8 +12,23 @@ type Attachment struct {
 	FileUrl    string    `json:"file_url"`
 	UploadedBy uuid.UUID `json:"uploaded_by" gorm:"type:uuid;not null"`
 
+	// CommentID links the attachment to a comment on its task, such as a pasted screenshot
+	CommentID *uuid.UUID `json:"comment_id,omitempty" gorm:"type:uuid;index"`
+
+	// metadata captured at upload; the MIME type is sniffed from the content
+	FileName     string  `json:"file_name"`
+	FileSize     int64   `json:"file_size" gorm:"not null;default:0"`
+	MimeType     string  `json:"mime_type"`
+	ThumbnailUrl *string `json:"thumbnail_url,omitempty"`
+
+	// Version is the number of the latest version, whose file the fields above mirror
+	Version int `json:"version" gorm:"not null;default:1"`
+
+	CreatedAt time.Time `json:"created_at"`
+
 	// relationships
 
-	Task     Task `json:"task" gorm:"foreignKey:TaskID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
-	Uploader User `json:"uploader" gorm:"foreignKey:UploadedBy;constraint:OnUpdate:CASCADE,OnDelete:SET NULL;"`
+	Task     Task     `json:"task" gorm:"foreignKey:TaskID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
+	Comment  *Comment `json:"comment,omitempty" gorm:"foreignKey:CommentID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
+	Uploader User     `json:"uploader" gorm:"foreignKey:UploadedBy;constraint:OnUpdate:CASCADE,OnDelete:SET NULL;"`
 }
@@ -0,0 +1,26 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// AttachmentVersion is one uploaded revision of an attachment; the attachment itself mirrors
+// the file of its latest version
+type AttachmentVersion struct {
+	ID           uuid.UUID `json:"id" gorm:"type:uuid;default:uuid_generate_v4();primaryKey"`
+	AttachmentID uuid.UUID `json:"attachment_id" gorm:"type:uuid;not null;uniqueIndex:idx_attachment_version"`
+	Version      int       `json:"version" gorm:"not null;uniqueIndex:idx_attachment_version"`
+	FileUrl      string    `json:"file_url" gorm:"not null"`
+	FileName     string    `json:"file_name"`
+	FileSize     int64     `json:"file_size" gorm:"not null;default:0"`
+	MimeType     string    `json:"mime_type"`
+	ThumbnailUrl *string   `json:"thumbnail_url,omitempty"`
+	UploadedBy   uuid.UUID `json:"uploaded_by" gorm:"type:uuid;not null"`
+	CreatedAt    time.Time `json:"created_at"`
+
+	// Relationships
+	Attachment Attachment `json:"attachment" gorm:"foreignKey:AttachmentID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
+	Uploader   User       `json:"uploader" gorm:"foreignKey:UploadedBy;constraint:OnUpdate:CASCADE,OnDelete:SET NULL;"`
+}
@@ -12,6 +12,7 @@ type Board struct {
 	Name       string         `json:"name"`
 	OrderIndex int            `json:"order_index"`
 	ProjectID  uuid.UUID      `json:"project_id" gorm:"type:uuid;not null"`
+	ArchivedAt *time.Time     `json:"archived_at,omitempty" gorm:"index"`
 	DeletedAt  gorm.DeletedAt `gorm:"index" json:"deleted_at"`
 
 	CreatedAt time.Time `json:"created_at"`
@@ -0,0 +1,20 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// CommentEdit keeps the content a comment had before one of its edits
+type CommentEdit struct {
+	ID        uuid.UUID `json:"id" gorm:"type:uuid;default:uuid_generate_v4();primaryKey"`
+	CommentID uuid.UUID `json:"comment_id" gorm:"type:uuid;not null;index"`
+	EditorID  uuid.UUID `json:"editor_id" gorm:"type:uuid;not null"`
+	Content   string    `json:"content" gorm:"not null"`
+	CreatedAt time.Time `json:"created_at"`
+
+	// Relationships
+	Comment Comment `json:"comment" gorm:"foreignKey:CommentID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
+	Editor  User    `json:"editor" gorm:"foreignKey:EditorID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
+}
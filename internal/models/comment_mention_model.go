@@ -0,0 +1,22 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// CommentMention links a comment to a project member it mentions; Handle keeps the text as
+// written so clients can render it as a link after the user is renamed
+type CommentMention struct {
+	ID        uuid.UUID `json:"id" gorm:"type:uuid;default:uuid_generate_v4();primaryKey"`
+	CommentID uuid.UUID `json:"comment_id" gorm:"type:uuid;not null;uniqueIndex:idx_comment_mention_unique"`
+	UserID    uuid.UUID `json:"user_id" gorm:"type:uuid;not null;uniqueIndex:idx_comment_mention_unique;index"`
+	Handle    string    `json:"handle" gorm:"not null"`
+
+	CreatedAt time.Time `json:"created_at"`
+
+	// Relationships
+	Comment Comment `json:"comment" gorm:"foreignKey:CommentID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
+	User    User    `json:"user" gorm:"foreignKey:UserID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
+}
@@ -0,0 +1,48 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	CustomFieldTypeText         = "text"
+	CustomFieldTypeNumber       = "number"
+	CustomFieldTypeDate         = "date"
+	CustomFieldTypeSingleSelect = "single_select"
+	CustomFieldTypeMultiSelect  = "multi_select"
+	CustomFieldTypeUser         = "user"
+	CustomFieldTypeCheckbox     = "checkbox"
+)
+
+// CustomField is a project-scoped task field definition; Options lists the choices of select fields
+type CustomField struct {
+	ID        uuid.UUID `json:"id" gorm:"type:uuid;default:uuid_generate_v4();primaryKey"`
+	ProjectID uuid.UUID `json:"project_id" gorm:"type:uuid;not null;uniqueIndex:idx_custom_field_project_name"`
+	Name      string    `json:"name" gorm:"not null;uniqueIndex:idx_custom_field_project_name"`
+	Type      string    `json:"type" gorm:"not null"`
+	Options   []byte    `json:"options" gorm:"type:jsonb"`
+	Required  bool      `json:"required" gorm:"not null;default:false"`
+	Position  int       `json:"position" gorm:"not null;default:0"`
+	CreatedBy uuid.UUID `json:"created_by" gorm:"type:uuid;not null"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+
+	// Relationships
+	Project Project                `json:"project" gorm:"foreignKey:ProjectID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
+	Values  []TaskCustomFieldValue `json:"values" gorm:"foreignKey:FieldID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
+}
+
+// TaskCustomFieldValue holds the JSON encoded value of a custom field for a task
+type TaskCustomFieldValue struct {
+	ID        uuid.UUID `json:"id" gorm:"type:uuid;default:uuid_generate_v4();primaryKey"`
+	TaskID    uuid.UUID `json:"task_id" gorm:"type:uuid;not null;uniqueIndex:idx_task_custom_field_value"`
+	FieldID   uuid.UUID `json:"field_id" gorm:"type:uuid;not null;uniqueIndex:idx_task_custom_field_value;index"`
+	Value     []byte    `json:"value" gorm:"type:jsonb;not null"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+
+	// Relationships
+	Field CustomField `json:"field" gorm:"foreignKey:FieldID;references:ID"`
+}
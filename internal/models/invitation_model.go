@@ -4,6 +4,7 @@ import (
 	"time"
 
 	"github.com/google/uuid"
+	"gorm.io/gorm"
 )
 
 type Invitation struct {
@@ -14,7 +15,8 @@ type Invitation struct {
 	Token     string    `json:"token" gorm:"not null;unique"`
 	Status    string    `json:"status" gorm:"not null;default:'pending'"`
 
-	CreatedAt time.Time `json:"created_at"`
+	CreatedAt time.Time      `json:"created_at"`
+	DeletedAt gorm.DeletedAt `gorm:"index" json:"deleted_at"`
 
 	// Relationships
 
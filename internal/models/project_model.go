@@ -12,6 +12,10 @@ type Project struct {
 	Name        string    `json:"name" gorm:"not null"`
 	OwnerID     uuid.UUID `json:"owner_id" gorm:"type:uuid;not null"`
 	Description string    `json:"description"`
+	IsTemplate  bool      `json:"is_template" gorm:"not null;default:false;index"`
+
+	// ArchivedAt marks the project as archived and read-only
+	ArchivedAt *time.Time `json:"archived_at,omitempty" gorm:"index"`
 
 	CreatedAt time.Time      `json:"created_at"`
 	UpdatedAt time.Time      `json:"updated_at"`
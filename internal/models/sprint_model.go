@@ -0,0 +1,34 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	SprintStatePlanned = "planned"
+	SprintStateActive  = "active"
+	SprintStateClosed  = "closed"
+)
+
+// Sprint is a time-boxed iteration of a project; CompletedPoints is the velocity recorded when it closes
+type Sprint struct {
+	ID              uuid.UUID  `json:"id" gorm:"type:uuid;default:uuid_generate_v4();primaryKey"`
+	ProjectID       uuid.UUID  `json:"project_id" gorm:"type:uuid;not null;index;uniqueIndex:idx_sprints_active_project,where:state = 'active'"`
+	Name            string     `json:"name" gorm:"not null"`
+	Goal            string     `json:"goal"`
+	StartDate       time.Time  `json:"start_date" gorm:"not null"`
+	EndDate         time.Time  `json:"end_date" gorm:"not null"`
+	State           string     `json:"state" gorm:"not null;default:'planned'"`
+	CommittedPoints int        `json:"committed_points" gorm:"not null;default:0"`
+	CompletedPoints int        `json:"completed_points" gorm:"not null;default:0"`
+	ClosedAt        *time.Time `json:"closed_at,omitempty"`
+	CreatedBy       uuid.UUID  `json:"created_by" gorm:"type:uuid;not null"`
+	CreatedAt       time.Time  `json:"created_at"`
+	UpdatedAt       time.Time  `json:"updated_at"`
+
+	// Relationships
+	Project Project `json:"project" gorm:"foreignKey:ProjectID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
+	Tasks   []Task  `json:"tasks" gorm:"foreignKey:SprintID;constraint:OnUpdate:CASCADE,OnDelete:SET NULL;"`
+}
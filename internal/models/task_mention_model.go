@@ -0,0 +1,22 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// TaskMention links a task to a project member mentioned in its description; Handle keeps the
+// text as written so clients can render it as a link after the user is renamed
+type TaskMention struct {
+	ID     uuid.UUID `json:"id" gorm:"type:uuid;default:uuid_generate_v4();primaryKey"`
+	TaskID uuid.UUID `json:"task_id" gorm:"type:uuid;not null;uniqueIndex:idx_task_mention_unique"`
+	UserID uuid.UUID `json:"user_id" gorm:"type:uuid;not null;uniqueIndex:idx_task_mention_unique;index"`
+	Handle string    `json:"handle" gorm:"not null"`
+
+	CreatedAt time.Time `json:"created_at"`
+
+	// Relationships
+	Task Task `json:"task" gorm:"foreignKey:TaskID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
+	User User `json:"user" gorm:"foreignKey:UserID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
+}
@@ -7,24 +7,38 @@ import (
 	"gorm.io/gorm"
 )
 
+const (
+	TaskStatusTodo       = "todo"
+	TaskStatusInProgress = "in_progress"
+	TaskStatusDone       = "done"
+)
+
 type Task struct {
-	ID          uuid.UUID      `json:"id" gorm:"type:uuid;default:uuid_generate_v4();primaryKey"`
-	BoardID     uuid.UUID      `json:"board_id" gorm:"type:uuid;not null"`
-	Title       string         `json:"title" gorm:"not null"`
-	Description string         `json:"description"`
-	Priority    string         `json:"priority" gorm:"not null"`
-	DueDate     time.Time      `json:"due_date"`
-	AssigneeID  *uuid.UUID     `json:"assignee_id,omitempty" gorm:"type:uuid"`
-	CreatedBy   uuid.UUID      `json:"created_by" gorm:"type:uuid;not null"`
-	CreatedAt   time.Time      `json:"created_at"`
-	UpdatedAt   time.Time      `json:"updated_at"`
-	DeletedAt   gorm.DeletedAt `gorm:"index" json:"deleted_at"`
+	ID              uuid.UUID      `json:"id" gorm:"type:uuid;default:uuid_generate_v4();primaryKey"`
+	BoardID         uuid.UUID      `json:"board_id" gorm:"type:uuid;not null"`
+	Title           string         `json:"title" gorm:"not null"`
+	Description     string         `json:"description"`
+	Priority        string         `json:"priority" gorm:"not null"`
+	Status          string         `json:"status" gorm:"not null;default:'todo'"`
+	DueDate         time.Time      `json:"due_date"`
+	CompletedAt     *time.Time     `json:"completed_at,omitempty"`
+	EstimateMinutes *int           `json:"estimate_minutes,omitempty"`
+	StoryPoints     *int           `json:"story_points,omitempty"`
+	SprintID        *uuid.UUID     `json:"sprint_id,omitempty" gorm:"type:uuid;index"`
+	CreatedBy       uuid.UUID      `json:"created_by" gorm:"type:uuid;not null"`
+	CreatedAt       time.Time      `json:"created_at"`
+	UpdatedAt       time.Time      `json:"updated_at"`
+	DeletedAt       gorm.DeletedAt `gorm:"index" json:"deleted_at"`
 
 	// Relationships
-	Board       Board        `json:"board" gorm:"foreignKey:BoardID;references:ID"`
-	Assignee    User         `json:"assignee,omitempty" gorm:"foreignKey:AssigneeID;references:ID"`
-	Creator     User         `json:"creator" gorm:"foreignKey:CreatedBy;references:ID"`
-	Labels      []TaskLabel  `json:"labels" gorm:"foreignKey:TaskID;references:ID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
-	Comments    []Comment    `json:"comments" gorm:"foreignKey:TaskID;references:ID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
-	Attachments []Attachment `json:"attachments" gorm:"foreignKey:TaskID;references:ID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
+	Board             Board                  `json:"board" gorm:"foreignKey:BoardID;references:ID"`
+	Assignees         []TaskAssignee         `json:"assignees" gorm:"foreignKey:TaskID;references:ID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
+	Watchers          []TaskWatcher          `json:"watchers" gorm:"foreignKey:TaskID;references:ID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
+	Creator           User                   `json:"creator" gorm:"foreignKey:CreatedBy;references:ID"`
+	Labels            []TaskLabel            `json:"labels" gorm:"foreignKey:TaskID;references:ID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
+	Comments          []Comment              `json:"comments" gorm:"foreignKey:TaskID;references:ID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
+	Attachments       []Attachment           `json:"attachments" gorm:"foreignKey:TaskID;references:ID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
+	TimeEntries       []TimeEntry            `json:"time_entries" gorm:"foreignKey:TaskID;references:ID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
+	CustomFieldValues []TaskCustomFieldValue `json:"custom_field_values" gorm:"foreignKey:TaskID;references:ID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
+	Mentions          []TaskMention          `json:"mentions" gorm:"foreignKey:TaskID;references:ID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
 }
@@ -0,0 +1,25 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type TaskRecurrence struct {
+	ID         uuid.UUID  `json:"id" gorm:"type:uuid;default:uuid_generate_v4();primaryKey"`
+	TemplateID uuid.UUID  `json:"template_id" gorm:"type:uuid;not null;uniqueIndex"`
+	BoardID    uuid.UUID  `json:"board_id" gorm:"type:uuid;not null"`
+	Rule       string     `json:"rule" gorm:"not null"`
+	StartAt    time.Time  `json:"start_at" gorm:"not null"`
+	NextRunAt  time.Time  `json:"next_run_at" gorm:"not null;index"`
+	LastTaskID *uuid.UUID `json:"last_task_id,omitempty" gorm:"type:uuid"`
+	IsActive   bool       `json:"is_active" gorm:"not null;default:true"`
+	CreatedBy  uuid.UUID  `json:"created_by" gorm:"type:uuid;not null"`
+	CreatedAt  time.Time  `json:"created_at"`
+	UpdatedAt  time.Time  `json:"updated_at"`
+
+	// Relationships
+	Template Task  `json:"template" gorm:"foreignKey:TemplateID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
+	Board    Board `json:"board" gorm:"foreignKey:BoardID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
+}
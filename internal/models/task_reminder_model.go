@@ -0,0 +1,25 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	ReminderKindDueSoon = "due_soon"
+	ReminderKindOverdue = "overdue"
+)
+
+// TaskReminder marks a reminder as sent for a given due date, so each one goes out at most once
+type TaskReminder struct {
+	ID          uuid.UUID `json:"id" gorm:"type:uuid;default:uuid_generate_v4();primaryKey"`
+	TaskID      uuid.UUID `json:"task_id" gorm:"type:uuid;not null;uniqueIndex:idx_task_reminder_unique"`
+	Kind        string    `json:"kind" gorm:"not null;uniqueIndex:idx_task_reminder_unique"`
+	LeadMinutes int       `json:"lead_minutes" gorm:"not null;uniqueIndex:idx_task_reminder_unique"`
+	DueDate     time.Time `json:"due_date" gorm:"not null;uniqueIndex:idx_task_reminder_unique"`
+	SentAt      time.Time `json:"sent_at"`
+
+	// Relationships
+	Task Task `json:"task" gorm:"foreignKey:TaskID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
+}
@@ -0,0 +1,28 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// TaskTemplate is a reusable task blueprint of a project; TitlePattern and Description may contain
+// {{variables}} expanded when a task is created from it, Labels and Checklist are stored as JSON
+type TaskTemplate struct {
+	ID                uuid.UUID  `json:"id" gorm:"type:uuid;default:uuid_generate_v4();primaryKey"`
+	ProjectID         uuid.UUID  `json:"project_id" gorm:"type:uuid;not null;uniqueIndex:idx_task_template_project_name"`
+	Name              string     `json:"name" gorm:"not null;uniqueIndex:idx_task_template_project_name"`
+	TitlePattern      string     `json:"title_pattern" gorm:"not null"`
+	Description       string     `json:"description"`
+	Priority          string     `json:"priority" gorm:"not null;default:'medium'"`
+	Labels            []byte     `json:"labels" gorm:"type:jsonb"`
+	Checklist         []byte     `json:"checklist" gorm:"type:jsonb"`
+	DefaultAssigneeID *uuid.UUID `json:"default_assignee_id,omitempty" gorm:"type:uuid"`
+	CreatedBy         uuid.UUID  `json:"created_by" gorm:"type:uuid;not null"`
+	CreatedAt         time.Time  `json:"created_at"`
+	UpdatedAt         time.Time  `json:"updated_at"`
+
+	// Relationships
+	Project         Project `json:"project" gorm:"foreignKey:ProjectID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
+	DefaultAssignee *User   `json:"default_assignee,omitempty" gorm:"foreignKey:DefaultAssigneeID;constraint:OnUpdate:CASCADE,OnDelete:SET NULL;"`
+}
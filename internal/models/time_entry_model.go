@@ -0,0 +1,24 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// TimeEntry records time spent on a task; an entry without EndedAt is a running timer
+type TimeEntry struct {
+	ID              uuid.UUID  `json:"id" gorm:"type:uuid;default:uuid_generate_v4();primaryKey"`
+	TaskID          uuid.UUID  `json:"task_id" gorm:"type:uuid;not null;index"`
+	UserID          uuid.UUID  `json:"user_id" gorm:"type:uuid;not null;index;uniqueIndex:idx_time_entries_running,where:ended_at IS NULL"`
+	StartedAt       time.Time  `json:"started_at" gorm:"not null;index"`
+	EndedAt         *time.Time `json:"ended_at,omitempty"`
+	DurationSeconds int64      `json:"duration_seconds" gorm:"not null;default:0"`
+	Note            string     `json:"note"`
+	CreatedAt       time.Time  `json:"created_at"`
+	UpdatedAt       time.Time  `json:"updated_at"`
+
+	// Relationships
+	Task Task `json:"task" gorm:"foreignKey:TaskID;references:ID"`
+	User User `json:"user" gorm:"foreignKey:UserID;references:ID"`
+}
@@ -0,0 +1,23 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ProjectUploadPolicy overrides the default upload limits of a project; zero sizes fall back
+// to the server defaults, and the MIME lists hold JSON arrays of types such as "image/*"
+type ProjectUploadPolicy struct {
+	ProjectID        uuid.UUID `json:"project_id" gorm:"type:uuid;primaryKey"`
+	MaxFileSize      int64     `json:"max_file_size" gorm:"not null;default:0"`
+	StorageQuota     int64     `json:"storage_quota" gorm:"not null;default:0"`
+	AllowedMimeTypes []byte    `json:"allowed_mime_types" gorm:"type:jsonb"`
+	DeniedMimeTypes  []byte    `json:"denied_mime_types" gorm:"type:jsonb"`
+	UpdatedBy        uuid.UUID `json:"updated_by" gorm:"type:uuid;not null"`
+	CreatedAt        time.Time `json:"created_at"`
+	UpdatedAt        time.Time `json:"updated_at"`
+
+	// Relationships
+	Project Project `json:"project" gorm:"foreignKey:ProjectID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
+}
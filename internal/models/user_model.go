@@ -21,7 +21,8 @@ type User struct {
 	// Relationships
 	OwenedProjects []Project       `json:"owned_projects" gorm:"foreignKey:OwnerID;references:ID"`
 	ProjectMembers []ProjectMember `json:"project_members" gorm:"foreignKey:UserID;references:ID"`
-	TasksAssigned  []Task          `json:"task_assigned" gorm:"foreignKey:AssigneeID;references:ID"`
+	TasksAssigned  []TaskAssignee  `json:"task_assigned" gorm:"foreignKey:UserID;references:ID"`
+	TasksWatched   []TaskWatcher   `json:"task_watched" gorm:"foreignKey:UserID;references:ID"`
 	TasksCreated   []Task          `json:"task_created" gorm:"foreignKey:CreatedBy;references:ID"`
 	Comments       []Comment       `json:"comments" gorm:"foreignKey:UserID;references:ID"`
 	Attachments    []Attachment    `json:"attachments" gorm:"foreignKey:UploadedBy;references:ID"`
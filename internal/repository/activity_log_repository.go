@@ -58,7 +58,7 @@ func (r *ActivityLogRepository) FindByProjectID(projectID uuid.UUID, limit, offs
 func (r *ActivityLogRepository) IsProjectMember(projectID, userID uuid.UUID) (bool, error) {
 	var count int64
 	err := r.DB.Table("projects").
-		Where("id = ? AND owner_id = ?", projectID, userID).
+		Where("id = ? AND owner_id = ? AND deleted_at IS NULL", projectID, userID).
 		Count(&count).Error
 	if err != nil {
 		return false, err
@@ -68,7 +68,8 @@ func (r *ActivityLogRepository) IsProjectMember(projectID, userID uuid.UUID) (bo
 	}
 
 	err = r.DB.Table("project_members").
-		Where("project_id = ? AND user_id = ?", projectID, userID).
+		Joins("JOIN projects ON projects.id = project_members.project_id AND projects.deleted_at IS NULL").
+		Where("project_members.project_id = ? AND project_members.user_id = ?", projectID, userID).
 		Count(&count).Error
 	return err == nil && count > 0, nil
 }
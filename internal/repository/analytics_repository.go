@@ -0,0 +1,52 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/Hann-arc/task-management-backend/internal/models"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// TaskStatusEvent is a status recorded for a task in the activity log
+type TaskStatusEvent struct {
+	TaskID    uuid.UUID
+	Status    string
+	CreatedAt time.Time
+}
+
+type AnalyticsRepository struct {
+	DB *gorm.DB
+}
+
+// NewAnalyticsRepository creates a new instance of AnalyticsRepository
+func NewAnalyticsRepository(db *gorm.DB) *AnalyticsRepository {
+	return &AnalyticsRepository{DB: db}
+}
+
+// FindProjectTasks retrieves every task of a project, including deleted ones so that
+// historical snapshots stay accurate, optionally limited to a sprint
+func (r *AnalyticsRepository) FindProjectTasks(projectID uuid.UUID, sprintID *uuid.UUID) ([]models.Task, error) {
+	var tasks []models.Task
+	query := r.DB.Unscoped().Model(&models.Task{}).
+		Select("tasks.id, tasks.status, tasks.story_points, tasks.sprint_id, tasks.created_at, tasks.updated_at, tasks.completed_at, tasks.deleted_at").
+		Joins("JOIN boards ON boards.id = tasks.board_id").
+		Where("boards.project_id = ?", projectID)
+	if sprintID != nil {
+		query = query.Where("tasks.sprint_id = ?", *sprintID)
+	}
+	err := query.Find(&tasks).Error
+	return tasks, err
+}
+
+// FindStatusEvents retrieves the task status changes recorded in the activity log of a project
+func (r *AnalyticsRepository) FindStatusEvents(projectID uuid.UUID) ([]TaskStatusEvent, error) {
+	var events []TaskStatusEvent
+	err := r.DB.Model(&models.ActivityLog{}).
+		Select("(details->>'task_id')::uuid AS task_id, details->>'status' AS status, created_at").
+		Where("project_id = ? AND action IN ?", projectID, []string{"task.created", "task.recurred", "task.updated"}).
+		Where("details->>'status' IS NOT NULL AND details->>'task_id' IS NOT NULL").
+		Order("created_at ASC").
+		Scan(&events).Error
+	return events, err
+}
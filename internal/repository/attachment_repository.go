@@ -4,6 +4,7 @@ import (
 	"github.com/Hann-arc/task-management-backend/internal/models"
 	"github.com/google/uuid"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type AttachmentRepository struct {
@@ -15,30 +16,201 @@ func NewAttachmentRepository(db *gorm.DB) *AttachmentRepository {
 	return &AttachmentRepository{DB: db}
 }
 
-// Create saves a new attachment record in the database
-func (r *AttachmentRepository) Create(attachment *models.Attachment) error {
-	return r.DB.Create(attachment).Error
+// Create saves new attachments of a task in the database along with their first versions. When
+// quota is positive the files must fit in the storage left to the project; false is returned
+// and nothing is saved when they do not
+func (r *AttachmentRepository) Create(attachments []*models.Attachment, quota int64) (bool, error) {
+	if len(attachments) == 0 {
+		return true, nil
+	}
+
+	var size int64
+	for _, attachment := range attachments {
+		size += attachment.FileSize
+	}
+
+	reserved := false
+	err := r.DB.Transaction(func(tx *gorm.DB) error {
+		fits, err := reserveStorage(tx, attachments[0].TaskID, size, quota)
+		if err != nil || !fits {
+			return err
+		}
+
+		for _, attachment := range attachments {
+			attachment.Version = 1
+			if err := tx.Create(attachment).Error; err != nil {
+				return err
+			}
+
+			if err := tx.Create(&models.AttachmentVersion{
+				AttachmentID: attachment.ID,
+				Version:      1,
+				FileUrl:      attachment.FileUrl,
+				FileName:     attachment.FileName,
+				FileSize:     attachment.FileSize,
+				MimeType:     attachment.MimeType,
+				ThumbnailUrl: attachment.ThumbnailUrl,
+				UploadedBy:   attachment.UploadedBy,
+				CreatedAt:    attachment.CreatedAt,
+			}).Error; err != nil {
+				return err
+			}
+		}
+		reserved = true
+		return nil
+	})
+	return reserved, err
+}
+
+// AddVersion numbers and saves a new version of an attachment and makes it the latest one. The
+// quota is enforced as in Create
+func (r *AttachmentRepository) AddVersion(version *models.AttachmentVersion, quota int64) (bool, error) {
+	reserved := false
+	err := r.DB.Transaction(func(tx *gorm.DB) error {
+		var attachment models.Attachment
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&attachment, "id = ?", version.AttachmentID).Error; err != nil {
+			return err
+		}
+
+		fits, err := reserveStorage(tx, attachment.TaskID, version.FileSize, quota)
+		if err != nil || !fits {
+			return err
+		}
+
+		var latest int
+		if err := tx.Model(&models.AttachmentVersion{}).
+			Where("attachment_id = ?", version.AttachmentID).
+			Select("COALESCE(MAX(version), 0)").
+			Scan(&latest).Error; err != nil {
+			return err
+		}
+
+		version.Version = latest + 1
+		if err := tx.Create(version).Error; err != nil {
+			return err
+		}
+		if err := mirrorVersion(tx, version); err != nil {
+			return err
+		}
+		reserved = true
+		return nil
+	})
+	return reserved, err
+}
+
+// FindVersions retrieves the versions of an attachment, newest first
+func (r *AttachmentRepository) FindVersions(attachmentID uuid.UUID) ([]models.AttachmentVersion, error) {
+	var versions []models.AttachmentVersion
+	err := r.DB.Where("attachment_id = ?", attachmentID).Preload("Uploader", func(db *gorm.DB) *gorm.DB {
+		return db.Select("id, name")
+	}).Order("version DESC").Find(&versions).Error
+	return versions, err
+}
+
+// FindVersion retrieves a single version of an attachment
+func (r *AttachmentRepository) FindVersion(attachmentID uuid.UUID, number int) (*models.AttachmentVersion, error) {
+	var version models.AttachmentVersion
+	err := r.DB.First(&version, "attachment_id = ? AND version = ?", attachmentID, number).Error
+	return &version, err
+}
+
+// DeleteVersion removes a version of an attachment; when the latest version is removed the
+// attachment falls back to the newest remaining one
+func (r *AttachmentRepository) DeleteVersion(version *models.AttachmentVersion) error {
+	return r.DB.Transaction(func(tx *gorm.DB) error {
+		var attachment models.Attachment
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&attachment, "id = ?", version.AttachmentID).Error; err != nil {
+			return err
+		}
+
+		if err := tx.Delete(&models.AttachmentVersion{}, "id = ?", version.ID).Error; err != nil {
+			return err
+		}
+		if attachment.Version != version.Version {
+			return nil
+		}
+
+		var previous models.AttachmentVersion
+		if err := tx.Where("attachment_id = ?", version.AttachmentID).Order("version DESC").First(&previous).Error; err != nil {
+			return err
+		}
+		return mirrorVersion(tx, &previous)
+	})
+}
+
+// CountVersions counts the versions of an attachment
+func (r *AttachmentRepository) CountVersions(attachmentID uuid.UUID) (int64, error) {
+	var count int64
+	err := r.DB.Model(&models.AttachmentVersion{}).Where("attachment_id = ?", attachmentID).Count(&count).Error
+	return count, err
 }
 
-// FindByTaskID retrieves all attachments associated with a specific task
+// FindProjectID resolves the project the task of an attachment belongs to
+func (r *AttachmentRepository) FindProjectID(taskID uuid.UUID) (uuid.UUID, error) {
+	var board models.Board
+	err := r.DB.Model(&models.Board{}).
+		Select("boards.project_id").
+		Joins("JOIN tasks ON tasks.board_id = boards.id").
+		Where("tasks.id = ?", taskID).
+		First(&board).Error
+	return board.ProjectID, err
+}
+
+// mirrorVersion copies the file of a version onto its attachment
+func mirrorVersion(tx *gorm.DB, version *models.AttachmentVersion) error {
+	return tx.Model(&models.Attachment{}).Where("id = ?", version.AttachmentID).Updates(map[string]interface{}{
+		"version":       version.Version,
+		"file_url":      version.FileUrl,
+		"file_name":     version.FileName,
+		"file_size":     version.FileSize,
+		"mime_type":     version.MimeType,
+		"thumbnail_url": version.ThumbnailUrl,
+	}).Error
+}
+
+// FindByTaskID retrieves all attachments associated with a specific task, leaving out those
+// that belong to its comments
 func (r *AttachmentRepository) FindByTaskID(taskID uuid.UUID) ([]models.Attachment, error) {
 	var attachments []models.Attachment
 
-	err := r.DB.Where("task_id = ?", taskID).Preload("Uploader", func(db *gorm.DB) *gorm.DB {
+	err := r.DB.Where("task_id = ? AND comment_id IS NULL", taskID).Preload("Uploader", func(db *gorm.DB) *gorm.DB {
 		return db.Select("id, name")
 	}).Find(&attachments).Error
 
 	return attachments, err
 }
 
+// FindByCommentIDs retrieves the attachments of the given comments, oldest first
+func (r *AttachmentRepository) FindByCommentIDs(commentIDs []uuid.UUID) ([]models.Attachment, error) {
+	var attachments []models.Attachment
+	if len(commentIDs) == 0 {
+		return attachments, nil
+	}
+
+	err := r.DB.Where("comment_id IN ?", commentIDs).Preload("Uploader", func(db *gorm.DB) *gorm.DB {
+		return db.Select("id, name")
+	}).Order("created_at ASC").Find(&attachments).Error
+
+	return attachments, err
+}
+
+// FindByCommentThread retrieves the attachments of a comment and of its replies, including those
+// of comments that are already deleted
+func (r *AttachmentRepository) FindByCommentThread(commentID uuid.UUID) ([]models.Attachment, error) {
+	var attachments []models.Attachment
+	err := r.DB.Where("comment_id IN (SELECT id FROM comments WHERE id = ? OR parent_id = ?)", commentID, commentID).
+		Find(&attachments).Error
+	return attachments, err
+}
+
 // IsTaskMember checks if a user is a member of the project that the task belongs to
 func (r *AttachmentRepository) IsTaskMember(taskID, userID uuid.UUID) (bool, error) {
 	var count int64
 
-	err := r.DB.Table("tasks").Joins("JOIN boards ON tasks.board_id = boards.id").
-		Joins("JOIN projects ON boards.project_id = projects.id").
+	err := r.DB.Table("tasks").Joins("JOIN boards ON tasks.board_id = boards.id AND boards.deleted_at IS NULL").
+		Joins("JOIN projects ON boards.project_id = projects.id AND projects.deleted_at IS NULL").
 		Joins("LEFT JOIN project_members ON projects.id = project_members.project_id AND project_members.user_id = ?", userID).
-		Where("tasks.id = ? AND (projects.owner_id = ? OR project_members.user_id = ?)", taskID, userID, userID).
+		Where("tasks.id = ? AND tasks.deleted_at IS NULL AND (projects.owner_id = ? OR project_members.user_id = ?)", taskID, userID, userID).
 		Count(&count).Error
 
 	return count > 0, err
@@ -49,10 +221,11 @@ func (r *AttachmentRepository) Delete(id uuid.UUID) error {
 	return r.DB.Delete(&models.Attachment{}, "id = ?", id).Error
 }
 
-// FindByID retrieves an attachment by its ID
+// FindByID retrieves an attachment by its ID; attachments of deleted tasks or comments stay
+// hidden until these are restored or purged
 func (r *AttachmentRepository) FindByID(id uuid.UUID) (*models.Attachment, error) {
 	var attachment models.Attachment
-	err := r.DB.First(&attachment, "id = ?", id).Error
+	err := r.DB.Scopes(liveAttachmentScope).First(&attachment, "id = ?", id).Error
 	return &attachment, err
 }
 
@@ -60,10 +233,27 @@ func (r *AttachmentRepository) FindByID(id uuid.UUID) (*models.Attachment, error
 func (r *AttachmentRepository) IsOwnerOfAttachmentProject(attachmentID, userID uuid.UUID) (bool, error) {
 	var count int64
 	err := r.DB.Table("attachments").
-		Joins("JOIN tasks ON attachments.task_id = tasks.id").
-		Joins("JOIN boards ON tasks.board_id = boards.id").
-		Joins("JOIN projects ON boards.project_id = projects.id").
+		Joins("JOIN tasks ON attachments.task_id = tasks.id AND tasks.deleted_at IS NULL").
+		Joins("JOIN boards ON tasks.board_id = boards.id AND boards.deleted_at IS NULL").
+		Joins("JOIN projects ON boards.project_id = projects.id AND projects.deleted_at IS NULL").
 		Where("attachments.id = ? AND projects.owner_id = ?", attachmentID, userID).
 		Count(&count).Error
 	return count > 0, err
 }
+
+// FindByFileUrl retrieves the attachment stored at a file URL, matching thumbnails and the
+// files of earlier versions as well
+func (r *AttachmentRepository) FindByFileUrl(fileUrl string) (*models.Attachment, error) {
+	var attachment models.Attachment
+	err := r.DB.Scopes(liveAttachmentScope).First(&attachment,
+		"file_url = ? OR thumbnail_url = ? OR id IN (SELECT attachment_id FROM attachment_versions WHERE file_url = ? OR thumbnail_url = ?)",
+		fileUrl, fileUrl, fileUrl, fileUrl).Error
+	return &attachment, err
+}
+
+// liveAttachmentScope leaves out attachments whose task or comment is deleted; deleting a project
+// or board stamps its tasks, so this covers those as well
+func liveAttachmentScope(db *gorm.DB) *gorm.DB {
+	return db.Where("attachments.task_id IN (SELECT id FROM tasks WHERE deleted_at IS NULL)").
+		Where("attachments.comment_id IS NULL OR attachments.comment_id IN (SELECT id FROM comments WHERE deleted_at IS NULL)")
+}
@@ -1,6 +1,8 @@
 package repository
 
 import (
+	"time"
+
 	"github.com/Hann-arc/task-management-backend/internal/models"
 	"github.com/google/uuid"
 	"gorm.io/gorm"
@@ -24,15 +26,24 @@ func (r *BoardRepository) Create(tx *gorm.DB, board *models.Board) error {
 	return db.Create(board).Error
 }
 
-// FindByProjectID retrieves all boards associated with a specific project
-func (r *BoardRepository) FindByProjectID(projectID uuid.UUID) ([]models.Board, error) {
+// FindByProjectID retrieves either the active or the archived boards of a specific project
+func (r *BoardRepository) FindByProjectID(projectID uuid.UUID, archived bool) ([]models.Board, error) {
 	var boards []models.Board
-	err := r.DB.Where("project_id = ?", projectID).
-		Order("order_index ASC").
-		Find(&boards).Error
+	query := r.DB.Where("project_id = ?", projectID)
+	if archived {
+		query = query.Where("archived_at IS NOT NULL")
+	} else {
+		query = query.Where("archived_at IS NULL")
+	}
+	err := query.Order("order_index ASC").Find(&boards).Error
 	return boards, err
 }
 
+// SetArchived archives a board, or unarchives it when archivedAt is nil
+func (r *BoardRepository) SetArchived(id uuid.UUID, archivedAt *time.Time) error {
+	return r.DB.Model(&models.Board{}).Where("id = ?", id).Update("archived_at", archivedAt).Error
+}
+
 // FindByID retrieves a board by its ID
 func (r *BoardRepository) FindByID(id uuid.UUID) (*models.Board, error) {
 	var board models.Board
@@ -89,11 +100,18 @@ func (r *BoardRepository) Update(tx *gorm.DB, id uuid.UUID, data map[string]inte
 	return db.Model(&models.Board{}).Where("id = ?", id).Updates(data).Error
 }
 
-// SoftDelete marks a board as deleted without removing it from the database
+// SoftDelete marks a board as deleted without removing it from the database, together with
+// its tasks and their comments and notifications
 func (r *BoardRepository) SoftDelete(tx *gorm.DB, id uuid.UUID) error {
-	db := r.DB
-	if tx != nil {
-		db = tx
+	if tx == nil {
+		return r.DB.Transaction(func(tx *gorm.DB) error {
+			return r.SoftDelete(tx, id)
+		})
+	}
+
+	now := time.Now()
+	if err := softDeleteTasks(tx, now, "board_id = ?", id); err != nil {
+		return err
 	}
-	return db.Delete(&models.Board{}, "id = ?", id).Error
+	return softDeleteRows(tx, &models.Board{}, now, "id = ?", id)
 }
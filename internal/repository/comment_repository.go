@@ -1,6 +1,8 @@
 package repository
 
 import (
+	"time"
+
 	"github.com/Hann-arc/task-management-backend/internal/models"
 	"github.com/google/uuid"
 	"gorm.io/gorm"
@@ -20,6 +22,12 @@ func (r *CommentRepository) Create(comment *models.Comment) error {
 	return r.DB.Create(comment).Error
 }
 
+// Delete permanently removes a comment that was never shown, such as one whose attachments
+// could not be saved
+func (r *CommentRepository) Delete(id uuid.UUID) error {
+	return r.DB.Unscoped().Delete(&models.Comment{}, "id = ?", id).Error
+}
+
 // FindByID retrieves a comment by its ID
 func (r *CommentRepository) FindByID(id uuid.UUID) (*models.Comment, error) {
 	var comment models.Comment
@@ -27,6 +35,17 @@ func (r *CommentRepository) FindByID(id uuid.UUID) (*models.Comment, error) {
 	return &comment, err
 }
 
+// FindByIDWithUser retrieves a comment by its ID along with its author and mentions
+func (r *CommentRepository) FindByIDWithUser(id uuid.UUID) (*models.Comment, error) {
+	var comment models.Comment
+	err := r.DB.Preload("User", func(db *gorm.DB) *gorm.DB {
+		return db.Select("id, name, avatar_url")
+	}).Preload("Mentions.User", func(db *gorm.DB) *gorm.DB {
+		return db.Select("id, name")
+	}).First(&comment, "id = ?", id).Error
+	return &comment, err
+}
+
 // GetMainCommentsWithReplies retrieves main comments for a task along with their replies
 func (r *CommentRepository) GetMainCommentsWithReplies(taskID uuid.UUID) ([]models.Comment, error) {
 	var mainComments []models.Comment
@@ -34,6 +53,9 @@ func (r *CommentRepository) GetMainCommentsWithReplies(taskID uuid.UUID) ([]mode
 		Preload("User", func(db *gorm.DB) *gorm.DB {
 			return db.Select("id, name, avatar_url")
 		}).
+		Preload("Mentions.User", func(db *gorm.DB) *gorm.DB {
+			return db.Select("id, name")
+		}).
 		Order("created_at ASC").
 		Find(&mainComments).Error
 	if err != nil {
@@ -52,6 +74,9 @@ func (r *CommentRepository) GetMainCommentsWithReplies(taskID uuid.UUID) ([]mode
 			Preload("User", func(db *gorm.DB) *gorm.DB {
 				return db.Select("id, name, avatar_url")
 			}).
+			Preload("Mentions.User", func(db *gorm.DB) *gorm.DB {
+				return db.Select("id, name")
+			}).
 			Order("created_at ASC").
 			Find(&replies)
 
@@ -71,30 +96,63 @@ func (r *CommentRepository) GetMainCommentsWithReplies(taskID uuid.UUID) ([]mode
 	return mainComments, nil
 }
 
+// UpdateContent replaces the content of a comment, keeping the previous content in its edit history
+func (r *CommentRepository) UpdateContent(comment *models.Comment, editorID uuid.UUID, content string) error {
+	return r.DB.Transaction(func(tx *gorm.DB) error {
+		edit := &models.CommentEdit{
+			CommentID: comment.ID,
+			EditorID:  editorID,
+			Content:   comment.Content,
+		}
+		if err := tx.Create(edit).Error; err != nil {
+			return err
+		}
+
+		comment.Content = content
+		comment.EditedAt = &edit.CreatedAt
+		return tx.Model(comment).Updates(map[string]interface{}{
+			"content":   comment.Content,
+			"edited_at": comment.EditedAt,
+		}).Error
+	})
+}
+
+// FindEdits retrieves the earlier versions of a comment, newest first
+func (r *CommentRepository) FindEdits(commentID uuid.UUID) ([]models.CommentEdit, error) {
+	var edits []models.CommentEdit
+	err := r.DB.Where("comment_id = ?", commentID).
+		Preload("Editor", func(db *gorm.DB) *gorm.DB {
+			return db.Select("id, name, avatar_url")
+		}).
+		Order("created_at DESC").
+		Find(&edits).Error
+	return edits, err
+}
+
 // IsTaskMember checks if a user is a member of the project associated with a task
 func (r *CommentRepository) IsTaskMember(taskID, userID uuid.UUID) (bool, error) {
 	var count int64
 	err := r.DB.Table("tasks").
-		Joins("JOIN boards ON tasks.board_id = boards.id").
-		Joins("JOIN projects ON boards.project_id = projects.id").
+		Joins("JOIN boards ON tasks.board_id = boards.id AND boards.deleted_at IS NULL").
+		Joins("JOIN projects ON boards.project_id = projects.id AND projects.deleted_at IS NULL").
 		Joins("LEFT JOIN project_members ON projects.id = project_members.project_id AND project_members.user_id = ?", userID).
-		Where("tasks.id = ? AND (projects.owner_id = ? OR project_members.user_id = ?)", taskID, userID, userID).
+		Where("tasks.id = ? AND tasks.deleted_at IS NULL AND (projects.owner_id = ? OR project_members.user_id = ?)", taskID, userID, userID).
 		Count(&count).Error
 	return count > 0, err
 }
 
-// SoftDelete marks a comment as deleted without removing it from the database
+// SoftDelete marks a comment and its replies as deleted without removing them from the database
 func (r *CommentRepository) SoftDelete(id uuid.UUID) error {
-	return r.DB.Delete(&models.Comment{}, "id = ?", id).Error
+	return softDeleteRows(r.DB, &models.Comment{}, time.Now(), "id = ? OR parent_id = ?", id, id)
 }
 
 // IsOwnerOfTaskProject checks if a user is the owner of the project associated with a task
 func (r *CommentRepository) IsOwnerOfTaskProject(taskID, userID uuid.UUID) (bool, error) {
 	var count int64
 	err := r.DB.Table("tasks").
-		Joins("JOIN boards ON tasks.board_id = boards.id").
-		Joins("JOIN projects ON boards.project_id = projects.id").
-		Where("tasks.id = ? AND projects.owner_id = ?", taskID, userID).
+		Joins("JOIN boards ON tasks.board_id = boards.id AND boards.deleted_at IS NULL").
+		Joins("JOIN projects ON boards.project_id = projects.id AND projects.deleted_at IS NULL").
+		Where("tasks.id = ? AND tasks.deleted_at IS NULL AND projects.owner_id = ?", taskID, userID).
 		Count(&count).Error
 	return count > 0, err
 }
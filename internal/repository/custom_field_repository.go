@@ -0,0 +1,64 @@
+package repository
+
+import (
+	"github.com/Hann-arc/task-management-backend/internal/models"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type CustomFieldRepository struct {
+	DB *gorm.DB
+}
+
+// NewCustomFieldRepository creates a new instance of CustomFieldRepository
+func NewCustomFieldRepository(db *gorm.DB) *CustomFieldRepository {
+	return &CustomFieldRepository{DB: db}
+}
+
+// Create inserts a new custom field definition into the database
+func (r *CustomFieldRepository) Create(field *models.CustomField) error {
+	return r.DB.Create(field).Error
+}
+
+// FindByID retrieves a custom field definition by its ID
+func (r *CustomFieldRepository) FindByID(id uuid.UUID) (*models.CustomField, error) {
+	var field models.CustomField
+	err := r.DB.Where("id = ?", id).First(&field).Error
+	return &field, err
+}
+
+// FindByProjectID retrieves the custom field definitions of a project in display order
+func (r *CustomFieldRepository) FindByProjectID(projectID uuid.UUID) ([]models.CustomField, error) {
+	var fields []models.CustomField
+	err := r.DB.Where("project_id = ?", projectID).
+		Order("position ASC, created_at ASC").
+		Find(&fields).Error
+	return fields, err
+}
+
+// NameExists checks whether another field of the project already uses the name, ignoring case
+func (r *CustomFieldRepository) NameExists(projectID uuid.UUID, name string, excludeID *uuid.UUID) (bool, error) {
+	var count int64
+	query := r.DB.Model(&models.CustomField{}).
+		Where("project_id = ? AND LOWER(name) = LOWER(?)", projectID, name)
+	if excludeID != nil {
+		query = query.Where("id <> ?", *excludeID)
+	}
+	err := query.Count(&count).Error
+	return count > 0, err
+}
+
+// Update modifies an existing custom field definition
+func (r *CustomFieldRepository) Update(id uuid.UUID, data map[string]interface{}) error {
+	return r.DB.Model(&models.CustomField{}).Where("id = ?", id).Updates(data).Error
+}
+
+// Delete removes a custom field definition together with its task values
+func (r *CustomFieldRepository) Delete(id uuid.UUID) error {
+	return r.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("field_id = ?", id).Delete(&models.TaskCustomFieldValue{}).Error; err != nil {
+			return err
+		}
+		return tx.Where("id = ?", id).Delete(&models.CustomField{}).Error
+	})
+}
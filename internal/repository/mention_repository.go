@@ -0,0 +1,65 @@
+package repository
+
+import (
+	"github.com/Hann-arc/task-management-backend/internal/models"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type MentionRepository struct {
+	DB *gorm.DB
+}
+
+// NewMentionRepository creates a new instance of MentionRepository
+func NewMentionRepository(db *gorm.DB) *MentionRepository {
+	return &MentionRepository{DB: db}
+}
+
+// FindProjectUsers retrieves the owner and members of a project, the only users that can be mentioned
+func (r *MentionRepository) FindProjectUsers(projectID uuid.UUID) ([]models.User, error) {
+	var users []models.User
+	err := r.DB.Select("users.id, users.name, users.email").
+		Where("users.id IN (SELECT owner_id FROM projects WHERE id = ?) OR users.id IN (SELECT user_id FROM project_members WHERE project_id = ?)", projectID, projectID).
+		Find(&users).Error
+	return users, err
+}
+
+// FindCommentMentionIDs retrieves the users currently mentioned in a comment
+func (r *MentionRepository) FindCommentMentionIDs(commentID uuid.UUID) ([]uuid.UUID, error) {
+	var ids []uuid.UUID
+	err := r.DB.Model(&models.CommentMention{}).Where("comment_id = ?", commentID).Pluck("user_id", &ids).Error
+	return ids, err
+}
+
+// FindTaskMentionIDs retrieves the users currently mentioned in a task description
+func (r *MentionRepository) FindTaskMentionIDs(taskID uuid.UUID) ([]uuid.UUID, error) {
+	var ids []uuid.UUID
+	err := r.DB.Model(&models.TaskMention{}).Where("task_id = ?", taskID).Pluck("user_id", &ids).Error
+	return ids, err
+}
+
+// ReplaceCommentMentions replaces all mentions of a comment
+func (r *MentionRepository) ReplaceCommentMentions(commentID uuid.UUID, mentions []models.CommentMention) error {
+	return r.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("comment_id = ?", commentID).Delete(&models.CommentMention{}).Error; err != nil {
+			return err
+		}
+		if len(mentions) == 0 {
+			return nil
+		}
+		return tx.Create(&mentions).Error
+	})
+}
+
+// ReplaceTaskMentions replaces all mentions of a task description
+func (r *MentionRepository) ReplaceTaskMentions(taskID uuid.UUID, mentions []models.TaskMention) error {
+	return r.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("task_id = ?", taskID).Delete(&models.TaskMention{}).Error; err != nil {
+			return err
+		}
+		if len(mentions) == 0 {
+			return nil
+		}
+		return tx.Create(&mentions).Error
+	})
+}
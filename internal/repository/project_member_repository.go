@@ -46,6 +46,36 @@ func (r *ProjectMemberRepository) Delete(projectID, userID uuid.UUID) error {
 		Delete(&models.ProjectMember{}).Error
 }
 
+// DeleteWithAssignments removes a project member together with their task assignments and
+// watcher subscriptions in the project, returning the IDs of the tasks they were assigned to
+func (r *ProjectMemberRepository) DeleteWithAssignments(projectID, userID uuid.UUID) ([]uuid.UUID, error) {
+	var taskIDs []uuid.UUID
+	err := r.DB.Transaction(func(tx *gorm.DB) error {
+		projectTasks := tx.Table("tasks").Select("tasks.id").
+			Joins("JOIN boards ON boards.id = tasks.board_id").
+			Where("boards.project_id = ?", projectID)
+
+		if err := tx.Model(&models.TaskAssignee{}).
+			Where("user_id = ? AND task_id IN (?)", userID, projectTasks).
+			Pluck("task_id", &taskIDs).Error; err != nil {
+			return err
+		}
+
+		if err := tx.Where("user_id = ? AND task_id IN (?)", userID, projectTasks).
+			Delete(&models.TaskAssignee{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("user_id = ? AND task_id IN (?)", userID, projectTasks).
+			Delete(&models.TaskWatcher{}).Error; err != nil {
+			return err
+		}
+
+		return tx.Where("project_id = ? AND user_id = ?", projectID, userID).
+			Delete(&models.ProjectMember{}).Error
+	})
+	return taskIDs, err
+}
+
 // UserExistsByEmail checks if a user exists by their email
 func (r *ProjectMemberRepository) UserExistsByEmail(email string) (*models.User, error) {
 	var user models.User
@@ -1,6 +1,8 @@
 package repository
 
 import (
+	"time"
+
 	"github.com/Hann-arc/task-management-backend/internal/models"
 	"github.com/google/uuid"
 	"gorm.io/gorm"
@@ -27,25 +29,31 @@ func (r *ProjectRepository) FindByID(id uuid.UUID) (*models.Project, error) {
 	return &project, err
 }
 
-// FindByIDWithDetails retrieves a project by its ID along with its owner and boards
+// FindByIDWithDetails retrieves a project by its ID along with its owner and active boards
 func (r *ProjectRepository) FindByIDWithDetails(id uuid.UUID) (*models.Project, error) {
 	var project models.Project
 	err := r.DB.Preload("Owner").
 		Preload("Boards", func(db *gorm.DB) *gorm.DB {
-			return db.Select("id, name, order_index, project_id")
+			return db.Select("id, name, order_index, project_id").Where("archived_at IS NULL")
 		}).
 		Where("id = ?", id).First(&project).Error
 	return &project, err
 }
 
-// FindAllByUser retrieves all projects where the user is either the owner or a member
-func (r *ProjectRepository) FindAllByUser(userID uuid.UUID) ([]models.Project, error) {
+// FindAllByUser retrieves the regular projects where the user is either the owner or a member,
+// either the active ones or only the archived ones
+func (r *ProjectRepository) FindAllByUser(userID uuid.UUID, archived bool) ([]models.Project, error) {
 	var projects []models.Project
 
 	subQuery := r.DB.Model(&models.ProjectMember{}).Select("project_id").Where("user_id = ?", userID)
 
-	err := r.DB.Where("owner_id = ? OR id IN (?)", userID, subQuery).
-		Find(&projects).Error
+	query := r.DB.Where("is_template = ? AND (owner_id = ? OR id IN (?))", false, userID, subQuery)
+	if archived {
+		query = query.Where("archived_at IS NOT NULL")
+	} else {
+		query = query.Where("archived_at IS NULL")
+	}
+	err := query.Find(&projects).Error
 	return projects, err
 }
 
@@ -54,9 +62,51 @@ func (r *ProjectRepository) Update(id uuid.UUID, data map[string]interface{}) er
 	return r.DB.Model(&models.Project{}).Where("id = ?", id).Updates(data).Error
 }
 
-// SoftDelete marks a project as deleted without removing it from the database
+// SoftDelete marks a project as deleted without removing it from the database, together with
+// its boards, tasks, comments, invitations and notifications
 func (r *ProjectRepository) SoftDelete(id uuid.UUID) error {
-	return r.DB.Delete(&models.Project{}, "id = ?", id).Error
+	return r.DB.Transaction(func(tx *gorm.DB) error {
+		now := time.Now()
+		boardIDs := tx.Unscoped().Model(&models.Board{}).Select("id").Where("project_id = ?", id)
+
+		if err := softDeleteTasks(tx, now, "board_id IN (?)", boardIDs); err != nil {
+			return err
+		}
+		if err := softDeleteRows(tx, &models.Board{}, now, "project_id = ?", id); err != nil {
+			return err
+		}
+		if err := softDeleteRows(tx, &models.Invitation{}, now, "project_id = ?", id); err != nil {
+			return err
+		}
+		if err := softDeleteRows(tx, &models.Notification{}, now, "reference_type = ? AND related_id = ?", "project", id); err != nil {
+			return err
+		}
+		return softDeleteRows(tx, &models.Project{}, now, "id = ?", id)
+	})
+}
+
+// SetArchived archives a project, or unarchives it when archivedAt is nil
+func (r *ProjectRepository) SetArchived(id uuid.UUID, archivedAt *time.Time) error {
+	return r.DB.Model(&models.Project{}).Where("id = ?", id).Update("archived_at", archivedAt).Error
+}
+
+// IsArchived checks whether a project is archived
+func (r *ProjectRepository) IsArchived(projectID uuid.UUID) (bool, error) {
+	var count int64
+	err := r.DB.Model(&models.Project{}).
+		Where("id = ? AND archived_at IS NOT NULL", projectID).
+		Count(&count).Error
+	return count > 0, err
+}
+
+// IsBoardArchived checks whether a board or the project it belongs to is archived
+func (r *ProjectRepository) IsBoardArchived(boardID uuid.UUID) (bool, error) {
+	var count int64
+	err := r.DB.Model(&models.Board{}).
+		Joins("JOIN projects ON projects.id = boards.project_id").
+		Where("boards.id = ? AND (boards.archived_at IS NOT NULL OR projects.archived_at IS NOT NULL)", boardID).
+		Count(&count).Error
+	return count > 0, err
 }
 
 // IsOwner checks if a user is the owner of a specific project
@@ -75,7 +125,395 @@ func (r *ProjectRepository) IsOwner(projectID, userID uuid.UUID) (bool, error) {
 func (r *ProjectRepository) IsMember(projectID, userID uuid.UUID) (bool, error) {
 	var count int64
 	err := r.DB.Model(&models.ProjectMember{}).
-		Where("project_id = ? AND user_id = ?", projectID, userID).
+		Joins("JOIN projects ON projects.id = project_members.project_id AND projects.deleted_at IS NULL").
+		Where("project_members.project_id = ? AND project_members.user_id = ?", projectID, userID).
 		Count(&count).Error
 	return err == nil && count > 0, nil
 }
+
+// ProjectTaskTotals holds the overall task counters of a project
+type ProjectTaskTotals struct {
+	Total               int64
+	Open                int64
+	Done                int64
+	Overdue             int64
+	Unassigned          int64
+	CompletedLast7Days  int64
+	CompletedLast30Days int64
+}
+
+// TaskCountRow holds the task counters of one group of a project breakdown
+type TaskCountRow struct {
+	ID      uuid.UUID
+	Name    string
+	Order   int
+	Total   int64
+	Open    int64
+	Overdue int64
+}
+
+// MemberActivityRow holds the number of logged actions of a user
+type MemberActivityRow struct {
+	UserID  uuid.UUID
+	Name    string
+	Actions int64
+}
+
+// projectTaskCounters are the aggregate columns shared by the project breakdowns
+const projectTaskCounters = `COUNT(tasks.id) AS total,
+	COUNT(tasks.id) FILTER (WHERE tasks.status <> 'done') AS open,
+	COUNT(tasks.id) FILTER (WHERE tasks.status <> 'done' AND tasks.due_date > '1900-01-01' AND tasks.due_date < @now) AS overdue`
+
+// CountTaskTotals aggregates the overall task counters of a project
+func (r *ProjectRepository) CountTaskTotals(projectID uuid.UUID, now time.Time) (*ProjectTaskTotals, error) {
+	var totals ProjectTaskTotals
+	err := r.DB.Raw(`
+		SELECT
+			COUNT(*) AS total,
+			COUNT(*) FILTER (WHERE tasks.status <> 'done') AS open,
+			COUNT(*) FILTER (WHERE tasks.status = 'done') AS done,
+			COUNT(*) FILTER (WHERE tasks.status <> 'done' AND tasks.due_date > '1900-01-01' AND tasks.due_date < @now) AS overdue,
+			COUNT(*) FILTER (WHERE tasks.status <> 'done' AND NOT EXISTS (
+				SELECT 1 FROM task_assignees WHERE task_assignees.task_id = tasks.id)) AS unassigned,
+			COUNT(*) FILTER (WHERE tasks.status = 'done' AND tasks.completed_at >= @week) AS completed_last7_days,
+			COUNT(*) FILTER (WHERE tasks.status = 'done' AND tasks.completed_at >= @month) AS completed_last30_days
+		FROM tasks
+		JOIN boards ON boards.id = tasks.board_id AND boards.deleted_at IS NULL
+		WHERE boards.project_id = @project AND tasks.deleted_at IS NULL`,
+		map[string]interface{}{
+			"project": projectID,
+			"now":     now,
+			"week":    now.AddDate(0, 0, -7),
+			"month":   now.AddDate(0, 0, -30),
+		},
+	).Scan(&totals).Error
+	return &totals, err
+}
+
+// CountTasksByBoard aggregates the task counters of every live board of a project
+func (r *ProjectRepository) CountTasksByBoard(projectID uuid.UUID, now time.Time) ([]TaskCountRow, error) {
+	var rows []TaskCountRow
+	err := r.DB.Raw(`
+		SELECT boards.id AS id, boards.name AS name, boards.order_index AS "order", `+projectTaskCounters+`
+		FROM boards
+		LEFT JOIN tasks ON tasks.board_id = boards.id AND tasks.deleted_at IS NULL
+		WHERE boards.project_id = @project AND boards.deleted_at IS NULL
+		GROUP BY boards.id, boards.name, boards.order_index
+		ORDER BY boards.order_index ASC`,
+		map[string]interface{}{"project": projectID, "now": now},
+	).Scan(&rows).Error
+	return rows, err
+}
+
+// CountTasksByPriority aggregates the task counters of a project per priority
+func (r *ProjectRepository) CountTasksByPriority(projectID uuid.UUID, now time.Time) ([]TaskCountRow, error) {
+	var rows []TaskCountRow
+	err := r.DB.Raw(`
+		SELECT tasks.priority AS name, `+projectTaskCounters+`
+		FROM tasks
+		JOIN boards ON boards.id = tasks.board_id AND boards.deleted_at IS NULL
+		WHERE boards.project_id = @project AND tasks.deleted_at IS NULL
+		GROUP BY tasks.priority
+		ORDER BY CASE tasks.priority WHEN 'urgent' THEN 1 WHEN 'high' THEN 2 WHEN 'medium' THEN 3 WHEN 'low' THEN 4 ELSE 5 END`,
+		map[string]interface{}{"project": projectID, "now": now},
+	).Scan(&rows).Error
+	return rows, err
+}
+
+// CountTasksByAssignee aggregates the task counters of a project per assignee
+func (r *ProjectRepository) CountTasksByAssignee(projectID uuid.UUID, now time.Time) ([]TaskCountRow, error) {
+	var rows []TaskCountRow
+	err := r.DB.Raw(`
+		SELECT users.id AS id, users.name AS name, `+projectTaskCounters+`
+		FROM task_assignees
+		JOIN tasks ON tasks.id = task_assignees.task_id AND tasks.deleted_at IS NULL
+		JOIN boards ON boards.id = tasks.board_id AND boards.deleted_at IS NULL
+		JOIN users ON users.id = task_assignees.user_id
+		WHERE boards.project_id = @project
+		GROUP BY users.id, users.name
+		ORDER BY open DESC, total DESC`,
+		map[string]interface{}{"project": projectID, "now": now},
+	).Scan(&rows).Error
+	return rows, err
+}
+
+// FindMostActiveMembers ranks the users of a project by the number of actions logged since the given time
+func (r *ProjectRepository) FindMostActiveMembers(projectID uuid.UUID, since time.Time, limit int) ([]MemberActivityRow, error) {
+	var rows []MemberActivityRow
+	err := r.DB.Table("activity_logs").
+		Select("users.id AS user_id, users.name AS name, COUNT(*) AS actions").
+		Joins("JOIN users ON users.id = activity_logs.user_id").
+		Where("activity_logs.project_id = ? AND activity_logs.created_at >= ?", projectID, since).
+		Group("users.id, users.name").
+		Order("actions DESC").
+		Limit(limit).
+		Scan(&rows).Error
+	return rows, err
+}
+
+// ProjectCopyOptions controls what Duplicate copies into the new project
+type ProjectCopyOptions struct {
+	Name           string
+	Description    string
+	OwnerID        uuid.UUID
+	IsTemplate     bool
+	IncludeTasks   bool
+	IncludeMembers bool
+
+	// DueDateShiftDays moves the due dates of copied tasks by whole days
+	DueDateShiftDays int
+}
+
+// Duplicate copies a project with its boards, custom fields and task templates, and optionally
+// its members and every task with its labels, assignees and custom values, in a single transaction.
+// Copied tasks, including completed ones, start over as todo, outside of any sprint.
+func (r *ProjectRepository) Duplicate(sourceID uuid.UUID, opts ProjectCopyOptions) (*models.Project, error) {
+	project := &models.Project{
+		ID:          uuid.New(),
+		Name:        opts.Name,
+		Description: opts.Description,
+		OwnerID:     opts.OwnerID,
+		IsTemplate:  opts.IsTemplate,
+	}
+
+	err := r.DB.Transaction(func(tx *gorm.DB) error {
+		var source models.Project
+		if err := tx.Where("id = ?", sourceID).First(&source).Error; err != nil {
+			return err
+		}
+		if err := tx.Create(project).Error; err != nil {
+			return err
+		}
+
+		// users of the new project, used to keep only valid assignees
+		projectUsers := map[uuid.UUID]bool{opts.OwnerID: true}
+		if opts.IncludeMembers {
+			var err error
+			if projectUsers, err = copyProjectMembers(tx, &source, project); err != nil {
+				return err
+			}
+		}
+
+		boardIDs, err := copyProjectBoards(tx, sourceID, project.ID)
+		if err != nil {
+			return err
+		}
+
+		fieldIDs, err := copyProjectCustomFields(tx, sourceID, project.ID, opts.OwnerID)
+		if err != nil {
+			return err
+		}
+
+		var templates []models.TaskTemplate
+		if err := tx.Where("project_id = ?", sourceID).Find(&templates).Error; err != nil {
+			return err
+		}
+		for _, t := range templates {
+			copied := t
+			copied.ID = uuid.New()
+			copied.ProjectID = project.ID
+			copied.CreatedBy = opts.OwnerID
+			copied.CreatedAt = time.Time{}
+			copied.UpdatedAt = time.Time{}
+			if copied.DefaultAssigneeID != nil && !projectUsers[*copied.DefaultAssigneeID] {
+				copied.DefaultAssigneeID = nil
+			}
+			if err := tx.Omit("Project", "DefaultAssignee").Create(&copied).Error; err != nil {
+				return err
+			}
+		}
+
+		if opts.IncludeTasks {
+			return copyProjectTasks(tx, boardIDs, fieldIDs, projectUsers, opts)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return project, nil
+}
+
+// copyProjectMembers copies the memberships of the source project, turning its owner into a member
+// when someone else owns the copy, and returns the set of users of the new project
+func copyProjectMembers(tx *gorm.DB, source, project *models.Project) (map[uuid.UUID]bool, error) {
+	var members []models.ProjectMember
+	if err := tx.Where("project_id = ?", source.ID).Find(&members).Error; err != nil {
+		return nil, err
+	}
+
+	users := map[uuid.UUID]bool{project.OwnerID: true}
+	now := time.Now()
+	for _, m := range members {
+		if users[m.UserID] {
+			continue
+		}
+		users[m.UserID] = true
+		if err := tx.Create(&models.ProjectMember{
+			ID:        uuid.New(),
+			ProjectID: project.ID,
+			UserID:    m.UserID,
+			RoleID:    m.RoleID,
+			InvitedAt: now,
+			JoinedAt:  now,
+		}).Error; err != nil {
+			return nil, err
+		}
+	}
+
+	if !users[source.OwnerID] {
+		var role models.Role
+		if err := tx.Where("name = ?", "member").First(&role).Error; err != nil {
+			role = models.Role{ID: uuid.New(), Name: "member"}
+			if err := tx.Create(&role).Error; err != nil {
+				return nil, err
+			}
+		}
+		users[source.OwnerID] = true
+		if err := tx.Create(&models.ProjectMember{
+			ID:        uuid.New(),
+			ProjectID: project.ID,
+			UserID:    source.OwnerID,
+			RoleID:    role.ID,
+			InvitedAt: now,
+			JoinedAt:  now,
+		}).Error; err != nil {
+			return nil, err
+		}
+	}
+
+	return users, nil
+}
+
+// copyProjectBoards copies the active boards of a project keeping their order and returns the old to new ID mapping
+func copyProjectBoards(tx *gorm.DB, sourceID, projectID uuid.UUID) (map[uuid.UUID]uuid.UUID, error) {
+	var boards []models.Board
+	if err := tx.Where("project_id = ? AND archived_at IS NULL", sourceID).Order("order_index ASC").Find(&boards).Error; err != nil {
+		return nil, err
+	}
+
+	ids := map[uuid.UUID]uuid.UUID{}
+	for _, b := range boards {
+		board := models.Board{
+			ID:         uuid.New(),
+			Name:       b.Name,
+			OrderIndex: b.OrderIndex,
+			ProjectID:  projectID,
+		}
+		if err := tx.Create(&board).Error; err != nil {
+			return nil, err
+		}
+		ids[b.ID] = board.ID
+	}
+	return ids, nil
+}
+
+// copyProjectCustomFields copies the custom field definitions of a project and returns the old to new ID mapping
+func copyProjectCustomFields(tx *gorm.DB, sourceID, projectID, ownerID uuid.UUID) (map[uuid.UUID]uuid.UUID, error) {
+	var fields []models.CustomField
+	if err := tx.Where("project_id = ?", sourceID).Find(&fields).Error; err != nil {
+		return nil, err
+	}
+
+	ids := map[uuid.UUID]uuid.UUID{}
+	for _, f := range fields {
+		field := models.CustomField{
+			ID:        uuid.New(),
+			ProjectID: projectID,
+			Name:      f.Name,
+			Type:      f.Type,
+			Options:   f.Options,
+			Required:  f.Required,
+			Position:  f.Position,
+			CreatedBy: ownerID,
+		}
+		if err := tx.Create(&field).Error; err != nil {
+			return nil, err
+		}
+		ids[f.ID] = field.ID
+	}
+	return ids, nil
+}
+
+// copyProjectTasks copies the tasks of the mapped boards with their labels, assignees and custom values
+func copyProjectTasks(tx *gorm.DB, boardIDs, fieldIDs map[uuid.UUID]uuid.UUID, projectUsers map[uuid.UUID]bool, opts ProjectCopyOptions) error {
+	if len(boardIDs) == 0 {
+		return nil
+	}
+
+	sourceBoards := make([]uuid.UUID, 0, len(boardIDs))
+	for id := range boardIDs {
+		sourceBoards = append(sourceBoards, id)
+	}
+
+	var tasks []models.Task
+	if err := tx.Preload("Labels").Preload("Assignees").Preload("CustomFieldValues").
+		Where("board_id IN ?", sourceBoards).
+		Order("created_at ASC").
+		Find(&tasks).Error; err != nil {
+		return err
+	}
+
+	for _, t := range tasks {
+		task := models.Task{
+			ID:              uuid.New(),
+			BoardID:         boardIDs[t.BoardID],
+			Title:           t.Title,
+			Description:     t.Description,
+			Priority:        t.Priority,
+			Status:          models.TaskStatusTodo,
+			EstimateMinutes: t.EstimateMinutes,
+			StoryPoints:     t.StoryPoints,
+			CreatedBy:       opts.OwnerID,
+		}
+		if !t.DueDate.IsZero() && t.DueDate.Year() > 1900 {
+			task.DueDate = t.DueDate.AddDate(0, 0, opts.DueDateShiftDays)
+		}
+
+		for _, l := range t.Labels {
+			task.Labels = append(task.Labels, models.TaskLabel{
+				ID:     uuid.New(),
+				TaskID: task.ID,
+				Name:   l.Name,
+				Color:  l.Color,
+			})
+		}
+		for _, a := range t.Assignees {
+			if opts.IncludeMembers && projectUsers[a.UserID] {
+				task.Assignees = append(task.Assignees, models.TaskAssignee{
+					ID:     uuid.New(),
+					TaskID: task.ID,
+					UserID: a.UserID,
+				})
+			}
+		}
+		for _, v := range t.CustomFieldValues {
+			if fieldID, ok := fieldIDs[v.FieldID]; ok {
+				task.CustomFieldValues = append(task.CustomFieldValues, models.TaskCustomFieldValue{
+					ID:      uuid.New(),
+					TaskID:  task.ID,
+					FieldID: fieldID,
+					Value:   v.Value,
+				})
+			}
+		}
+
+		if err := tx.Create(&task).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// FindTemplatesByUser retrieves the project templates the user owns or belongs to
+func (r *ProjectRepository) FindTemplatesByUser(userID uuid.UUID) ([]models.Project, error) {
+	var projects []models.Project
+
+	subQuery := r.DB.Model(&models.ProjectMember{}).Select("project_id").Where("user_id = ?", userID)
+
+	err := r.DB.Where("is_template = ? AND (owner_id = ? OR id IN (?))", true, userID, subQuery).
+		Order("name ASC").
+		Find(&projects).Error
+	return projects, err
+}
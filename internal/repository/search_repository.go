@@ -0,0 +1,113 @@
+package repository
+
+import (
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type SearchRepository struct {
+	DB *gorm.DB
+}
+
+// TaskSearchRow is a ranked task match
+type TaskSearchRow struct {
+	ID        uuid.UUID
+	Title     string
+	BoardID   uuid.UUID
+	ProjectID uuid.UUID
+	Rank      float64
+	Highlight string
+}
+
+// CommentSearchRow is a ranked comment match
+type CommentSearchRow struct {
+	ID        uuid.UUID
+	TaskID    uuid.UUID
+	ProjectID uuid.UUID
+	UserID    uuid.UUID
+	Rank      float64
+	Highlight string
+}
+
+// ProjectSearchRow is a ranked project match
+type ProjectSearchRow struct {
+	ID        uuid.UUID
+	Name      string
+	Rank      float64
+	Highlight string
+}
+
+// Highlight markers wrap matched terms in headlines; they are private-use characters so the
+// service can escape the user's text before turning them into <mark> tags
+const (
+	HighlightStart = "\uE000"
+	HighlightStop  = "\uE001"
+)
+
+// headlineOptions configures ts_headline to wrap matched terms in the highlight markers
+const headlineOptions = `StartSel="` + HighlightStart + `", StopSel="` + HighlightStop + `", MaxFragments=2, MaxWords=25, MinWords=8`
+
+// accessibleProjects restricts a query to live projects the user owns or is a member of
+const accessibleProjects = `projects.deleted_at IS NULL AND (projects.owner_id = @user OR EXISTS (
+	SELECT 1 FROM project_members WHERE project_members.project_id = projects.id AND project_members.user_id = @user))`
+
+// NewSearchRepository creates a new instance of SearchRepository
+func NewSearchRepository(db *gorm.DB) *SearchRepository {
+	return &SearchRepository{DB: db}
+}
+
+// SearchTasks runs a full-text search over task titles and descriptions
+func (r *SearchRepository) SearchTasks(userID uuid.UUID, query string, limit int) ([]TaskSearchRow, error) {
+	var rows []TaskSearchRow
+	err := r.DB.Raw(`
+		SELECT tasks.id, tasks.title, tasks.board_id, boards.project_id,
+			ts_rank(tasks.search_vector, q) AS rank,
+			ts_headline('english', coalesce(tasks.title, '') || ' ' || coalesce(tasks.description, ''), q, @opts) AS highlight
+		FROM tasks
+		JOIN boards ON boards.id = tasks.board_id AND boards.deleted_at IS NULL
+		JOIN projects ON projects.id = boards.project_id,
+		websearch_to_tsquery('english', @query) q
+		WHERE tasks.deleted_at IS NULL AND tasks.search_vector @@ q AND `+accessibleProjects+`
+		ORDER BY rank DESC, tasks.updated_at DESC
+		LIMIT @limit`,
+		map[string]interface{}{"user": userID, "query": query, "opts": headlineOptions, "limit": limit},
+	).Scan(&rows).Error
+	return rows, err
+}
+
+// SearchComments runs a full-text search over comment content
+func (r *SearchRepository) SearchComments(userID uuid.UUID, query string, limit int) ([]CommentSearchRow, error) {
+	var rows []CommentSearchRow
+	err := r.DB.Raw(`
+		SELECT comments.id, comments.task_id, boards.project_id, comments.user_id,
+			ts_rank(comments.search_vector, q) AS rank,
+			ts_headline('english', comments.content, q, @opts) AS highlight
+		FROM comments
+		JOIN tasks ON tasks.id = comments.task_id AND tasks.deleted_at IS NULL
+		JOIN boards ON boards.id = tasks.board_id AND boards.deleted_at IS NULL
+		JOIN projects ON projects.id = boards.project_id,
+		websearch_to_tsquery('english', @query) q
+		WHERE comments.deleted_at IS NULL AND comments.search_vector @@ q AND `+accessibleProjects+`
+		ORDER BY rank DESC, comments.created_at DESC
+		LIMIT @limit`,
+		map[string]interface{}{"user": userID, "query": query, "opts": headlineOptions, "limit": limit},
+	).Scan(&rows).Error
+	return rows, err
+}
+
+// SearchProjects runs a full-text search over project names and descriptions
+func (r *SearchRepository) SearchProjects(userID uuid.UUID, query string, limit int) ([]ProjectSearchRow, error) {
+	var rows []ProjectSearchRow
+	err := r.DB.Raw(`
+		SELECT projects.id, projects.name,
+			ts_rank(projects.search_vector, q) AS rank,
+			ts_headline('english', coalesce(projects.name, '') || ' ' || coalesce(projects.description, ''), q, @opts) AS highlight
+		FROM projects,
+		websearch_to_tsquery('english', @query) q
+		WHERE projects.search_vector @@ q AND `+accessibleProjects+`
+		ORDER BY rank DESC, projects.updated_at DESC
+		LIMIT @limit`,
+		map[string]interface{}{"user": userID, "query": query, "opts": headlineOptions, "limit": limit},
+	).Scan(&rows).Error
+	return rows, err
+}
@@ -0,0 +1,195 @@
+package repository_test
+
+import (
+	"errors"
+	"os"
+	"sync"
+	"testing"
+
+	"github.com/Hann-arc/task-management-backend/config"
+	"github.com/Hann-arc/task-management-backend/internal/models"
+	"github.com/Hann-arc/task-management-backend/internal/repository"
+	"github.com/google/uuid"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+var (
+	migrateOnce sync.Once
+	migrateErr  error
+)
+
+// openTestDB connects to the database named by TEST_DATABASE_URL and runs the test inside a
+// transaction that is rolled back afterwards; the test is skipped when the variable is unset
+func openTestDB(t *testing.T) *gorm.DB {
+	t.Helper()
+
+	dsn := os.Getenv("TEST_DATABASE_URL")
+	if dsn == "" {
+		t.Skip("TEST_DATABASE_URL is not set")
+	}
+
+	db, err := gorm.Open(postgres.Open(dsn), &gorm.Config{Logger: logger.Default.LogMode(logger.Silent)})
+	if err != nil {
+		t.Fatalf("failed to connect: %v", err)
+	}
+
+	migrateOnce.Do(func() {
+		if migrateErr = db.Exec(`CREATE EXTENSION IF NOT EXISTS "uuid-ossp"`).Error; migrateErr != nil {
+			return
+		}
+		migrateErr = db.AutoMigrate(config.Models...)
+	})
+	if migrateErr != nil {
+		t.Fatalf("failed to migrate: %v", migrateErr)
+	}
+
+	tx := db.Begin()
+	t.Cleanup(func() { tx.Rollback() })
+	return tx
+}
+
+// softDeleteFixture is a project with one board, task, comment and two attachments, one on the
+// task and one on the comment
+type softDeleteFixture struct {
+	owner             models.User
+	project           models.Project
+	board             models.Board
+	task              models.Task
+	comment           models.Comment
+	taskAttachment    models.Attachment
+	commentAttachment models.Attachment
+}
+
+func seedSoftDeleteFixture(t *testing.T, db *gorm.DB) *softDeleteFixture {
+	t.Helper()
+
+	f := &softDeleteFixture{}
+	f.owner = models.User{Name: "Owner", Email: uuid.NewString() + "@example.com", PasswordHash: "hash"}
+	mustCreate(t, db, &f.owner)
+
+	f.project = models.Project{Name: "Project", OwnerID: f.owner.ID}
+	mustCreate(t, db, &f.project)
+
+	f.board = models.Board{Name: "Board", ProjectID: f.project.ID}
+	mustCreate(t, db, &f.board)
+
+	f.task = models.Task{BoardID: f.board.ID, Title: "Task", Priority: "medium", CreatedBy: f.owner.ID}
+	mustCreate(t, db, &f.task)
+
+	f.comment = models.Comment{TaskID: f.task.ID, UserID: f.owner.ID, Content: "Comment"}
+	mustCreate(t, db, &f.comment)
+
+	f.taskAttachment = models.Attachment{TaskID: f.task.ID, FileUrl: "/files/task.txt", UploadedBy: f.owner.ID, FileName: "task.txt"}
+	f.commentAttachment = models.Attachment{TaskID: f.task.ID, CommentID: &f.comment.ID, FileUrl: "/files/comment.txt", UploadedBy: f.owner.ID, FileName: "comment.txt"}
+	if _, err := repository.NewAttachmentRepository(db).Create([]*models.Attachment{&f.taskAttachment, &f.commentAttachment}, 0); err != nil {
+		t.Fatalf("failed to create attachments: %v", err)
+	}
+	return f
+}
+
+func mustCreate(t *testing.T, db *gorm.DB, value interface{}) {
+	t.Helper()
+	if err := db.Create(value).Error; err != nil {
+		t.Fatalf("failed to create %T: %v", value, err)
+	}
+}
+
+// checkChildrenVisible asserts whether the task, comment and attachments of the fixture can be
+// reached through the lookups and listings the API uses
+func checkChildrenVisible(t *testing.T, db *gorm.DB, f *softDeleteFixture, visible bool) {
+	t.Helper()
+
+	taskRepo := repository.NewTaskRepository(db)
+	commentRepo := repository.NewCommentRepository(db)
+	attachmentRepo := repository.NewAttachmentRepository(db)
+
+	checkFound := func(what string, err error) {
+		t.Helper()
+		switch {
+		case visible && err != nil:
+			t.Errorf("%s: expected to be found, got %v", what, err)
+		case !visible && !errors.Is(err, gorm.ErrRecordNotFound):
+			t.Errorf("%s: expected record not found, got %v", what, err)
+		}
+	}
+	checkCount := func(what string, got int, err error) {
+		t.Helper()
+		if err != nil {
+			t.Errorf("%s: %v", what, err)
+			return
+		}
+		want := 0
+		if visible {
+			want = 1
+		}
+		if got != want {
+			t.Errorf("%s: expected %d results, got %d", what, want, got)
+		}
+	}
+
+	_, err := taskRepo.FindByID(f.task.ID)
+	checkFound("TaskRepository.FindByID", err)
+	_, err = commentRepo.FindByID(f.comment.ID)
+	checkFound("CommentRepository.FindByID", err)
+	_, err = attachmentRepo.FindByID(f.taskAttachment.ID)
+	checkFound("AttachmentRepository.FindByID on a task attachment", err)
+	_, err = attachmentRepo.FindByID(f.commentAttachment.ID)
+	checkFound("AttachmentRepository.FindByID on a comment attachment", err)
+
+	isMember, err := attachmentRepo.IsTaskMember(f.task.ID, f.owner.ID)
+	if err != nil {
+		t.Errorf("AttachmentRepository.IsTaskMember: %v", err)
+	} else if isMember != visible {
+		t.Errorf("AttachmentRepository.IsTaskMember: expected %v, got %v", visible, isMember)
+	}
+
+	tasks, err := taskRepo.FindByBoardID(f.board.ID)
+	checkCount("TaskRepository.FindByBoardID", len(tasks), err)
+
+	tasks, err = taskRepo.FindFiltered(repository.TaskFilter{ProjectID: &f.project.ID, Limit: 10})
+	checkCount("TaskRepository.FindFiltered by project", len(tasks), err)
+
+	tasks, err = taskRepo.FindFiltered(repository.TaskFilter{BoardID: &f.board.ID, Statuses: []string{models.TaskStatusTodo}, Limit: 10})
+	checkCount("TaskRepository.FindFiltered by board and status", len(tasks), err)
+
+	tasks, err = taskRepo.FindFiltered(repository.TaskFilter{InvolvedUserID: &f.owner.ID, Limit: 10})
+	checkCount("TaskRepository.FindFiltered by involved user", len(tasks), err)
+
+	comments, err := commentRepo.GetMainCommentsWithReplies(f.task.ID)
+	checkCount("CommentRepository.GetMainCommentsWithReplies", len(comments), err)
+}
+
+func TestSoftDeleteHidesChildren(t *testing.T) {
+	tests := []struct {
+		name   string
+		delete func(db *gorm.DB, f *softDeleteFixture) error
+	}{
+		{
+			name: "project",
+			delete: func(db *gorm.DB, f *softDeleteFixture) error {
+				return repository.NewProjectRepository(db).SoftDelete(f.project.ID)
+			},
+		},
+		{
+			name: "board",
+			delete: func(db *gorm.DB, f *softDeleteFixture) error {
+				return repository.NewBoardRepository(db).SoftDelete(nil, f.board.ID)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db := openTestDB(t)
+			f := seedSoftDeleteFixture(t, db)
+
+			checkChildrenVisible(t, db, f, true)
+			if err := tt.delete(db, f); err != nil {
+				t.Fatalf("failed to delete %s: %v", tt.name, err)
+			}
+			checkChildrenVisible(t, db, f, false)
+		})
+	}
+}
@@ -0,0 +1,167 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/Hann-arc/task-management-backend/internal/models"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// SprintPoints summarises the tasks planned into a sprint
+type SprintPoints struct {
+	TaskCount   int64
+	TotalPoints int64
+	DonePoints  int64
+}
+
+type SprintRepository struct {
+	DB *gorm.DB
+}
+
+// NewSprintRepository creates a new instance of SprintRepository
+func NewSprintRepository(db *gorm.DB) *SprintRepository {
+	return &SprintRepository{DB: db}
+}
+
+// Create inserts a new sprint into the database
+func (r *SprintRepository) Create(sprint *models.Sprint) error {
+	return r.DB.Create(sprint).Error
+}
+
+// FindByID retrieves a sprint by its ID
+func (r *SprintRepository) FindByID(id uuid.UUID) (*models.Sprint, error) {
+	var sprint models.Sprint
+	err := r.DB.Where("id = ?", id).First(&sprint).Error
+	return &sprint, err
+}
+
+// FindByProjectID retrieves all sprints of a project in chronological order
+func (r *SprintRepository) FindByProjectID(projectID uuid.UUID) ([]models.Sprint, error) {
+	var sprints []models.Sprint
+	err := r.DB.Where("project_id = ?", projectID).
+		Order("start_date ASC, created_at ASC").
+		Find(&sprints).Error
+	return sprints, err
+}
+
+// FindClosedByProjectID retrieves the closed sprints of a project, most recent last
+func (r *SprintRepository) FindClosedByProjectID(projectID uuid.UUID) ([]models.Sprint, error) {
+	var sprints []models.Sprint
+	err := r.DB.Where("project_id = ? AND state = ?", projectID, models.SprintStateClosed).
+		Order("closed_at ASC").
+		Find(&sprints).Error
+	return sprints, err
+}
+
+// FindNextPlanned retrieves the earliest planned sprint of a project other than the given one
+func (r *SprintRepository) FindNextPlanned(projectID, excludeID uuid.UUID) (*models.Sprint, error) {
+	var sprint models.Sprint
+	err := r.DB.Where("project_id = ? AND state = ? AND id <> ?", projectID, models.SprintStatePlanned, excludeID).
+		Order("start_date ASC, created_at ASC").
+		First(&sprint).Error
+	return &sprint, err
+}
+
+// Update modifies an existing sprint
+func (r *SprintRepository) Update(id uuid.UUID, data map[string]interface{}) error {
+	return r.DB.Model(&models.Sprint{}).Where("id = ?", id).Updates(data).Error
+}
+
+// Delete removes a sprint, moving its tasks back to the backlog
+func (r *SprintRepository) Delete(id uuid.UUID) error {
+	return r.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&models.Task{}).Where("sprint_id = ?", id).Update("sprint_id", nil).Error; err != nil {
+			return err
+		}
+		return tx.Where("id = ?", id).Delete(&models.Sprint{}).Error
+	})
+}
+
+// CountPoints summarises the live tasks of a sprint
+func (r *SprintRepository) CountPoints(sprintID uuid.UUID) (*SprintPoints, error) {
+	var points SprintPoints
+	err := r.DB.Model(&models.Task{}).
+		Select("COUNT(*) AS task_count, "+
+			"COALESCE(SUM(story_points), 0) AS total_points, "+
+			"COALESCE(SUM(story_points) FILTER (WHERE status = ?), 0) AS done_points", models.TaskStatusDone).
+		Where("sprint_id = ?", sprintID).
+		Scan(&points).Error
+	return &points, err
+}
+
+// CountProjectTasks counts how many of the given tasks belong to live boards of the project
+func (r *SprintRepository) CountProjectTasks(projectID uuid.UUID, taskIDs []uuid.UUID) (int64, error) {
+	var count int64
+	err := r.DB.Model(&models.Task{}).
+		Joins("JOIN boards ON boards.id = tasks.board_id AND boards.deleted_at IS NULL").
+		Where("boards.project_id = ? AND tasks.id IN ?", projectID, taskIDs).
+		Count(&count).Error
+	return count, err
+}
+
+// AssignTasks plans the given tasks into a sprint
+func (r *SprintRepository) AssignTasks(sprintID uuid.UUID, taskIDs []uuid.UUID) error {
+	return r.DB.Model(&models.Task{}).Where("id IN ?", taskIDs).Update("sprint_id", sprintID).Error
+}
+
+// RemoveTask moves a task of the sprint back to the backlog
+func (r *SprintRepository) RemoveTask(sprintID, taskID uuid.UUID) (bool, error) {
+	result := r.DB.Model(&models.Task{}).
+		Where("id = ? AND sprint_id = ?", taskID, sprintID).
+		Update("sprint_id", nil)
+	return result.RowsAffected > 0, result.Error
+}
+
+// Start activates a sprint and records the points committed to it
+func (r *SprintRepository) Start(sprint *models.Sprint) error {
+	return r.DB.Transaction(func(tx *gorm.DB) error {
+		var committed int64
+		if err := tx.Model(&models.Task{}).
+			Select("COALESCE(SUM(story_points), 0)").
+			Where("sprint_id = ?", sprint.ID).
+			Scan(&committed).Error; err != nil {
+			return err
+		}
+
+		sprint.State = models.SprintStateActive
+		sprint.CommittedPoints = int(committed)
+		return tx.Model(&models.Sprint{}).Where("id = ?", sprint.ID).Updates(map[string]interface{}{
+			"state":            sprint.State,
+			"committed_points": sprint.CommittedPoints,
+		}).Error
+	})
+}
+
+// Close records the velocity of a sprint and rolls its unfinished tasks into the next sprint,
+// or back to the backlog when nextSprintID is nil. It returns the number of tasks moved.
+func (r *SprintRepository) Close(sprint *models.Sprint, nextSprintID *uuid.UUID, closedAt time.Time) (int64, error) {
+	var moved int64
+	err := r.DB.Transaction(func(tx *gorm.DB) error {
+		var completed int64
+		if err := tx.Model(&models.Task{}).
+			Select("COALESCE(SUM(story_points), 0)").
+			Where("sprint_id = ? AND status = ?", sprint.ID, models.TaskStatusDone).
+			Scan(&completed).Error; err != nil {
+			return err
+		}
+
+		result := tx.Model(&models.Task{}).
+			Where("sprint_id = ? AND status <> ?", sprint.ID, models.TaskStatusDone).
+			Update("sprint_id", nextSprintID)
+		if result.Error != nil {
+			return result.Error
+		}
+		moved = result.RowsAffected
+
+		sprint.State = models.SprintStateClosed
+		sprint.CompletedPoints = int(completed)
+		sprint.ClosedAt = &closedAt
+		return tx.Model(&models.Sprint{}).Where("id = ?", sprint.ID).Updates(map[string]interface{}{
+			"state":            sprint.State,
+			"completed_points": sprint.CompletedPoints,
+			"closed_at":        closedAt,
+		}).Error
+	})
+	return moved, err
+}
@@ -0,0 +1,76 @@
+package repository
+
+import (
+	"log"
+	"time"
+
+	"github.com/Hann-arc/task-management-backend/internal/models"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type TaskRecurrenceRepository struct {
+	DB *gorm.DB
+}
+
+// NewTaskRecurrenceRepository creates a new instance of TaskRecurrenceRepository
+func NewTaskRecurrenceRepository(db *gorm.DB) *TaskRecurrenceRepository {
+	return &TaskRecurrenceRepository{DB: db}
+}
+
+// Save creates or replaces the recurrence of a template task
+func (r *TaskRecurrenceRepository) Save(recurrence *models.TaskRecurrence) error {
+	return r.DB.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "template_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"board_id", "rule", "start_at", "next_run_at", "last_task_id", "is_active", "updated_at"}),
+	}).Create(recurrence).Error
+}
+
+// FindByTemplateID retrieves the recurrence attached to a template task
+func (r *TaskRecurrenceRepository) FindByTemplateID(templateID uuid.UUID) (*models.TaskRecurrence, error) {
+	var recurrence models.TaskRecurrence
+	err := r.DB.Where("template_id = ?", templateID).First(&recurrence).Error
+	return &recurrence, err
+}
+
+// DeleteByTemplateID removes the recurrence attached to a template task
+func (r *TaskRecurrenceRepository) DeleteByTemplateID(templateID uuid.UUID) error {
+	return r.DB.Where("template_id = ?", templateID).Delete(&models.TaskRecurrence{}).Error
+}
+
+// ProcessDue locks the active recurrences whose next occurrence has arrived or whose
+// latest instance is done, outside of archived or deleted boards and projects, and passes each one to handle.
+// Each recurrence runs in its own savepoint, so a failing series is rolled back and deactivated without undoing
+// the others; otherwise it would be picked first on every run and could starve the rest. Setting the recurrence
+// again reactivates it.
+// Rows locked by another replica are skipped, so every occurrence is handled once.
+func (r *TaskRecurrenceRepository) ProcessDue(now time.Time, limit int, handle func(tx *gorm.DB, recurrence *models.TaskRecurrence) error) error {
+	return r.DB.Transaction(func(tx *gorm.DB) error {
+		var recurrences []models.TaskRecurrence
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+			Where("is_active = ?", true).
+			Where("next_run_at <= ? OR EXISTS (SELECT 1 FROM tasks WHERE tasks.id = task_recurrences.last_task_id AND tasks.status = ?)", now, models.TaskStatusDone).
+			Where("NOT EXISTS (SELECT 1 FROM boards JOIN projects ON projects.id = boards.project_id WHERE boards.id = task_recurrences.board_id AND (boards.archived_at IS NOT NULL OR projects.archived_at IS NOT NULL OR boards.deleted_at IS NOT NULL OR projects.deleted_at IS NOT NULL))").
+			Order("next_run_at ASC").
+			Limit(limit).
+			Find(&recurrences).Error
+		if err != nil {
+			return err
+		}
+
+		for i := range recurrences {
+			recurrence := &recurrences[i]
+			err := tx.Transaction(func(sp *gorm.DB) error {
+				return handle(sp, recurrence)
+			})
+			if err != nil {
+				log.Printf("Failed to process recurrence %s, deactivating it: %v", recurrence.ID, err)
+				if err := tx.Model(&models.TaskRecurrence{}).Where("id = ?", recurrence.ID).Update("is_active", false).Error; err != nil {
+					return err
+				}
+			}
+		}
+		return nil
+	})
+}
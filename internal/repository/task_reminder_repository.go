@@ -0,0 +1,41 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/Hann-arc/task-management-backend/internal/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type TaskReminderRepository struct {
+	DB *gorm.DB
+}
+
+// NewTaskReminderRepository creates a new instance of TaskReminderRepository
+func NewTaskReminderRepository(db *gorm.DB) *TaskReminderRepository {
+	return &TaskReminderRepository{DB: db}
+}
+
+// FindOpenTasksDueBetween retrieves unfinished tasks in live, unarchived boards whose due date is in (from, to]
+func (r *TaskReminderRepository) FindOpenTasksDueBetween(from, to time.Time) ([]models.Task, error) {
+	var tasks []models.Task
+	err := r.DB.Model(&models.Task{}).
+		Joins("JOIN boards ON boards.id = tasks.board_id AND boards.deleted_at IS NULL AND boards.archived_at IS NULL").
+		Joins("JOIN projects ON projects.id = boards.project_id AND projects.deleted_at IS NULL AND projects.archived_at IS NULL").
+		Where("tasks.status <> ?", models.TaskStatusDone).
+		Where("tasks.due_date > ? AND tasks.due_date <= ?", from, to).
+		Preload("Assignees").
+		Find(&tasks).Error
+	return tasks, err
+}
+
+// MarkSent records a reminder and reports whether it was recorded now.
+// It returns false when the reminder was already sent, by this or another instance.
+func (r *TaskReminderRepository) MarkSent(reminder *models.TaskReminder) (bool, error) {
+	result := r.DB.Clauses(clause.OnConflict{DoNothing: true}).Create(reminder)
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected > 0, nil
+}
@@ -1,15 +1,83 @@
 package repository
 
 import (
+	"fmt"
+	"time"
+
 	"github.com/Hann-arc/task-management-backend/internal/models"
+	"github.com/Hann-arc/task-management-backend/internal/utils"
 	"github.com/google/uuid"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type TaskRepository struct {
 	DB *gorm.DB
 }
 
+// TaskSortColumns maps the public sort keys to their SQL expressions.
+// Tasks without a due date are stored as NULL or as the zero time, both sort last.
+var TaskSortColumns = map[string]string{
+	"due_date":   "CASE WHEN tasks.due_date IS NULL OR tasks.due_date < '1900-01-01' THEN 'infinity'::timestamptz ELSE tasks.due_date END",
+	"priority":   "CASE tasks.priority WHEN 'low' THEN 1 WHEN 'medium' THEN 2 WHEN 'high' THEN 3 WHEN 'urgent' THEN 4 ELSE 0 END",
+	"created_at": "tasks.created_at",
+	"updated_at": "tasks.updated_at",
+}
+
+// TaskFilter holds the optional filters, sorting and keyset cursor for task listings
+type TaskFilter struct {
+	BoardID    *uuid.UUID
+	ProjectID  *uuid.UUID
+	AssigneeID *uuid.UUID
+	CreatedBy  *uuid.UUID
+	Priorities []string
+	Statuses   []string
+	Label      string
+	SprintID   *uuid.UUID
+	Backlog    bool
+	DueFrom    *time.Time
+	DueTo      *time.Time
+	Overdue    bool
+	Search     string
+
+	// CustomFields limits results to tasks whose custom field values match every condition
+	CustomFields []CustomFieldCondition
+
+	// InvolvedUserID limits results to tasks assigned to or created by the user
+	// in live, unarchived projects the user still belongs to
+	InvolvedUserID *uuid.UUID
+	InvolvedRole   string
+
+	// IncludeContext preloads the board and project of each task
+	IncludeContext bool
+
+	SortBy string
+	Desc   bool
+
+	// CursorValue and CursorID identify the last row of the previous page
+	CursorValue string
+	CursorID    *uuid.UUID
+	Limit       int
+}
+
+// CustomFieldCondition matches the value of a custom field, interpreted according to its type
+type CustomFieldCondition struct {
+	FieldID uuid.UUID
+	Type    string
+	Value   interface{}
+}
+
+// customFieldFilterExprs maps each custom field type to the SQL matching a stored JSON value
+var customFieldFilterExprs = map[string]string{
+	models.CustomFieldTypeText:         "task_custom_field_values.value #>> '{}' ILIKE ? ESCAPE '\\'",
+	models.CustomFieldTypeNumber:       "(task_custom_field_values.value #>> '{}')::numeric = ?",
+	models.CustomFieldTypeDate:         "task_custom_field_values.value #>> '{}' = ?",
+	models.CustomFieldTypeSingleSelect: "task_custom_field_values.value #>> '{}' = ?",
+	models.CustomFieldTypeUser:         "task_custom_field_values.value #>> '{}' = ?",
+	models.CustomFieldTypeMultiSelect:  "task_custom_field_values.value @> jsonb_build_array(?::text)",
+	models.CustomFieldTypeCheckbox:     "(task_custom_field_values.value #>> '{}')::boolean = ?",
+}
+
 // NewTaskRepository creates a new instance of TaskRepository
 func NewTaskRepository(db *gorm.DB) *TaskRepository {
 	return &TaskRepository{DB: db}
@@ -25,11 +93,131 @@ func (r *TaskRepository) FindByBoardID(boardID uuid.UUID) ([]models.Task, error)
 
 	var tasks []models.Task
 
-	err := r.DB.Where("board_id = ?", boardID).Preload("Assignee", func(db *gorm.DB) *gorm.DB {
+	err := r.DB.Where("board_id = ?", boardID).Preload("Assignees.User", func(db *gorm.DB) *gorm.DB {
+		return db.Select("id, name")
+	}).Preload("Watchers.User", func(db *gorm.DB) *gorm.DB {
+		return db.Select("id, name")
+	}).Preload("Creator", func(db *gorm.DB) *gorm.DB {
+		return db.Select("id, name")
+	}).Preload("Mentions.User", func(db *gorm.DB) *gorm.DB {
+		return db.Select("id, name")
+	}).Preload("Labels").Preload("CustomFieldValues.Field").Find(&tasks).Error
+
+	return tasks, err
+}
+
+// FindFiltered retrieves tasks matching the given filter, ordered by the sort key and task ID
+func (r *TaskRepository) FindFiltered(filter TaskFilter) ([]models.Task, error) {
+	var tasks []models.Task
+
+	sortExpr, ok := TaskSortColumns[filter.SortBy]
+	if !ok {
+		sortExpr = TaskSortColumns["created_at"]
+	}
+
+	query := r.DB.Model(&models.Task{}).
+		Joins("JOIN boards ON boards.id = tasks.board_id AND boards.deleted_at IS NULL")
+
+	if filter.BoardID != nil {
+		query = query.Where("tasks.board_id = ?", *filter.BoardID)
+	}
+	if filter.ProjectID != nil {
+		query = query.Where("boards.project_id = ?", *filter.ProjectID)
+	}
+	if filter.AssigneeID != nil {
+		query = query.Where("EXISTS (SELECT 1 FROM task_assignees WHERE task_assignees.task_id = tasks.id AND task_assignees.user_id = ?)", *filter.AssigneeID)
+	}
+	if filter.CreatedBy != nil {
+		query = query.Where("tasks.created_by = ?", *filter.CreatedBy)
+	}
+	if len(filter.Priorities) > 0 {
+		query = query.Where("tasks.priority IN ?", filter.Priorities)
+	}
+	if len(filter.Statuses) > 0 {
+		query = query.Where("tasks.status IN ?", filter.Statuses)
+	}
+	if filter.SprintID != nil {
+		query = query.Where("tasks.sprint_id = ?", *filter.SprintID)
+	}
+	if filter.Backlog {
+		query = query.Where("tasks.sprint_id IS NULL")
+	}
+	for _, cf := range filter.CustomFields {
+		// an unchecked checkbox also matches tasks that never set the field
+		if cf.Type == models.CustomFieldTypeCheckbox && cf.Value == false {
+			query = query.Where("NOT EXISTS (SELECT 1 FROM task_custom_field_values WHERE task_custom_field_values.task_id = tasks.id AND task_custom_field_values.field_id = ? AND task_custom_field_values.value = 'true'::jsonb)", cf.FieldID)
+			continue
+		}
+		query = query.Where("EXISTS (SELECT 1 FROM task_custom_field_values WHERE task_custom_field_values.task_id = tasks.id AND task_custom_field_values.field_id = ? AND "+customFieldFilterExprs[cf.Type]+")", cf.FieldID, cf.Value)
+	}
+	if filter.Label != "" {
+		query = query.Where("EXISTS (SELECT 1 FROM task_labels WHERE task_labels.task_id = tasks.id AND LOWER(task_labels.name) = LOWER(?))", filter.Label)
+	}
+	if filter.DueFrom != nil {
+		query = query.Where("tasks.due_date >= ?", *filter.DueFrom)
+	}
+	if filter.DueTo != nil {
+		query = query.Where("tasks.due_date <= ?", *filter.DueTo)
+	}
+	if filter.Overdue {
+		query = query.Where("tasks.due_date > '1900-01-01' AND tasks.due_date < ? AND tasks.status <> ?", time.Now(), models.TaskStatusDone)
+	}
+	if filter.InvolvedUserID != nil {
+		userID := *filter.InvolvedUserID
+		switch filter.InvolvedRole {
+		case "assigned":
+			query = query.Where("EXISTS (SELECT 1 FROM task_assignees WHERE task_assignees.task_id = tasks.id AND task_assignees.user_id = ?)", userID)
+		case "created":
+			query = query.Where("tasks.created_by = ?", userID)
+		default:
+			query = query.Where("(tasks.created_by = ? OR EXISTS (SELECT 1 FROM task_assignees WHERE task_assignees.task_id = tasks.id AND task_assignees.user_id = ?))", userID, userID)
+		}
+		query = query.Joins("JOIN projects ON projects.id = boards.project_id AND projects.deleted_at IS NULL AND projects.archived_at IS NULL").
+			Where("(projects.owner_id = ? OR EXISTS (SELECT 1 FROM project_members WHERE project_members.project_id = projects.id AND project_members.user_id = ?))", userID, userID)
+	}
+	if filter.IncludeContext {
+		query = query.Preload("Board", func(db *gorm.DB) *gorm.DB {
+			return db.Select("id, name, order_index, project_id")
+		}).Preload("Board.Project", func(db *gorm.DB) *gorm.DB {
+			return db.Select("id, name")
+		})
+	}
+	if filter.Search != "" {
+		pattern := "%" + utils.EscapeLikePattern(filter.Search) + "%"
+		query = query.Where("(tasks.title ILIKE ? ESCAPE '\\' OR tasks.description ILIKE ? ESCAPE '\\')", pattern, pattern)
+	}
+
+	cast := "timestamptz"
+	if filter.SortBy == "priority" {
+		cast = "int"
+	}
+
+	direction := "ASC"
+	comparator := ">"
+	if filter.Desc {
+		direction = "DESC"
+		comparator = "<"
+	}
+
+	if filter.CursorID != nil {
+		query = query.Where(
+			fmt.Sprintf("(%s, tasks.id) %s (?::%s, ?)", sortExpr, comparator, cast),
+			filter.CursorValue, *filter.CursorID,
+		)
+	}
+
+	err := query.Preload("Assignees.User", func(db *gorm.DB) *gorm.DB {
+		return db.Select("id, name")
+	}).Preload("Watchers.User", func(db *gorm.DB) *gorm.DB {
 		return db.Select("id, name")
 	}).Preload("Creator", func(db *gorm.DB) *gorm.DB {
 		return db.Select("id, name")
-	}).Preload("Labels").Find(&tasks).Error
+	}).Preload("Mentions.User", func(db *gorm.DB) *gorm.DB {
+		return db.Select("id, name")
+	}).Preload("Labels").Preload("CustomFieldValues.Field").
+		Order(fmt.Sprintf("%s %s, tasks.id %s", sortExpr, direction, direction)).
+		Limit(filter.Limit).
+		Find(&tasks).Error
 
 	return tasks, err
 }
@@ -38,11 +226,15 @@ func (r *TaskRepository) FindByBoardID(boardID uuid.UUID) ([]models.Task, error)
 func (r *TaskRepository) FindByID(id uuid.UUID) (*models.Task, error) {
 	var task models.Task
 
-	err := r.DB.Preload("Assignee", func(db *gorm.DB) *gorm.DB {
+	err := r.DB.Preload("Assignees.User", func(db *gorm.DB) *gorm.DB {
+		return db.Select("id, name")
+	}).Preload("Watchers.User", func(db *gorm.DB) *gorm.DB {
 		return db.Select("id, name")
 	}).Preload("Creator", func(db *gorm.DB) *gorm.DB {
 		return db.Select("id, name")
-	}).Preload("Labels").
+	}).Preload("Mentions.User", func(db *gorm.DB) *gorm.DB {
+		return db.Select("id, name")
+	}).Preload("Labels").Preload("CustomFieldValues.Field").
 		First(&task, "id = ?", id).Error
 	return &task, err
 }
@@ -52,9 +244,12 @@ func (r *TaskRepository) Update(id uuid.UUID, data map[string]interface{}) error
 	return r.DB.Model(&models.Task{}).Where("id = ?", id).Updates(data).Error
 }
 
-// SoftDelete marks a task as deleted without removing it from the database
+// SoftDelete marks a task as deleted without removing it from the database, together with
+// its comments and notifications
 func (r *TaskRepository) SoftDelete(id uuid.UUID) error {
-	return r.DB.Delete(&models.Task{}, "id = ?", id).Error
+	return r.DB.Transaction(func(tx *gorm.DB) error {
+		return softDeleteTasks(tx, time.Now(), "id = ?", id)
+	})
 }
 
 // BoardExists checks if a board with the given ID exists
@@ -71,6 +266,28 @@ func (r *TaskRepository) UserExists(id uuid.UUID) (bool, error) {
 	return count > 0, err
 }
 
+// IsArchived checks whether the board or project of a task is archived, making the task read-only
+func (r *TaskRepository) IsArchived(taskID uuid.UUID) (bool, error) {
+	var count int64
+	err := r.DB.Model(&models.Task{}).
+		Joins("JOIN boards ON boards.id = tasks.board_id").
+		Joins("JOIN projects ON projects.id = boards.project_id").
+		Where("tasks.id = ? AND (boards.archived_at IS NOT NULL OR projects.archived_at IS NOT NULL)", taskID).
+		Count(&count).Error
+	return count > 0, err
+}
+
+// FindProjectID resolves the project a task belongs to
+func (r *TaskRepository) FindProjectID(taskID uuid.UUID) (uuid.UUID, error) {
+	var board models.Board
+	err := r.DB.Model(&models.Board{}).
+		Select("boards.project_id").
+		Joins("JOIN tasks ON tasks.board_id = boards.id").
+		Where("tasks.id = ?", taskID).
+		First(&board).Error
+	return board.ProjectID, err
+}
+
 // ReplaceLabels replaces all labels associated with a task
 func (r *TaskRepository) ReplaceLabels(taskID uuid.UUID, labels []models.TaskLabel) error {
 	if err := r.DB.Where("task_id = ?", taskID).Delete(&models.TaskLabel{}).Error; err != nil {
@@ -83,3 +300,63 @@ func (r *TaskRepository) ReplaceLabels(taskID uuid.UUID, labels []models.TaskLab
 	}
 	return nil
 }
+
+// ReplaceAssignees replaces all assignees of a task
+func (r *TaskRepository) ReplaceAssignees(taskID uuid.UUID, userIDs []uuid.UUID) error {
+	return r.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("task_id = ?", taskID).Delete(&models.TaskAssignee{}).Error; err != nil {
+			return err
+		}
+		if len(userIDs) == 0 {
+			return nil
+		}
+
+		var assignees []models.TaskAssignee
+		for _, id := range userIDs {
+			assignees = append(assignees, models.TaskAssignee{ID: uuid.New(), TaskID: taskID, UserID: id})
+		}
+		return tx.Create(&assignees).Error
+	})
+}
+
+// SaveCustomFieldValues upserts the given custom field values of a task and removes the cleared ones
+func (r *TaskRepository) SaveCustomFieldValues(taskID uuid.UUID, values []models.TaskCustomFieldValue, removedFieldIDs []uuid.UUID) error {
+	return r.DB.Transaction(func(tx *gorm.DB) error {
+		if len(removedFieldIDs) > 0 {
+			if err := tx.Where("task_id = ? AND field_id IN ?", taskID, removedFieldIDs).
+				Delete(&models.TaskCustomFieldValue{}).Error; err != nil {
+				return err
+			}
+		}
+		if len(values) == 0 {
+			return nil
+		}
+		return tx.Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "task_id"}, {Name: "field_id"}},
+			DoUpdates: clause.AssignmentColumns([]string{"value", "updated_at"}),
+		}).Create(&values).Error
+	})
+}
+
+// AddWatcher subscribes a user to a task, doing nothing if already watching
+func (r *TaskRepository) AddWatcher(taskID, userID uuid.UUID) error {
+	return r.DB.Clauses(clause.OnConflict{DoNothing: true}).
+		Create(&models.TaskWatcher{ID: uuid.New(), TaskID: taskID, UserID: userID}).Error
+}
+
+// RemoveWatcher unsubscribes a user from a task
+func (r *TaskRepository) RemoveWatcher(taskID, userID uuid.UUID) error {
+	return r.DB.Where("task_id = ? AND user_id = ?", taskID, userID).Delete(&models.TaskWatcher{}).Error
+}
+
+// FindAudienceIDs returns the creator, assignees and watchers of a task
+func (r *TaskRepository) FindAudienceIDs(taskID uuid.UUID) ([]uuid.UUID, error) {
+	var ids []uuid.UUID
+	err := r.DB.Raw(`
+		SELECT created_by FROM tasks WHERE id = @task
+		UNION SELECT user_id FROM task_assignees WHERE task_id = @task
+		UNION SELECT user_id FROM task_watchers WHERE task_id = @task`,
+		map[string]interface{}{"task": taskID},
+	).Scan(&ids).Error
+	return ids, err
+}
@@ -0,0 +1,63 @@
+package repository
+
+import (
+	"github.com/Hann-arc/task-management-backend/internal/models"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type TaskTemplateRepository struct {
+	DB *gorm.DB
+}
+
+// NewTaskTemplateRepository creates a new instance of TaskTemplateRepository
+func NewTaskTemplateRepository(db *gorm.DB) *TaskTemplateRepository {
+	return &TaskTemplateRepository{DB: db}
+}
+
+// Create inserts a new task template into the database
+func (r *TaskTemplateRepository) Create(template *models.TaskTemplate) error {
+	return r.DB.Create(template).Error
+}
+
+// FindByID retrieves a task template by its ID
+func (r *TaskTemplateRepository) FindByID(id uuid.UUID) (*models.TaskTemplate, error) {
+	var template models.TaskTemplate
+	err := r.DB.Preload("DefaultAssignee", func(db *gorm.DB) *gorm.DB {
+		return db.Select("id, name")
+	}).Where("id = ?", id).First(&template).Error
+	return &template, err
+}
+
+// FindByProjectID retrieves the task templates of a project ordered by name
+func (r *TaskTemplateRepository) FindByProjectID(projectID uuid.UUID) ([]models.TaskTemplate, error) {
+	var templates []models.TaskTemplate
+	err := r.DB.Preload("DefaultAssignee", func(db *gorm.DB) *gorm.DB {
+		return db.Select("id, name")
+	}).Where("project_id = ?", projectID).
+		Order("name ASC").
+		Find(&templates).Error
+	return templates, err
+}
+
+// NameExists checks whether another template of the project already uses the name, ignoring case
+func (r *TaskTemplateRepository) NameExists(projectID uuid.UUID, name string, excludeID *uuid.UUID) (bool, error) {
+	var count int64
+	query := r.DB.Model(&models.TaskTemplate{}).
+		Where("project_id = ? AND LOWER(name) = LOWER(?)", projectID, name)
+	if excludeID != nil {
+		query = query.Where("id <> ?", *excludeID)
+	}
+	err := query.Count(&count).Error
+	return count > 0, err
+}
+
+// Update modifies an existing task template
+func (r *TaskTemplateRepository) Update(id uuid.UUID, data map[string]interface{}) error {
+	return r.DB.Model(&models.TaskTemplate{}).Where("id = ?", id).Updates(data).Error
+}
+
+// Delete removes a task template
+func (r *TaskTemplateRepository) Delete(id uuid.UUID) error {
+	return r.DB.Where("id = ?", id).Delete(&models.TaskTemplate{}).Error
+}
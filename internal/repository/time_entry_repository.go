@@ -0,0 +1,143 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/Hann-arc/task-management-backend/internal/models"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// TimeReportGroupColumns maps a report grouping to its ID and name columns
+var TimeReportGroupColumns = map[string][2]string{
+	"task":    {"tasks.id", "tasks.title"},
+	"user":    {"users.id", "users.name"},
+	"project": {"projects.id", "projects.name"},
+}
+
+// TimeReportFilter narrows the time entries aggregated in a report
+type TimeReportFilter struct {
+	ProjectID *uuid.UUID
+	TaskID    *uuid.UUID
+	UserID    *uuid.UUID
+	From      *time.Time
+	To        *time.Time
+	GroupBy   string
+}
+
+// TimeReportRow is a single aggregated group of a time report
+type TimeReportRow struct {
+	GroupID         uuid.UUID
+	Name            string
+	TotalSeconds    int64
+	EntryCount      int64
+	EstimateMinutes *int
+}
+
+type TimeEntryRepository struct {
+	DB *gorm.DB
+}
+
+// NewTimeEntryRepository creates a new instance of TimeEntryRepository
+func NewTimeEntryRepository(db *gorm.DB) *TimeEntryRepository {
+	return &TimeEntryRepository{DB: db}
+}
+
+// Create inserts a new time entry into the database
+func (r *TimeEntryRepository) Create(entry *models.TimeEntry) error {
+	return r.DB.Create(entry).Error
+}
+
+// FindByID retrieves a time entry by its ID
+func (r *TimeEntryRepository) FindByID(id uuid.UUID) (*models.TimeEntry, error) {
+	var entry models.TimeEntry
+	err := r.DB.Preload("User").Where("id = ?", id).First(&entry).Error
+	return &entry, err
+}
+
+// FindRunningByUser retrieves the timer currently running for a user
+func (r *TimeEntryRepository) FindRunningByUser(userID uuid.UUID) (*models.TimeEntry, error) {
+	var entry models.TimeEntry
+	err := r.DB.Preload("User").
+		Where("user_id = ? AND ended_at IS NULL", userID).
+		First(&entry).Error
+	return &entry, err
+}
+
+// Stop ends a running timer, doing nothing if it was stopped concurrently
+func (r *TimeEntryRepository) Stop(entry *models.TimeEntry, endedAt time.Time) (bool, error) {
+	duration := int64(endedAt.Sub(entry.StartedAt).Seconds())
+	result := r.DB.Model(&models.TimeEntry{}).
+		Where("id = ? AND ended_at IS NULL", entry.ID).
+		Updates(map[string]interface{}{
+			"ended_at":         endedAt,
+			"duration_seconds": duration,
+		})
+	if result.Error != nil {
+		return false, result.Error
+	}
+
+	entry.EndedAt = &endedAt
+	entry.DurationSeconds = duration
+	return result.RowsAffected > 0, nil
+}
+
+// FindByTaskID retrieves the time entries of a task, optionally limited to a date range
+func (r *TimeEntryRepository) FindByTaskID(taskID uuid.UUID, from, to *time.Time) ([]models.TimeEntry, error) {
+	var entries []models.TimeEntry
+	query := r.DB.Preload("User").Where("task_id = ?", taskID)
+	if from != nil {
+		query = query.Where("started_at >= ?", *from)
+	}
+	if to != nil {
+		query = query.Where("started_at <= ?", *to)
+	}
+	err := query.Order("started_at DESC").Find(&entries).Error
+	return entries, err
+}
+
+// Delete removes a time entry from the database
+func (r *TimeEntryRepository) Delete(id uuid.UUID) error {
+	return r.DB.Where("id = ?", id).Delete(&models.TimeEntry{}).Error
+}
+
+// Report aggregates finished time entries by task, user or project
+func (r *TimeEntryRepository) Report(filter TimeReportFilter) ([]TimeReportRow, error) {
+	columns := TimeReportGroupColumns[filter.GroupBy]
+
+	selects := columns[0] + " AS group_id, " + columns[1] + " AS name, " +
+		"SUM(time_entries.duration_seconds) AS total_seconds, COUNT(*) AS entry_count"
+	groupBy := columns[0] + ", " + columns[1]
+	if filter.GroupBy == "task" {
+		selects += ", tasks.estimate_minutes AS estimate_minutes"
+		groupBy += ", tasks.estimate_minutes"
+	}
+
+	query := r.DB.Table("time_entries").
+		Select(selects).
+		Joins("JOIN tasks ON tasks.id = time_entries.task_id AND tasks.deleted_at IS NULL").
+		Joins("JOIN boards ON boards.id = tasks.board_id AND boards.deleted_at IS NULL").
+		Joins("JOIN projects ON projects.id = boards.project_id AND projects.deleted_at IS NULL").
+		Joins("JOIN users ON users.id = time_entries.user_id").
+		Where("time_entries.ended_at IS NOT NULL")
+
+	if filter.ProjectID != nil {
+		query = query.Where("boards.project_id = ?", *filter.ProjectID)
+	}
+	if filter.TaskID != nil {
+		query = query.Where("time_entries.task_id = ?", *filter.TaskID)
+	}
+	if filter.UserID != nil {
+		query = query.Where("time_entries.user_id = ?", *filter.UserID)
+	}
+	if filter.From != nil {
+		query = query.Where("time_entries.started_at >= ?", *filter.From)
+	}
+	if filter.To != nil {
+		query = query.Where("time_entries.started_at <= ?", *filter.To)
+	}
+
+	var rows []TimeReportRow
+	err := query.Group(groupBy).Order("total_seconds DESC").Scan(&rows).Error
+	return rows, err
+}
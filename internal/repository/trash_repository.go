@@ -0,0 +1,321 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/Hann-arc/task-management-backend/internal/models"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type TrashRepository struct {
+	DB *gorm.DB
+}
+
+// NewTrashRepository creates a new instance of TrashRepository
+func NewTrashRepository(db *gorm.DB) *TrashRepository {
+	return &TrashRepository{DB: db}
+}
+
+// FindDeletedProject retrieves a soft-deleted project by its ID
+func (r *TrashRepository) FindDeletedProject(id uuid.UUID) (*models.Project, error) {
+	var project models.Project
+	err := r.DB.Unscoped().Where("id = ? AND deleted_at IS NOT NULL", id).First(&project).Error
+	return &project, err
+}
+
+// FindDeletedProjectsByOwner retrieves the soft-deleted projects of an owner, most recent first
+func (r *TrashRepository) FindDeletedProjectsByOwner(ownerID uuid.UUID) ([]models.Project, error) {
+	var projects []models.Project
+	err := r.DB.Unscoped().
+		Where("owner_id = ? AND deleted_at IS NOT NULL", ownerID).
+		Order("deleted_at DESC").
+		Find(&projects).Error
+	return projects, err
+}
+
+// FindDeletedBoards retrieves the soft-deleted boards of a project
+func (r *TrashRepository) FindDeletedBoards(projectID uuid.UUID) ([]models.Board, error) {
+	var boards []models.Board
+	err := r.DB.Unscoped().
+		Where("project_id = ? AND deleted_at IS NOT NULL", projectID).
+		Order("deleted_at DESC").
+		Find(&boards).Error
+	return boards, err
+}
+
+// FindDeletedTasks retrieves the tasks of a project that were deleted on their own,
+// leaving out those whose board is deleted as well
+func (r *TrashRepository) FindDeletedTasks(projectID uuid.UUID) ([]models.Task, error) {
+	var tasks []models.Task
+	err := r.DB.Unscoped().
+		Joins("JOIN boards ON boards.id = tasks.board_id").
+		Where("boards.project_id = ? AND boards.deleted_at IS NULL AND tasks.deleted_at IS NOT NULL", projectID).
+		Order("tasks.deleted_at DESC").
+		Find(&tasks).Error
+	return tasks, err
+}
+
+// FindDeletedComments retrieves the comments of a project that were deleted on their own,
+// leaving out those whose task, board or parent comment is deleted as well
+func (r *TrashRepository) FindDeletedComments(projectID uuid.UUID) ([]models.Comment, error) {
+	var comments []models.Comment
+	err := r.DB.Unscoped().
+		Joins("JOIN tasks ON tasks.id = comments.task_id").
+		Joins("JOIN boards ON boards.id = tasks.board_id").
+		Where("boards.project_id = ? AND boards.deleted_at IS NULL AND tasks.deleted_at IS NULL AND comments.deleted_at IS NOT NULL", projectID).
+		Where("comments.parent_id IS NULL OR NOT EXISTS (SELECT 1 FROM comments parents WHERE parents.id = comments.parent_id AND parents.deleted_at IS NOT NULL)").
+		Order("comments.deleted_at DESC").
+		Find(&comments).Error
+	return comments, err
+}
+
+// FindDeletedBoard retrieves a soft-deleted board by its ID
+func (r *TrashRepository) FindDeletedBoard(id uuid.UUID) (*models.Board, error) {
+	var board models.Board
+	err := r.DB.Unscoped().Where("id = ? AND deleted_at IS NOT NULL", id).First(&board).Error
+	return &board, err
+}
+
+// FindDeletedTask retrieves a soft-deleted task by its ID
+func (r *TrashRepository) FindDeletedTask(id uuid.UUID) (*models.Task, error) {
+	var task models.Task
+	err := r.DB.Unscoped().Where("id = ? AND deleted_at IS NOT NULL", id).First(&task).Error
+	return &task, err
+}
+
+// FindDeletedComment retrieves a soft-deleted comment by its ID
+func (r *TrashRepository) FindDeletedComment(id uuid.UUID) (*models.Comment, error) {
+	var comment models.Comment
+	err := r.DB.Unscoped().Where("id = ? AND deleted_at IS NOT NULL", id).First(&comment).Error
+	return &comment, err
+}
+
+// IsDeleted checks whether the row of the given model with the ID is soft-deleted or missing
+func (r *TrashRepository) IsDeleted(model interface{}, id uuid.UUID) (bool, error) {
+	var count int64
+	err := r.DB.Model(model).Where("id = ?", id).Count(&count).Error
+	return count == 0, err
+}
+
+// BoardProjectID resolves the project of a board, including deleted boards
+func (r *TrashRepository) BoardProjectID(boardID uuid.UUID) (uuid.UUID, error) {
+	var board models.Board
+	err := r.DB.Unscoped().Select("project_id").Where("id = ?", boardID).First(&board).Error
+	return board.ProjectID, err
+}
+
+// TaskProjectID resolves the board and project of a task, including deleted tasks and boards
+func (r *TrashRepository) TaskProjectID(taskID uuid.UUID) (uuid.UUID, uuid.UUID, error) {
+	var row struct {
+		BoardID   uuid.UUID
+		ProjectID uuid.UUID
+	}
+	err := r.DB.Table("tasks").
+		Select("tasks.board_id, boards.project_id").
+		Joins("JOIN boards ON boards.id = tasks.board_id").
+		Where("tasks.id = ?", taskID).
+		Take(&row).Error
+	return row.BoardID, row.ProjectID, err
+}
+
+// The restore methods bring back an item together with the children that were deleted
+// at the same time as it or later, so children deleted on their own beforehand stay in the trash.
+
+// RestoreProject restores a project with the boards, tasks, comments, invitations and
+// notifications deleted along with it
+func (r *TrashRepository) RestoreProject(project *models.Project) error {
+	since := project.DeletedAt.Time
+	return r.DB.Transaction(func(tx *gorm.DB) error {
+		if err := restoreRows(tx, &models.Project{}, since, "id = ?", project.ID); err != nil {
+			return err
+		}
+		if err := restoreRows(tx, &models.Board{}, since, "project_id = ?", project.ID); err != nil {
+			return err
+		}
+		boardIDs := tx.Unscoped().Model(&models.Board{}).Select("id").Where("project_id = ?", project.ID)
+		if err := restoreTasks(tx, since, "board_id IN (?)", boardIDs); err != nil {
+			return err
+		}
+		if err := restoreRows(tx, &models.Invitation{}, since, "project_id = ?", project.ID); err != nil {
+			return err
+		}
+		return restoreRows(tx, &models.Notification{}, since, "reference_type = ? AND related_id = ?", "project", project.ID)
+	})
+}
+
+// RestoreBoard restores a board with the tasks and comments deleted along with it, placing it last
+func (r *TrashRepository) RestoreBoard(board *models.Board) error {
+	since := board.DeletedAt.Time
+	return r.DB.Transaction(func(tx *gorm.DB) error {
+		var maxOrder int
+		if err := tx.Model(&models.Board{}).
+			Where("project_id = ?", board.ProjectID).
+			Select("COALESCE(MAX(order_index), 0)").
+			Scan(&maxOrder).Error; err != nil {
+			return err
+		}
+		if err := tx.Unscoped().Model(&models.Board{}).Where("id = ?", board.ID).
+			UpdateColumns(map[string]interface{}{"deleted_at": nil, "order_index": maxOrder + 1}).Error; err != nil {
+			return err
+		}
+		return restoreTasks(tx, since, "board_id = ?", board.ID)
+	})
+}
+
+// RestoreTask restores a task with the comments deleted along with it
+func (r *TrashRepository) RestoreTask(task *models.Task) error {
+	return r.DB.Transaction(func(tx *gorm.DB) error {
+		return restoreTasks(tx, task.DeletedAt.Time, "id = ?", task.ID)
+	})
+}
+
+// RestoreComment restores a comment with the replies deleted along with it
+func (r *TrashRepository) RestoreComment(comment *models.Comment) error {
+	return restoreRows(r.DB, &models.Comment{}, comment.DeletedAt.Time, "id = ? OR parent_id = ?", comment.ID, comment.ID)
+}
+
+// softDeleteRows stamps the matching live rows with the given deletion time; a cascade uses
+// one timestamp for the whole subtree so that it can be restored as a unit
+func softDeleteRows(tx *gorm.DB, model interface{}, at time.Time, query string, args ...interface{}) error {
+	return tx.Unscoped().Model(model).
+		Where(query, args...).
+		Where("deleted_at IS NULL").
+		UpdateColumn("deleted_at", at).Error
+}
+
+// softDeleteTasks soft-deletes the matching tasks together with their comments and notifications
+func softDeleteTasks(tx *gorm.DB, at time.Time, query string, args ...interface{}) error {
+	taskIDs := tx.Unscoped().Model(&models.Task{}).Select("id").Where(query, args...)
+	if err := softDeleteRows(tx, &models.Comment{}, at, "task_id IN (?)", taskIDs); err != nil {
+		return err
+	}
+	if err := softDeleteRows(tx, &models.Notification{}, at, "reference_type = ? AND related_id IN (?)", "task", taskIDs); err != nil {
+		return err
+	}
+	return softDeleteRows(tx, &models.Task{}, at, query, args...)
+}
+
+// restoreRows clears deleted_at on the matching rows deleted at or after since
+func restoreRows(tx *gorm.DB, model interface{}, since time.Time, query string, args ...interface{}) error {
+	return tx.Unscoped().Model(model).
+		Where(query, args...).
+		Where("deleted_at >= ?", since).
+		UpdateColumn("deleted_at", nil).Error
+}
+
+// restoreTasks restores the matching tasks with the comments and notifications deleted along with them
+func restoreTasks(tx *gorm.DB, since time.Time, query string, args ...interface{}) error {
+	taskIDs := tx.Unscoped().Model(&models.Task{}).Select("id").Where(query, args...)
+	if err := restoreRows(tx, &models.Comment{}, since, "task_id IN (?)", taskIDs); err != nil {
+		return err
+	}
+	if err := restoreRows(tx, &models.Notification{}, since, "reference_type = ? AND related_id IN (?)", "task", taskIDs); err != nil {
+		return err
+	}
+	return restoreRows(tx, &models.Task{}, since, query, args...)
+}
+
+// FindExpiredIDs retrieves up to limit IDs of rows of the model soft-deleted before the cutoff
+func (r *TrashRepository) FindExpiredIDs(model interface{}, cutoff time.Time, limit int) ([]uuid.UUID, error) {
+	var ids []uuid.UUID
+	err := r.DB.Unscoped().Model(model).
+		Where("deleted_at IS NOT NULL AND deleted_at < ?", cutoff).
+		Order("deleted_at ASC").
+		Limit(limit).
+		Pluck("id", &ids).Error
+	return ids, err
+}
+
+// FindAttachmentURLs retrieves the file and thumbnail URLs of every version of the attachments
+// of the given tasks, boards or projects
+func (r *TrashRepository) FindAttachmentURLs(taskIDs, boardIDs, projectIDs []uuid.UUID) ([]string, error) {
+	query := r.DB.Model(&models.AttachmentVersion{}).
+		Joins("JOIN attachments ON attachments.id = attachment_versions.attachment_id").
+		Joins("JOIN tasks ON tasks.id = attachments.task_id").
+		Joins("JOIN boards ON boards.id = tasks.board_id")
+
+	switch {
+	case len(taskIDs) > 0:
+		query = query.Where("tasks.id IN ?", taskIDs)
+	case len(boardIDs) > 0:
+		query = query.Where("boards.id IN ?", boardIDs)
+	case len(projectIDs) > 0:
+		query = query.Where("boards.project_id IN ?", projectIDs)
+	default:
+		return nil, nil
+	}
+
+	var rows []models.AttachmentVersion
+	if err := query.Select("attachment_versions.file_url, attachment_versions.thumbnail_url").Find(&rows).Error; err != nil {
+		return nil, err
+	}
+
+	var urls []string
+	for _, row := range rows {
+		urls = append(urls, row.FileUrl)
+		if row.ThumbnailUrl != nil {
+			urls = append(urls, *row.ThumbnailUrl)
+		}
+	}
+	return urls, nil
+}
+
+// FindCommentAttachmentURLs retrieves the file and thumbnail URLs of every version of the
+// attachments of the given comments and their replies
+func (r *TrashRepository) FindCommentAttachmentURLs(commentIDs []uuid.UUID) ([]string, error) {
+	var rows []models.AttachmentVersion
+	err := r.DB.Model(&models.AttachmentVersion{}).
+		Joins("JOIN attachments ON attachments.id = attachment_versions.attachment_id").
+		Where("attachments.comment_id IN ? OR attachments.comment_id IN (SELECT id FROM comments WHERE parent_id IN ?)", commentIDs, commentIDs).
+		Select("attachment_versions.file_url, attachment_versions.thumbnail_url").
+		Find(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+
+	var urls []string
+	for _, row := range rows {
+		urls = append(urls, row.FileUrl)
+		if row.ThumbnailUrl != nil {
+			urls = append(urls, *row.ThumbnailUrl)
+		}
+	}
+	return urls, nil
+}
+
+// PurgeRows permanently deletes rows of a model without stored files, such as notifications,
+// that were soft-deleted before the cutoff
+func (r *TrashRepository) PurgeRows(model interface{}, cutoff time.Time) error {
+	return r.DB.Unscoped().
+		Where("deleted_at IS NOT NULL AND deleted_at < ?", cutoff).
+		Delete(model).Error
+}
+
+// PurgeComments permanently deletes comments; their replies and attachments cascade
+func (r *TrashRepository) PurgeComments(ids []uuid.UUID) error {
+	return r.DB.Unscoped().Where("id IN ?", ids).Delete(&models.Comment{}).Error
+}
+
+// PurgeTasks permanently deletes tasks; their comments, attachments and other children cascade
+func (r *TrashRepository) PurgeTasks(ids []uuid.UUID) error {
+	return r.DB.Unscoped().Where("id IN ?", ids).Delete(&models.Task{}).Error
+}
+
+// PurgeBoards permanently deletes boards together with all of their tasks
+func (r *TrashRepository) PurgeBoards(ids []uuid.UUID) error {
+	return r.DB.Unscoped().Where("id IN ?", ids).Delete(&models.Board{}).Error
+}
+
+// PurgeProjects permanently deletes projects with everything they contain; rows referencing
+// a project without a cascading foreign key are removed first
+func (r *TrashRepository) PurgeProjects(ids []uuid.UUID) error {
+	return r.DB.Transaction(func(tx *gorm.DB) error {
+		for _, model := range []interface{}{&models.ProjectMember{}, &models.Invitation{}, &models.ActivityLog{}} {
+			if err := tx.Unscoped().Where("project_id IN ?", ids).Delete(model).Error; err != nil {
+				return err
+			}
+		}
+		return tx.Unscoped().Where("id IN ?", ids).Delete(&models.Project{}).Error
+	})
+}
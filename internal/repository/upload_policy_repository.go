@@ -0,0 +1,95 @@
+package repository
+
+import (
+	"github.com/Hann-arc/task-management-backend/internal/models"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type UploadPolicyRepository struct {
+	DB *gorm.DB
+}
+
+// NewUploadPolicyRepository creates a new instance of UploadPolicyRepository
+func NewUploadPolicyRepository(db *gorm.DB) *UploadPolicyRepository {
+	return &UploadPolicyRepository{DB: db}
+}
+
+// FindByProjectID retrieves the upload policy of a project
+func (r *UploadPolicyRepository) FindByProjectID(projectID uuid.UUID) (*models.ProjectUploadPolicy, error) {
+	var policy models.ProjectUploadPolicy
+	err := r.DB.Where("project_id = ?", projectID).First(&policy).Error
+	return &policy, err
+}
+
+// Save creates or replaces the upload policy of a project
+func (r *UploadPolicyRepository) Save(policy *models.ProjectUploadPolicy) error {
+	return r.DB.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "project_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"max_file_size", "storage_quota", "allowed_mime_types", "denied_mime_types", "updated_by", "updated_at"}),
+	}).Create(policy).Error
+}
+
+// StorageUsage holds the attachment totals of a project
+type StorageUsage struct {
+	UsedBytes       int64
+	AttachmentCount int64
+}
+
+// GetStorageUsage sums every stored version of the attachments of a project, including those
+// of deleted tasks whose files are kept until the trash is purged
+func (r *UploadPolicyRepository) GetStorageUsage(projectID uuid.UUID) (*StorageUsage, error) {
+	var usage StorageUsage
+	err := storageUsageQuery(r.DB, projectID).
+		Select("COALESCE(SUM(attachment_versions.file_size), 0) AS used_bytes, COUNT(DISTINCT attachments.id) AS attachment_count").
+		Scan(&usage).Error
+	return &usage, err
+}
+
+// storageUsageQuery selects the stored attachment versions of a project
+func storageUsageQuery(db *gorm.DB, projectID uuid.UUID) *gorm.DB {
+	return db.Model(&models.AttachmentVersion{}).
+		Joins("JOIN attachments ON attachments.id = attachment_versions.attachment_id").
+		Joins("JOIN tasks ON tasks.id = attachments.task_id").
+		Joins("JOIN boards ON boards.id = tasks.board_id").
+		Where("boards.project_id = ?", projectID)
+}
+
+// reserveStorage reports whether size more bytes fit in the quota of the project of a task. The
+// project row is locked until the transaction ends, so concurrent uploads are counted one after
+// the other; a quota of zero means unlimited
+func reserveStorage(tx *gorm.DB, taskID uuid.UUID, size, quota int64) (bool, error) {
+	if quota <= 0 {
+		return true, nil
+	}
+
+	var project models.Project
+	if err := tx.Clauses(clause.Locking{Strength: "UPDATE", Table: clause.Table{Name: "projects"}}).
+		Select("projects.id").
+		Joins("JOIN boards ON boards.project_id = projects.id").
+		Joins("JOIN tasks ON tasks.board_id = boards.id").
+		Where("tasks.id = ?", taskID).
+		First(&project).Error; err != nil {
+		return false, err
+	}
+
+	var used int64
+	if err := storageUsageQuery(tx, project.ID).
+		Select("COALESCE(SUM(attachment_versions.file_size), 0)").
+		Scan(&used).Error; err != nil {
+		return false, err
+	}
+	return used+size <= quota, nil
+}
+
+// FindTaskProjectID resolves the project a task belongs to
+func (r *UploadPolicyRepository) FindTaskProjectID(taskID uuid.UUID) (uuid.UUID, error) {
+	var board models.Board
+	err := r.DB.Model(&models.Board{}).
+		Select("boards.project_id").
+		Joins("JOIN tasks ON tasks.board_id = boards.id").
+		Where("tasks.id = ?", taskID).
+		First(&board).Error
+	return board.ProjectID, err
+}
@@ -0,0 +1,23 @@
+package routes
+
+import (
+	"github.com/Hann-arc/task-management-backend/config"
+	"github.com/Hann-arc/task-management-backend/internal/handlers"
+	"github.com/Hann-arc/task-management-backend/internal/middlewares"
+	"github.com/Hann-arc/task-management-backend/internal/repository"
+	"github.com/Hann-arc/task-management-backend/internal/services"
+	"github.com/gofiber/fiber/v2"
+)
+
+// AnalyticsRoutes sets up the routes for project and sprint analytics
+func AnalyticsRoutes(router fiber.Router) {
+	analyticsRepo := repository.NewAnalyticsRepository(config.DB)
+	sprintRepo := repository.NewSprintRepository(config.DB)
+	projectRepo := repository.NewProjectRepository(config.DB)
+
+	analyticsService := services.NewAnalyticsService(analyticsRepo, sprintRepo, projectRepo)
+	analyticsHandler := handlers.NewAnalyticsHandler(analyticsService)
+
+	router.Get("/projects/:projectId/analytics", middlewares.AuthMiddleware, analyticsHandler.GetProjectAnalytics)
+	router.Get("/sprints/:id/analytics", middlewares.AuthMiddleware, analyticsHandler.GetSprintAnalytics)
+}
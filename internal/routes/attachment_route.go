@@ -12,11 +12,32 @@ import (
 // AttachmentRoutes sets up the routes for attachment operations
 func AttachmentRoutes(router fiber.Router) {
 	attachmentRepo := repository.NewAttachmentRepository(config.DB)
-	attachmentService := services.NewAttachmentService(attachmentRepo)
+	taskRepo := repository.NewTaskRepository(config.DB)
+	projectRepo := repository.NewProjectRepository(config.DB)
+	uploadPolicyRepo := repository.NewUploadPolicyRepository(config.DB)
+	notificationRepo := repository.NewNotificationRepository(config.DB)
+	activityLogRepo := repository.NewActivityLogRepository(config.DB)
+
+	notificationService := services.NewNotificationService(notificationRepo)
+	activityLogService := services.NewActivityLogService(activityLogRepo)
+	uploadPolicyService := services.NewUploadPolicyService(uploadPolicyRepo, projectRepo, activityLogService)
+	attachmentService := services.NewAttachmentService(attachmentRepo, taskRepo, config.Storage, uploadPolicyService, config.Scanner, notificationService, activityLogService)
 	attachmentHandler := handlers.NewAttachmentHandler(attachmentService)
+	uploadPolicyHandler := handlers.NewUploadPolicyHandler(uploadPolicyService)
 
 	attachmentRoutes := router.Group("/tasks/:taskId/attachments", middlewares.AuthMiddleware)
 	attachmentRoutes.Post("/", attachmentHandler.UploadAttachment)
 	attachmentRoutes.Get("/", attachmentHandler.GetAttachments)
 	attachmentRoutes.Delete("/:id", attachmentHandler.DeleteAttachment)
+
+	router.Get("/attachments/:id/download", middlewares.SignedOrAuthMiddleware, attachmentHandler.DownloadAttachment)
+	router.Get("/attachments/:id/thumbnail", middlewares.SignedOrAuthMiddleware, attachmentHandler.DownloadThumbnail)
+
+	router.Post("/attachments/:id/versions", middlewares.AuthMiddleware, attachmentHandler.UploadVersion)
+	router.Get("/attachments/:id/versions", middlewares.AuthMiddleware, attachmentHandler.GetVersions)
+	router.Get("/attachments/:id/versions/:version/download", middlewares.SignedOrAuthMiddleware, attachmentHandler.DownloadVersion)
+	router.Delete("/attachments/:id/versions/:version", middlewares.AuthMiddleware, attachmentHandler.DeleteVersion)
+
+	router.Get("/projects/:projectId/upload-policy", middlewares.AuthMiddleware, uploadPolicyHandler.GetPolicy)
+	router.Patch("/projects/:projectId/upload-policy", middlewares.AuthMiddleware, uploadPolicyHandler.UpdatePolicy)
 }
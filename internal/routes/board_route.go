@@ -24,4 +24,6 @@ func BoardRouter(router fiber.Router) {
 	boardRoutes.Post("/", boardHandler.CreateBoard)
 	boardRoutes.Patch("/:boardId", boardHandler.UpdateBoard)
 	boardRoutes.Delete("/:boardId", boardHandler.DeleteBoard)
+	boardRoutes.Post("/:boardId/archive", boardHandler.ArchiveBoard)
+	boardRoutes.Post("/:boardId/unarchive", boardHandler.UnarchiveBoard)
 }
@@ -15,17 +15,27 @@ func CommentRoute(router fiber.Router) {
 	taskRepo := repository.NewTaskRepository(config.DB)
 	activityLogRepo := repository.NewActivityLogRepository(config.DB)
 	notificationRepo := repository.NewNotificationRepository(config.DB)
+	mentionRepo := repository.NewMentionRepository(config.DB)
+	attachmentRepo := repository.NewAttachmentRepository(config.DB)
+	projectRepo := repository.NewProjectRepository(config.DB)
+	uploadPolicyRepo := repository.NewUploadPolicyRepository(config.DB)
 
 	notificationService := services.NewNotificationService(notificationRepo)
+	mentionService := services.NewMentionService(mentionRepo, notificationService)
 	activityLogService := services.NewActivityLogService(activityLogRepo)
-	commentService := services.NewCommentService(commentRepo, taskRepo, activityLogService, notificationService)
+	uploadPolicyService := services.NewUploadPolicyService(uploadPolicyRepo, projectRepo, activityLogService)
+	attachmentService := services.NewAttachmentService(attachmentRepo, taskRepo, config.Storage, uploadPolicyService, config.Scanner, notificationService, activityLogService)
+	commentService := services.NewCommentService(commentRepo, taskRepo, activityLogService, notificationService, attachmentService, mentionService)
 	commentHandler := handlers.NewCommentHandler(commentService)
 
 	commentRoutes := router.Group("/tasks/:taskId/comments", middlewares.AuthMiddleware)
 	commentRoutes.Post("/", commentHandler.CreateMainComment)
 	commentRoutes.Get("/", commentHandler.GetComments)
+	commentRoutes.Patch("/:id", commentHandler.UpdateComment)
+	commentRoutes.Get("/:id/history", commentHandler.GetCommentHistory)
 	commentRoutes.Delete("/:id", commentHandler.DeleteComment)
 
 	replyRoutes := router.Group("/comments", middlewares.AuthMiddleware)
 	replyRoutes.Post("/:commentId/replies", commentHandler.CreateReply)
+	replyRoutes.Post("/:commentId/attachments", commentHandler.AddAttachments)
 }
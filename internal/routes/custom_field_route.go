@@ -0,0 +1,28 @@
+package routes
+
+import (
+	"github.com/Hann-arc/task-management-backend/config"
+	"github.com/Hann-arc/task-management-backend/internal/handlers"
+	"github.com/Hann-arc/task-management-backend/internal/middlewares"
+	"github.com/Hann-arc/task-management-backend/internal/repository"
+	"github.com/Hann-arc/task-management-backend/internal/services"
+	"github.com/gofiber/fiber/v2"
+)
+
+// CustomFieldRoutes sets up the routes for project custom field definitions
+func CustomFieldRoutes(router fiber.Router) {
+	customFieldRepo := repository.NewCustomFieldRepository(config.DB)
+	projectRepo := repository.NewProjectRepository(config.DB)
+	activityLogRepo := repository.NewActivityLogRepository(config.DB)
+
+	activityLogService := services.NewActivityLogService(activityLogRepo)
+	customFieldService := services.NewCustomFieldService(customFieldRepo, projectRepo, activityLogService)
+	customFieldHandler := handlers.NewCustomFieldHandler(customFieldService)
+
+	router.Post("/projects/:projectId/custom-fields", middlewares.AuthMiddleware, customFieldHandler.CreateField)
+	router.Get("/projects/:projectId/custom-fields", middlewares.AuthMiddleware, customFieldHandler.GetFields)
+
+	fieldRoutes := router.Group("/custom-fields", middlewares.AuthMiddleware)
+	fieldRoutes.Patch("/:id", customFieldHandler.UpdateField)
+	fieldRoutes.Delete("/:id", customFieldHandler.DeleteField)
+}
@@ -0,0 +1,20 @@
+package routes
+
+import (
+	"github.com/Hann-arc/task-management-backend/config"
+	"github.com/Hann-arc/task-management-backend/internal/handlers"
+	"github.com/Hann-arc/task-management-backend/internal/middlewares"
+	"github.com/Hann-arc/task-management-backend/internal/repository"
+	"github.com/Hann-arc/task-management-backend/internal/services"
+	"github.com/gofiber/fiber/v2"
+)
+
+// FileRoutes sets up the download route for files kept by the local storage driver
+func FileRoutes(router fiber.Router) {
+	attachmentRepo := repository.NewAttachmentRepository(config.DB)
+
+	fileService := services.NewFileService(attachmentRepo, config.Storage)
+	fileHandler := handlers.NewFileHandler(fileService)
+
+	router.Get("/files/*", middlewares.AuthMiddleware, fileHandler.DownloadFile)
+}
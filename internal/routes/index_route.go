@@ -16,4 +16,13 @@ func MainRoutes(app *fiber.App) {
 	ActivityLogRoutes(api)
 	AttachmentRoutes(api)
 	NotificationRoutes(api)
+	SearchRoutes(api)
+	TaskRecurrenceRoutes(api)
+	TimeEntryRoutes(api)
+	SprintRoutes(api)
+	AnalyticsRoutes(api)
+	CustomFieldRoutes(api)
+	TaskTemplateRoutes(api)
+	TrashRoutes(api)
+	FileRoutes(api)
 }
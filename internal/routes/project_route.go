@@ -23,6 +23,13 @@ func ProjectRoutes(router fiber.Router) {
 	projectRoute.Post("/", projectHandler.CreateProject)
 	projectRoute.Get("/", projectHandler.ListProjects)
 	projectRoute.Get("/:id", projectHandler.GetProject)
+	projectRoute.Get("/:id/stats", projectHandler.GetProjectStats)
+	projectRoute.Post("/:id/duplicate", projectHandler.DuplicateProject)
+	projectRoute.Post("/:id/save-as-template", projectHandler.SaveAsTemplate)
+	projectRoute.Post("/:id/archive", projectHandler.ArchiveProject)
+	projectRoute.Post("/:id/unarchive", projectHandler.UnarchiveProject)
 	projectRoute.Patch("/:id", projectHandler.UpdateProject)
 	projectRoute.Delete("/:id", projectHandler.DeleteProject)
+
+	router.Get("/project-templates", middlewares.AuthMiddleware, projectHandler.ListTemplates)
 }
@@ -0,0 +1,20 @@
+package routes
+
+import (
+	"github.com/Hann-arc/task-management-backend/config"
+	"github.com/Hann-arc/task-management-backend/internal/handlers"
+	"github.com/Hann-arc/task-management-backend/internal/middlewares"
+	"github.com/Hann-arc/task-management-backend/internal/repository"
+	"github.com/Hann-arc/task-management-backend/internal/services"
+	"github.com/gofiber/fiber/v2"
+)
+
+// SearchRoutes sets up the routes for full-text search
+func SearchRoutes(router fiber.Router) {
+	searchRepo := repository.NewSearchRepository(config.DB)
+	searchService := services.NewSearchService(searchRepo)
+	searchHandler := handlers.NewSearchHandler(searchService)
+
+	searchRoutes := router.Group("/search", middlewares.AuthMiddleware)
+	searchRoutes.Get("/", searchHandler.Search)
+}
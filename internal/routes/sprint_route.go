@@ -0,0 +1,44 @@
+package routes
+
+import (
+	"github.com/Hann-arc/task-management-backend/config"
+	"github.com/Hann-arc/task-management-backend/internal/handlers"
+	"github.com/Hann-arc/task-management-backend/internal/middlewares"
+	"github.com/Hann-arc/task-management-backend/internal/repository"
+	"github.com/Hann-arc/task-management-backend/internal/services"
+	"github.com/gofiber/fiber/v2"
+)
+
+// SprintRoutes sets up the routes for sprint operations
+func SprintRoutes(router fiber.Router) {
+	sprintRepo := repository.NewSprintRepository(config.DB)
+	taskRepo := repository.NewTaskRepository(config.DB)
+	projectRepo := repository.NewProjectRepository(config.DB)
+	userRepo := repository.NewUserRepository(config.DB)
+	customFieldRepo := repository.NewCustomFieldRepository(config.DB)
+	activityLogRepo := repository.NewActivityLogRepository(config.DB)
+	notificationRepo := repository.NewNotificationRepository(config.DB)
+	mentionRepo := repository.NewMentionRepository(config.DB)
+
+	activityLogService := services.NewActivityLogService(activityLogRepo)
+	notificationService := services.NewNotificationService(notificationRepo)
+	mentionService := services.NewMentionService(mentionRepo, notificationService)
+	taskService := services.NewTaskService(taskRepo, projectRepo, userRepo, customFieldRepo, activityLogService, notificationService, mentionService)
+	sprintService := services.NewSprintService(sprintRepo, taskRepo, projectRepo, taskService, activityLogService)
+	sprintHandler := handlers.NewSprintHandler(sprintService)
+
+	projectSprintRoutes := router.Group("/projects/:projectId", middlewares.AuthMiddleware)
+	projectSprintRoutes.Post("/sprints", sprintHandler.CreateSprint)
+	projectSprintRoutes.Get("/sprints", sprintHandler.GetSprints)
+	projectSprintRoutes.Get("/velocity", sprintHandler.GetVelocity)
+
+	sprintRoutes := router.Group("/sprints", middlewares.AuthMiddleware)
+	sprintRoutes.Get("/:id", sprintHandler.GetSprint)
+	sprintRoutes.Patch("/:id", sprintHandler.UpdateSprint)
+	sprintRoutes.Delete("/:id", sprintHandler.DeleteSprint)
+	sprintRoutes.Get("/:id/board", sprintHandler.GetSprintBoard)
+	sprintRoutes.Post("/:id/start", sprintHandler.StartSprint)
+	sprintRoutes.Post("/:id/close", sprintHandler.CloseSprint)
+	sprintRoutes.Post("/:id/tasks", sprintHandler.AddTasks)
+	sprintRoutes.Delete("/:id/tasks/:taskId", sprintHandler.RemoveTask)
+}
@@ -0,0 +1,29 @@
+package routes
+
+import (
+	"github.com/Hann-arc/task-management-backend/config"
+	"github.com/Hann-arc/task-management-backend/internal/handlers"
+	"github.com/Hann-arc/task-management-backend/internal/middlewares"
+	"github.com/Hann-arc/task-management-backend/internal/repository"
+	"github.com/Hann-arc/task-management-backend/internal/services"
+	"github.com/gofiber/fiber/v2"
+)
+
+// TaskRecurrenceRoutes sets up the routes for recurring task operations
+func TaskRecurrenceRoutes(router fiber.Router) {
+	recurrenceRepo := repository.NewTaskRecurrenceRepository(config.DB)
+	taskRepo := repository.NewTaskRepository(config.DB)
+	projectRepo := repository.NewProjectRepository(config.DB)
+	activityLogRepo := repository.NewActivityLogRepository(config.DB)
+	notificationRepo := repository.NewNotificationRepository(config.DB)
+
+	activityLogService := services.NewActivityLogService(activityLogRepo)
+	notificationService := services.NewNotificationService(notificationRepo)
+	recurrenceService := services.NewTaskRecurrenceService(recurrenceRepo, taskRepo, projectRepo, activityLogService, notificationService)
+	recurrenceHandler := handlers.NewTaskRecurrenceHandler(recurrenceService)
+
+	recurrenceRoutes := router.Group("/tasks/:taskId/recurrence", middlewares.AuthMiddleware)
+	recurrenceRoutes.Put("/", recurrenceHandler.SetRecurrence)
+	recurrenceRoutes.Get("/", recurrenceHandler.GetRecurrence)
+	recurrenceRoutes.Delete("/", recurrenceHandler.DeleteRecurrence)
+}
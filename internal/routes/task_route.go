@@ -14,12 +14,15 @@ func TaskRouter(router fiber.Router) {
 	taskRepo := repository.NewTaskRepository(config.DB)
 	projectRepo := repository.NewProjectRepository(config.DB)
 	userRepo := repository.NewUserRepository(config.DB)
+	customFieldRepo := repository.NewCustomFieldRepository(config.DB)
 	notificationRepo := repository.NewNotificationRepository(config.DB)
+	mentionRepo := repository.NewMentionRepository(config.DB)
 
 	notificationService := services.NewNotificationService(notificationRepo)
+	mentionService := services.NewMentionService(mentionRepo, notificationService)
 	activityLogRepo := repository.NewActivityLogRepository(config.DB)
 	activityLogService := services.NewActivityLogService(activityLogRepo)
-	taskService := services.NewTaskService(taskRepo, projectRepo, userRepo, activityLogService, notificationService)
+	taskService := services.NewTaskService(taskRepo, projectRepo, userRepo, customFieldRepo, activityLogService, notificationService, mentionService)
 	taskHandler := handlers.NewTaskHandler(taskService)
 
 	taskRoutes := router.Group("/boards/:boardId/tasks", middlewares.AuthMiddleware)
@@ -30,4 +33,11 @@ func TaskRouter(router fiber.Router) {
 	taskRoutes.Patch("/:id", taskHandler.UpdateTask)
 	taskRoutes.Delete("/:id", taskHandler.DeleteTask)
 
+	taskRoutes.Post("/:id/watchers", taskHandler.WatchTask)
+	taskRoutes.Delete("/:id/watchers", taskHandler.UnwatchTask)
+
+	projectTaskRoutes := router.Group("/projects/:projectId/tasks", middlewares.AuthMiddleware)
+	projectTaskRoutes.Get("/", taskHandler.GetTasksByProject)
+
+	router.Get("/users/me/tasks", middlewares.AuthMiddleware, taskHandler.GetMyTasks)
 }
@@ -0,0 +1,40 @@
+package routes
+
+import (
+	"github.com/Hann-arc/task-management-backend/config"
+	"github.com/Hann-arc/task-management-backend/internal/handlers"
+	"github.com/Hann-arc/task-management-backend/internal/middlewares"
+	"github.com/Hann-arc/task-management-backend/internal/repository"
+	"github.com/Hann-arc/task-management-backend/internal/services"
+	"github.com/gofiber/fiber/v2"
+)
+
+// TaskTemplateRoutes sets up the routes for task templates and creating tasks from them
+func TaskTemplateRoutes(router fiber.Router) {
+	taskTemplateRepo := repository.NewTaskTemplateRepository(config.DB)
+	taskRepo := repository.NewTaskRepository(config.DB)
+	boardRepo := repository.NewBoardRepository(config.DB)
+	projectRepo := repository.NewProjectRepository(config.DB)
+	userRepo := repository.NewUserRepository(config.DB)
+	customFieldRepo := repository.NewCustomFieldRepository(config.DB)
+	activityLogRepo := repository.NewActivityLogRepository(config.DB)
+	notificationRepo := repository.NewNotificationRepository(config.DB)
+	mentionRepo := repository.NewMentionRepository(config.DB)
+
+	activityLogService := services.NewActivityLogService(activityLogRepo)
+	notificationService := services.NewNotificationService(notificationRepo)
+	mentionService := services.NewMentionService(mentionRepo, notificationService)
+	taskService := services.NewTaskService(taskRepo, projectRepo, userRepo, customFieldRepo, activityLogService, notificationService, mentionService)
+	taskTemplateService := services.NewTaskTemplateService(taskTemplateRepo, boardRepo, projectRepo, userRepo, taskService, activityLogService)
+	taskTemplateHandler := handlers.NewTaskTemplateHandler(taskTemplateService)
+
+	router.Post("/projects/:projectId/task-templates", middlewares.AuthMiddleware, taskTemplateHandler.CreateTemplate)
+	router.Get("/projects/:projectId/task-templates", middlewares.AuthMiddleware, taskTemplateHandler.GetTemplates)
+
+	templateRoutes := router.Group("/task-templates", middlewares.AuthMiddleware)
+	templateRoutes.Get("/:id", taskTemplateHandler.GetTemplate)
+	templateRoutes.Patch("/:id", taskTemplateHandler.UpdateTemplate)
+	templateRoutes.Delete("/:id", taskTemplateHandler.DeleteTemplate)
+
+	router.Post("/boards/:boardId/tasks/from-template", middlewares.AuthMiddleware, taskTemplateHandler.CreateTaskFromTemplate)
+}
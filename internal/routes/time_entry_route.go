@@ -0,0 +1,36 @@
+package routes
+
+import (
+	"github.com/Hann-arc/task-management-backend/config"
+	"github.com/Hann-arc/task-management-backend/internal/handlers"
+	"github.com/Hann-arc/task-management-backend/internal/middlewares"
+	"github.com/Hann-arc/task-management-backend/internal/repository"
+	"github.com/Hann-arc/task-management-backend/internal/services"
+	"github.com/gofiber/fiber/v2"
+)
+
+// TimeEntryRoutes sets up the routes for time tracking operations
+func TimeEntryRoutes(router fiber.Router) {
+	timeEntryRepo := repository.NewTimeEntryRepository(config.DB)
+	taskRepo := repository.NewTaskRepository(config.DB)
+	projectRepo := repository.NewProjectRepository(config.DB)
+	activityLogRepo := repository.NewActivityLogRepository(config.DB)
+
+	activityLogService := services.NewActivityLogService(activityLogRepo)
+	timeEntryService := services.NewTimeEntryService(timeEntryRepo, taskRepo, projectRepo, activityLogService)
+	timeEntryHandler := handlers.NewTimeEntryHandler(timeEntryService)
+
+	taskTimeRoutes := router.Group("/tasks/:taskId", middlewares.AuthMiddleware)
+	taskTimeRoutes.Post("/time-entries", timeEntryHandler.CreateTimeEntry)
+	taskTimeRoutes.Get("/time-entries", timeEntryHandler.GetTaskTimeEntries)
+	taskTimeRoutes.Post("/timer/start", timeEntryHandler.StartTimer)
+
+	router.Delete("/time-entries/:id", middlewares.AuthMiddleware, timeEntryHandler.DeleteTimeEntry)
+
+	timerRoutes := router.Group("/users/me", middlewares.AuthMiddleware)
+	timerRoutes.Get("/timer", timeEntryHandler.GetRunningTimer)
+	timerRoutes.Post("/timer/stop", timeEntryHandler.StopTimer)
+	timerRoutes.Get("/time-report", timeEntryHandler.GetUserReport)
+
+	router.Get("/projects/:projectId/time-report", middlewares.AuthMiddleware, timeEntryHandler.GetProjectReport)
+}
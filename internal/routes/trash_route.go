@@ -0,0 +1,28 @@
+package routes
+
+import (
+	"github.com/Hann-arc/task-management-backend/config"
+	"github.com/Hann-arc/task-management-backend/internal/handlers"
+	"github.com/Hann-arc/task-management-backend/internal/middlewares"
+	"github.com/Hann-arc/task-management-backend/internal/repository"
+	"github.com/Hann-arc/task-management-backend/internal/services"
+	"github.com/gofiber/fiber/v2"
+)
+
+// TrashRoutes sets up the routes for listing and restoring deleted items
+func TrashRoutes(router fiber.Router) {
+	trashRepo := repository.NewTrashRepository(config.DB)
+	projectRepo := repository.NewProjectRepository(config.DB)
+	activityLogRepo := repository.NewActivityLogRepository(config.DB)
+
+	activityLogService := services.NewActivityLogService(activityLogRepo)
+	trashService := services.NewTrashService(trashRepo, projectRepo, config.Storage, activityLogService)
+	trashHandler := handlers.NewTrashHandler(trashService)
+
+	router.Get("/projects/:projectId/trash", middlewares.AuthMiddleware, trashHandler.GetProjectTrash)
+	router.Get("/trash/projects", middlewares.AuthMiddleware, trashHandler.GetDeletedProjects)
+	router.Post("/trash/projects/:id/restore", middlewares.AuthMiddleware, trashHandler.RestoreProject)
+	router.Post("/trash/boards/:id/restore", middlewares.AuthMiddleware, trashHandler.RestoreBoard)
+	router.Post("/trash/tasks/:id/restore", middlewares.AuthMiddleware, trashHandler.RestoreTask)
+	router.Post("/trash/comments/:id/restore", middlewares.AuthMiddleware, trashHandler.RestoreComment)
+}
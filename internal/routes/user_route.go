@@ -12,7 +12,7 @@ import (
 // UserRoutes sets up user-related routes
 func UserRoutes(router fiber.Router) {
 	userRepo := repository.NewUserRepository(config.DB)
-	userService := services.NewUserService(userRepo)
+	userService := services.NewUserService(userRepo, config.Storage)
 	userHandler := handlers.NewUserHandler(userService)
 
 	usersRoute := router.Group("/users", middlewares.AuthMiddleware)
@@ -0,0 +1,15 @@
+package scanner
+
+import "io"
+
+// Result is the verdict of a malware scan
+type Result struct {
+	Infected bool
+	// Signature names the detected threat when Infected is set
+	Signature string
+}
+
+// Scanner checks uploaded content for malware before it is stored
+type Scanner interface {
+	Scan(content io.Reader) (*Result, error)
+}
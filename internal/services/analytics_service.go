@@ -0,0 +1,401 @@
+package services
+
+import (
+	"errors"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/Hann-arc/task-management-backend/internal/dto"
+	apperrors "github.com/Hann-arc/task-management-backend/internal/errors"
+	"github.com/Hann-arc/task-management-backend/internal/models"
+	"github.com/Hann-arc/task-management-backend/internal/repository"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// maxAnalyticsDays bounds the number of daily data points a single request can produce
+const maxAnalyticsDays = 366
+
+type AnalyticsService struct {
+	AnalyticsRepo *repository.AnalyticsRepository
+	SprintRepo    *repository.SprintRepository
+	ProjectRepo   *repository.ProjectRepository
+}
+
+// NewAnalyticsService creates a new instance of AnalyticsService
+func NewAnalyticsService(
+	analyticsRepo *repository.AnalyticsRepository,
+	sprintRepo *repository.SprintRepository,
+	projectRepo *repository.ProjectRepository,
+) *AnalyticsService {
+	return &AnalyticsService{
+		AnalyticsRepo: analyticsRepo,
+		SprintRepo:    sprintRepo,
+		ProjectRepo:   projectRepo,
+	}
+}
+
+// statusChange is a point in time at which a task entered a status
+type statusChange struct {
+	at     time.Time
+	status string
+}
+
+// taskTimeline is the reconstructed status history of a single task
+type taskTimeline struct {
+	weight    int
+	createdAt time.Time
+	deletedAt *time.Time
+	changes   []statusChange
+	startedAt *time.Time
+	doneAt    *time.Time
+}
+
+// GetProjectAnalytics computes burndown, cumulative flow, cycle time and lead time for a project.
+// The range defaults to the last 30 days.
+func (s *AnalyticsService) GetProjectAnalytics(projectID, userID uuid.UUID, query *dto.AnalyticsQuery) (*dto.AnalyticsResponse, error) {
+	if err := s.checkMember(projectID, userID); err != nil {
+		return nil, err
+	}
+
+	loc, unit, err := parseAnalyticsOptions(query)
+	if err != nil {
+		return nil, err
+	}
+
+	today := startOfDayIn(time.Now(), loc)
+	from, to, err := parseAnalyticsRange(query, loc, today.AddDate(0, 0, -29), today)
+	if err != nil {
+		return nil, err
+	}
+
+	tasks, err := s.AnalyticsRepo.FindProjectTasks(projectID, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.buildAnalytics(projectID, tasks, unit, from, to, false)
+}
+
+// GetSprintAnalytics computes the same metrics for the tasks planned into a sprint, over the
+// sprint's dates unless a range is given, with an ideal burndown line. Tasks rolled over into
+// another sprint when it closed belong to that sprint and are not part of its scope anymore.
+func (s *AnalyticsService) GetSprintAnalytics(sprintID, userID uuid.UUID, query *dto.AnalyticsQuery) (*dto.AnalyticsResponse, error) {
+	sprint, err := s.SprintRepo.FindByID(sprintID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, apperrors.ErrSprintNotFound
+		}
+		return nil, err
+	}
+	if err := s.checkMember(sprint.ProjectID, userID); err != nil {
+		return nil, err
+	}
+
+	if query.Unit == "" {
+		query.Unit = "points"
+	}
+	loc, unit, err := parseAnalyticsOptions(query)
+	if err != nil {
+		return nil, err
+	}
+
+	from, to, err := parseAnalyticsRange(query, loc, startOfDayIn(sprint.StartDate, loc), startOfDayIn(sprint.EndDate, loc))
+	if err != nil {
+		return nil, err
+	}
+
+	tasks, err := s.AnalyticsRepo.FindProjectTasks(sprint.ProjectID, &sprintID)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := s.buildAnalytics(sprint.ProjectID, tasks, unit, from, to, true)
+	if err != nil {
+		return nil, err
+	}
+	idStr := sprintID.String()
+	resp.SprintID = &idStr
+	return resp, nil
+}
+
+// buildAnalytics reconstructs each task's status history and samples it at the end of every day
+func (s *AnalyticsService) buildAnalytics(projectID uuid.UUID, tasks []models.Task, unit string, from, to time.Time, withIdeal bool) (*dto.AnalyticsResponse, error) {
+	events, err := s.AnalyticsRepo.FindStatusEvents(projectID)
+	if err != nil {
+		return nil, err
+	}
+
+	eventsByTask := map[uuid.UUID][]repository.TaskStatusEvent{}
+	for _, e := range events {
+		eventsByTask[e.TaskID] = append(eventsByTask[e.TaskID], e)
+	}
+
+	var timelines []taskTimeline
+	for _, task := range tasks {
+		timelines = append(timelines, buildTaskTimeline(&task, eventsByTask[task.ID], unit))
+	}
+
+	resp := &dto.AnalyticsResponse{
+		ProjectID:      projectID.String(),
+		From:           from,
+		To:             to,
+		Unit:           unit,
+		Burndown:       []dto.BurndownPoint{},
+		CumulativeFlow: []dto.CumulativeFlowPoint{},
+	}
+
+	now := time.Now()
+	totalDays := int(math.Round(to.Sub(from).Hours()/24)) + 1
+	var days []time.Time
+	for day := from; !day.After(to) && day.Before(now); day = day.AddDate(0, 0, 1) {
+		days = append(days, day)
+	}
+
+	for i, day := range days {
+		at := day.AddDate(0, 0, 1)
+		if at.After(now) {
+			at = now
+		}
+
+		point := dto.BurndownPoint{Date: day.Format("2006-01-02")}
+		flow := dto.CumulativeFlowPoint{Date: point.Date}
+		for _, t := range timelines {
+			status, ok := t.statusAt(at)
+			if !ok {
+				continue
+			}
+			point.Total += t.weight
+			switch status {
+			case models.TaskStatusDone:
+				point.Completed += t.weight
+				flow.Done += t.weight
+			case models.TaskStatusInProgress:
+				flow.InProgress += t.weight
+			default:
+				flow.Todo += t.weight
+			}
+		}
+		point.Remaining = point.Total - point.Completed
+
+		// the ideal line burns the scope of the first day down to zero on the last day
+		if withIdeal {
+			start := float64(point.Total)
+			if len(resp.Burndown) > 0 {
+				start = float64(resp.Burndown[0].Total)
+			}
+			ideal := start
+			if totalDays > 1 {
+				ideal = roundTwoDecimals(start - start*float64(i)/float64(totalDays-1))
+			}
+			point.Ideal = &ideal
+		}
+
+		resp.Burndown = append(resp.Burndown, point)
+		resp.CumulativeFlow = append(resp.CumulativeFlow, flow)
+	}
+
+	// cycle and lead time cover the tasks finished within the range
+	rangeEnd := to.AddDate(0, 0, 1)
+	var cycleHours, leadHours []float64
+	for _, t := range timelines {
+		if t.doneAt == nil || t.doneAt.Before(from) || !t.doneAt.Before(rangeEnd) {
+			continue
+		}
+		if t.deletedAt != nil {
+			continue
+		}
+		leadHours = append(leadHours, t.doneAt.Sub(t.createdAt).Hours())
+		if t.startedAt != nil && !t.startedAt.After(*t.doneAt) {
+			cycleHours = append(cycleHours, t.doneAt.Sub(*t.startedAt).Hours())
+		}
+	}
+	resp.CycleTime = buildDurationStats(cycleHours)
+	resp.LeadTime = buildDurationStats(leadHours)
+
+	return resp, nil
+}
+
+// buildTaskTimeline replays the logged status changes of a task. Tasks created before statuses
+// were logged have no history, so their current status is assumed to have been reached when
+// they were completed or last updated.
+func buildTaskTimeline(task *models.Task, events []repository.TaskStatusEvent, unit string) taskTimeline {
+	t := taskTimeline{weight: 1, createdAt: task.CreatedAt}
+	if unit == "points" {
+		t.weight = 0
+		if task.StoryPoints != nil {
+			t.weight = *task.StoryPoints
+		}
+	}
+	if task.DeletedAt.Valid {
+		deletedAt := task.DeletedAt.Time
+		t.deletedAt = &deletedAt
+	}
+
+	t.changes = []statusChange{{at: task.CreatedAt, status: models.TaskStatusTodo}}
+	for _, e := range events {
+		at := e.CreatedAt
+		if at.Before(task.CreatedAt) {
+			at = task.CreatedAt
+		}
+		t.addChange(at, e.Status)
+	}
+
+	if last := t.changes[len(t.changes)-1]; last.status != task.Status {
+		at := task.UpdatedAt
+		if task.Status == models.TaskStatusDone && task.CompletedAt != nil {
+			at = *task.CompletedAt
+		}
+		if at.Before(last.at) {
+			at = last.at
+		}
+		t.addChange(at, task.Status)
+	}
+
+	for _, c := range t.changes {
+		if c.status == models.TaskStatusInProgress && t.startedAt == nil {
+			at := c.at
+			t.startedAt = &at
+		}
+	}
+	if last := t.changes[len(t.changes)-1]; last.status == models.TaskStatusDone {
+		at := last.at
+		t.doneAt = &at
+	}
+
+	return t
+}
+
+// addChange appends a status change, collapsing changes made at the creation time
+// and repeated statuses
+func (t *taskTimeline) addChange(at time.Time, status string) {
+	last := &t.changes[len(t.changes)-1]
+	if status == last.status {
+		return
+	}
+	if at.Equal(last.at) {
+		last.status = status
+		return
+	}
+	t.changes = append(t.changes, statusChange{at: at, status: status})
+}
+
+// statusAt returns the status of the task at the given time, or false if it did not exist then
+func (t *taskTimeline) statusAt(at time.Time) (string, bool) {
+	if at.Before(t.createdAt) {
+		return "", false
+	}
+	if t.deletedAt != nil && !at.Before(*t.deletedAt) {
+		return "", false
+	}
+
+	status := t.changes[0].status
+	for _, c := range t.changes {
+		if c.at.After(at) {
+			break
+		}
+		status = c.status
+	}
+	return status, true
+}
+
+// buildDurationStats summarises a list of durations in hours
+func buildDurationStats(hours []float64) dto.DurationStats {
+	stats := dto.DurationStats{Count: len(hours)}
+	if len(hours) == 0 {
+		return stats
+	}
+
+	sort.Float64s(hours)
+	var sum float64
+	for _, h := range hours {
+		sum += h
+	}
+
+	stats.AverageHours = roundTwoDecimals(sum / float64(len(hours)))
+	stats.MedianHours = roundTwoDecimals(percentile(hours, 0.5))
+	stats.P85Hours = roundTwoDecimals(percentile(hours, 0.85))
+	return stats
+}
+
+// percentile returns the nearest-rank percentile of sorted values
+func percentile(sorted []float64, p float64) float64 {
+	rank := int(math.Ceil(p*float64(len(sorted)))) - 1
+	if rank < 0 {
+		rank = 0
+	}
+	return sorted[rank]
+}
+
+// roundTwoDecimals rounds a value to two decimals
+func roundTwoDecimals(h float64) float64 {
+	return math.Round(h*100) / 100
+}
+
+// checkMember ensures the user is the owner or a member of the project
+func (s *AnalyticsService) checkMember(projectID, userID uuid.UUID) error {
+	isMember, err := s.ProjectRepo.IsMember(projectID, userID)
+	if err != nil {
+		return err
+	}
+	isOwner, err := s.ProjectRepo.IsOwner(projectID, userID)
+	if err != nil {
+		return err
+	}
+	if !isMember && !isOwner {
+		return apperrors.ErrUnauthorizedProject
+	}
+	return nil
+}
+
+// parseAnalyticsOptions validates the time zone and unit of an analytics query
+func parseAnalyticsOptions(query *dto.AnalyticsQuery) (*time.Location, string, error) {
+	loc := time.UTC
+	if query.TimeZone != "" {
+		var err error
+		loc, err = time.LoadLocation(query.TimeZone)
+		if err != nil {
+			return nil, "", apperrors.ErrInvalidAnalyticsQuery
+		}
+	}
+
+	unit := query.Unit
+	if unit == "" {
+		unit = "tasks"
+	}
+	if unit != "tasks" && unit != "points" {
+		return nil, "", apperrors.ErrInvalidAnalyticsQuery
+	}
+	return loc, unit, nil
+}
+
+// parseAnalyticsRange parses the from/to days of an analytics query, falling back to the defaults
+func parseAnalyticsRange(query *dto.AnalyticsQuery, loc *time.Location, defaultFrom, defaultTo time.Time) (time.Time, time.Time, error) {
+	from, to := defaultFrom, defaultTo
+	if query.From != "" {
+		t, err := time.ParseInLocation("2006-01-02", query.From, loc)
+		if err != nil {
+			return time.Time{}, time.Time{}, apperrors.ErrInvalidAnalyticsQuery
+		}
+		from = t
+	}
+	if query.To != "" {
+		t, err := time.ParseInLocation("2006-01-02", query.To, loc)
+		if err != nil {
+			return time.Time{}, time.Time{}, apperrors.ErrInvalidAnalyticsQuery
+		}
+		to = t
+	}
+
+	if to.Before(from) || to.Sub(from).Hours()/24 >= maxAnalyticsDays {
+		return time.Time{}, time.Time{}, apperrors.ErrInvalidAnalyticsQuery
+	}
+	return from, to, nil
+}
+
+// startOfDayIn returns midnight of the day of t in the given location
+func startOfDayIn(t time.Time, loc *time.Location) time.Time {
+	t = t.In(loc)
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, loc)
+}
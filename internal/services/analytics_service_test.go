@@ -0,0 +1,184 @@
+package services
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/Hann-arc/task-management-backend/internal/models"
+	"github.com/Hann-arc/task-management-backend/internal/repository"
+	"gorm.io/gorm"
+)
+
+var timelineStart = time.Date(2025, time.March, 1, 9, 0, 0, 0, time.UTC)
+
+// hoursIn returns the time the given number of hours after timelineStart
+func hoursIn(n int) time.Time {
+	return timelineStart.Add(time.Duration(n) * time.Hour)
+}
+
+func hoursInPtr(n int) *time.Time {
+	t := hoursIn(n)
+	return &t
+}
+
+func TestBuildTaskTimeline(t *testing.T) {
+	events := func(changes ...statusChange) []repository.TaskStatusEvent {
+		var result []repository.TaskStatusEvent
+		for _, c := range changes {
+			result = append(result, repository.TaskStatusEvent{Status: c.status, CreatedAt: c.at})
+		}
+		return result
+	}
+	points := 5
+
+	tests := []struct {
+		name        string
+		status      string
+		updatedAt   time.Time
+		completedAt *time.Time
+		events      []repository.TaskStatusEvent
+		want        []statusChange
+		wantStarted *time.Time
+		wantDone    *time.Time
+	}{
+		{
+			name:      "new task",
+			status:    models.TaskStatusTodo,
+			updatedAt: hoursIn(0),
+			want:      []statusChange{{hoursIn(0), models.TaskStatusTodo}},
+		},
+		{
+			name:        "logged history",
+			status:      models.TaskStatusDone,
+			updatedAt:   hoursIn(3),
+			completedAt: hoursInPtr(3),
+			events:      events(statusChange{hoursIn(1), models.TaskStatusInProgress}, statusChange{hoursIn(3), models.TaskStatusDone}),
+			want:        []statusChange{{hoursIn(0), models.TaskStatusTodo}, {hoursIn(1), models.TaskStatusInProgress}, {hoursIn(3), models.TaskStatusDone}},
+			wantStarted: hoursInPtr(1),
+			wantDone:    hoursInPtr(3),
+		},
+		{
+			name:        "unlogged done task uses its completion time",
+			status:      models.TaskStatusDone,
+			updatedAt:   hoursIn(6),
+			completedAt: hoursInPtr(5),
+			want:        []statusChange{{hoursIn(0), models.TaskStatusTodo}, {hoursIn(5), models.TaskStatusDone}},
+			wantDone:    hoursInPtr(5),
+		},
+		{
+			name:        "unlogged status uses the last update",
+			status:      models.TaskStatusInProgress,
+			updatedAt:   hoursIn(2),
+			want:        []statusChange{{hoursIn(0), models.TaskStatusTodo}, {hoursIn(2), models.TaskStatusInProgress}},
+			wantStarted: hoursInPtr(2),
+		},
+		{
+			name:        "events before creation replace the initial status",
+			status:      models.TaskStatusInProgress,
+			updatedAt:   hoursIn(0),
+			events:      events(statusChange{hoursIn(-1), models.TaskStatusInProgress}),
+			want:        []statusChange{{hoursIn(0), models.TaskStatusInProgress}},
+			wantStarted: hoursInPtr(0),
+		},
+		{
+			name:        "repeated statuses collapse",
+			status:      models.TaskStatusDone,
+			updatedAt:   hoursIn(3),
+			events:      events(statusChange{hoursIn(1), models.TaskStatusInProgress}, statusChange{hoursIn(2), models.TaskStatusInProgress}, statusChange{hoursIn(3), models.TaskStatusDone}),
+			want:        []statusChange{{hoursIn(0), models.TaskStatusTodo}, {hoursIn(1), models.TaskStatusInProgress}, {hoursIn(3), models.TaskStatusDone}},
+			wantStarted: hoursInPtr(1),
+			wantDone:    hoursInPtr(3),
+		},
+		{
+			name:        "reopened task is not done",
+			status:      models.TaskStatusInProgress,
+			updatedAt:   hoursIn(2),
+			events:      events(statusChange{hoursIn(1), models.TaskStatusDone}, statusChange{hoursIn(2), models.TaskStatusInProgress}),
+			want:        []statusChange{{hoursIn(0), models.TaskStatusTodo}, {hoursIn(1), models.TaskStatusDone}, {hoursIn(2), models.TaskStatusInProgress}},
+			wantStarted: hoursInPtr(2),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			task := &models.Task{Status: tt.status, CompletedAt: tt.completedAt, StoryPoints: &points}
+			task.CreatedAt = hoursIn(0)
+			task.UpdatedAt = tt.updatedAt
+
+			got := buildTaskTimeline(task, tt.events, "count")
+			if !reflect.DeepEqual(got.changes, tt.want) {
+				t.Errorf("expected changes %v, got %v", tt.want, got.changes)
+			}
+			if !reflect.DeepEqual(got.startedAt, tt.wantStarted) {
+				t.Errorf("expected started at %v, got %v", tt.wantStarted, got.startedAt)
+			}
+			if !reflect.DeepEqual(got.doneAt, tt.wantDone) {
+				t.Errorf("expected done at %v, got %v", tt.wantDone, got.doneAt)
+			}
+			if got.weight != 1 {
+				t.Errorf("expected weight 1, got %d", got.weight)
+			}
+		})
+	}
+}
+
+func TestBuildTaskTimelineWeight(t *testing.T) {
+	points := 5
+
+	tests := []struct {
+		name        string
+		unit        string
+		storyPoints *int
+		want        int
+	}{
+		{"count", "count", &points, 1},
+		{"points", "points", &points, 5},
+		{"points without an estimate", "points", nil, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			task := &models.Task{Status: models.TaskStatusTodo, StoryPoints: tt.storyPoints}
+			if got := buildTaskTimeline(task, nil, tt.unit).weight; got != tt.want {
+				t.Errorf("expected weight %d, got %d", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestTaskTimelineStatusAt(t *testing.T) {
+	task := &models.Task{Status: models.TaskStatusDone, CompletedAt: hoursInPtr(5)}
+	task.CreatedAt = hoursIn(0)
+	task.UpdatedAt = hoursIn(5)
+	task.DeletedAt = gorm.DeletedAt{Time: hoursIn(10), Valid: true}
+
+	timeline := buildTaskTimeline(task, []repository.TaskStatusEvent{
+		{Status: models.TaskStatusInProgress, CreatedAt: hoursIn(2)},
+		{Status: models.TaskStatusDone, CreatedAt: hoursIn(5)},
+	}, "count")
+
+	tests := []struct {
+		name       string
+		at         time.Time
+		wantStatus string
+		wantOK     bool
+	}{
+		{"before creation", hoursIn(-1), "", false},
+		{"at creation", hoursIn(0), models.TaskStatusTodo, true},
+		{"before the first change", hoursIn(1), models.TaskStatusTodo, true},
+		{"at a change", hoursIn(2), models.TaskStatusInProgress, true},
+		{"between changes", hoursIn(4), models.TaskStatusInProgress, true},
+		{"after the last change", hoursIn(9), models.TaskStatusDone, true},
+		{"at deletion", hoursIn(10), "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			status, ok := timeline.statusAt(tt.at)
+			if status != tt.wantStatus || ok != tt.wantOK {
+				t.Errorf("expected (%q, %v), got (%q, %v)", tt.wantStatus, tt.wantOK, status, ok)
+			}
+		})
+	}
+}
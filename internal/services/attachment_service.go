@@ -1,58 +1,159 @@
 package services
 
 import (
+	"bytes"
+	"errors"
+	"io"
+	"log"
 	"mime/multipart"
+	"os"
+	"strconv"
+	"time"
 
+	"github.com/Hann-arc/task-management-backend/config"
 	"github.com/Hann-arc/task-management-backend/internal/dto"
 	apperrors "github.com/Hann-arc/task-management-backend/internal/errors"
 	"github.com/Hann-arc/task-management-backend/internal/models"
 	"github.com/Hann-arc/task-management-backend/internal/repository"
+	"github.com/Hann-arc/task-management-backend/internal/scanner"
+	"github.com/Hann-arc/task-management-backend/internal/storage"
 	"github.com/Hann-arc/task-management-backend/internal/utils"
 	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// thumbnailSize is the maximum width and height of generated image thumbnails
+const thumbnailSize = 256
+
+// Download variants of an attachment
+const (
+	DownloadVariantFile      = "file"
+	DownloadVariantThumbnail = "thumbnail"
 )
 
 type AttachmentService struct {
-	AttachmentRepo repository.AttachmentRepository
+	AttachmentRepo      repository.AttachmentRepository
+	TaskRepo            *repository.TaskRepository
+	Storage             storage.Storage
+	UploadPolicyService *UploadPolicyService
+	Scanner             scanner.Scanner
+	NotificationService *NotificationService
+	ActivityLogService  *ActivityLogService
 }
 
 // NewAttachmentService creates a new instance of AttachmentService
-func NewAttachmentService(attachmentRepo *repository.AttachmentRepository) *AttachmentService {
-	return &AttachmentService{AttachmentRepo: *attachmentRepo}
+func NewAttachmentService(
+	attachmentRepo *repository.AttachmentRepository,
+	taskRepo *repository.TaskRepository,
+	store storage.Storage,
+	uploadPolicyService *UploadPolicyService,
+	fileScanner scanner.Scanner,
+	notificationService *NotificationService,
+	activityLogService *ActivityLogService,
+) *AttachmentService {
+	return &AttachmentService{
+		AttachmentRepo:      *attachmentRepo,
+		TaskRepo:            taskRepo,
+		Storage:             store,
+		UploadPolicyService: uploadPolicyService,
+		Scanner:             fileScanner,
+		NotificationService: notificationService,
+		ActivityLogService:  activityLogService,
+	}
 }
 
 // UploadAttachment handles the uploading of an attachment to a task
 func (s *AttachmentService) UploadAttachment(taskID, userID uuid.UUID, file *multipart.FileHeader) (*dto.CreateAttachmentResponse, error) {
-	isMember, err := s.AttachmentRepo.IsTaskMember(taskID, userID)
-	if err != nil {
+	if err := s.checkTaskWritable(taskID, userID); err != nil {
 		return nil, err
 	}
-	if !isMember {
-		return nil, apperrors.ErrUnauthorizedProject
-	}
 
-	fileUrl, err := utils.UploadToCloudinary(file)
+	stored, err := s.storeUpload(taskID, userID, file)
 	if err != nil {
 		return nil, err
 	}
 
 	attachment := &models.Attachment{
-		TaskID:     taskID,
-		FileUrl:    fileUrl,
-		UploadedBy: userID,
+		TaskID:       taskID,
+		FileUrl:      stored.FileUrl,
+		UploadedBy:   userID,
+		FileName:     stored.FileName,
+		FileSize:     stored.FileSize,
+		MimeType:     stored.MimeType,
+		ThumbnailUrl: stored.ThumbnailUrl,
 	}
 
-	if err := s.AttachmentRepo.Create(attachment); err != nil {
+	if err := s.saveAttachments(taskID, attachment); err != nil {
+		s.deleteStoredFiles(stored)
 		return nil, err
 	}
 
+	s.logAttachmentActivity(taskID, userID, "attachment.uploaded", map[string]interface{}{
+		"attachment_id": attachment.ID,
+		"task_id":       taskID,
+		"file_name":     attachment.FileName,
+	})
+	s.notifyTaskAudience(taskID, userID, "attachment.added", "New attachment on a task you follow")
+
+	fileUrl, thumbnailUrl, expiresAt := signAttachmentURLs(attachment, userID)
 	return &dto.CreateAttachmentResponse{
-		ID:         attachment.ID.String(),
-		TaskID:     attachment.TaskID.String(),
-		FileUrl:    attachment.FileUrl,
-		UploadedBy: attachment.UploadedBy.String(),
+		ID:           attachment.ID.String(),
+		TaskID:       attachment.TaskID.String(),
+		FileUrl:      fileUrl,
+		FileName:     attachment.FileName,
+		FileSize:     attachment.FileSize,
+		MimeType:     attachment.MimeType,
+		ThumbnailUrl: thumbnailUrl,
+		UrlExpiresAt: expiresAt,
+		Version:      attachment.Version,
+		UploadedBy:   attachment.UploadedBy.String(),
+		CreatedAt:    attachment.CreatedAt,
 	}, nil
 }
 
+// UploadVersion uploads a new version of an attachment, which becomes the one shown by default
+func (s *AttachmentService) UploadVersion(attachmentID, userID uuid.UUID, file *multipart.FileHeader) (*dto.AttachmentVersionResponse, error) {
+	attachment, err := s.AttachmentRepo.FindByID(attachmentID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.checkTaskWritable(attachment.TaskID, userID); err != nil {
+		return nil, err
+	}
+
+	version, err := s.storeUpload(attachment.TaskID, userID, file)
+	if err != nil {
+		return nil, err
+	}
+	version.AttachmentID = attachment.ID
+
+	quota, err := s.UploadPolicyService.TaskStorageQuota(attachment.TaskID)
+	if err != nil {
+		s.deleteStoredFiles(version)
+		return nil, err
+	}
+	reserved, err := s.AttachmentRepo.AddVersion(version, quota)
+	if err == nil && !reserved {
+		err = apperrors.ErrStorageQuotaExceeded
+	}
+	if err != nil {
+		s.deleteStoredFiles(version)
+		return nil, err
+	}
+
+	s.logAttachmentActivity(attachment.TaskID, userID, "attachment.version_uploaded", map[string]interface{}{
+		"attachment_id": attachment.ID,
+		"task_id":       attachment.TaskID,
+		"version":       version.Version,
+		"file_name":     version.FileName,
+	})
+	s.notifyTaskAudience(attachment.TaskID, userID, "attachment.version_added", "New version of an attachment on a task you follow")
+
+	response := toAttachmentVersionResponse(version, userID, true)
+	return &response, nil
+}
+
 // GetAttachments retrieves all attachments for a given task
 func (s *AttachmentService) GetAttachments(taskID, userID uuid.UUID) ([]dto.GetAttachmentResponse, error) {
 	isMember, err := s.AttachmentRepo.IsTaskMember(taskID, userID)
@@ -69,25 +170,264 @@ func (s *AttachmentService) GetAttachments(taskID, userID uuid.UUID) ([]dto.GetA
 	}
 
 	var result []dto.GetAttachmentResponse
-	for _, att := range attachments {
-		result = append(result, dto.GetAttachmentResponse{
-			ID:         att.ID.String(),
-			TaskID:     att.TaskID.String(),
-			FileUrl:    att.FileUrl,
-			UploadedBy: att.UploadedBy.String(),
-			Uploader: struct {
-				ID   string `json:"id"`
-				Name string `json:"name"`
-			}{
-				ID:   att.Uploader.ID.String(),
-				Name: att.Uploader.Name,
-			},
+	for i := range attachments {
+		result = append(result, toAttachmentResponse(&attachments[i], userID))
+	}
+
+	return result, nil
+}
+
+// StoreCommentFiles checks and stores files meant for a comment on a task; if any of them is
+// rejected the ones already stored are removed again
+func (s *AttachmentService) StoreCommentFiles(taskID, userID uuid.UUID, files []*multipart.FileHeader) ([]*models.AttachmentVersion, error) {
+	// each file may fit the quota on its own while the batch does not
+	var total int64
+	for _, file := range files {
+		total += file.Size
+	}
+	if err := s.UploadPolicyService.CheckTaskStorage(taskID, total); err != nil {
+		return nil, err
+	}
+
+	var stored []*models.AttachmentVersion
+	for _, file := range files {
+		version, err := s.storeUpload(taskID, userID, file)
+		if err != nil {
+			s.DiscardStoredFiles(stored)
+			return nil, err
+		}
+		stored = append(stored, version)
+	}
+	return stored, nil
+}
+
+// AttachStoredFiles saves files stored by StoreCommentFiles as attachments of a comment; either
+// all of them are saved or, on failure, none are and the stored files are removed
+func (s *AttachmentService) AttachStoredFiles(comment *models.Comment, userID uuid.UUID, stored []*models.AttachmentVersion) ([]dto.GetAttachmentResponse, error) {
+	attachments := make([]*models.Attachment, 0, len(stored))
+	for _, version := range stored {
+		attachments = append(attachments, &models.Attachment{
+			TaskID:       comment.TaskID,
+			CommentID:    &comment.ID,
+			FileUrl:      version.FileUrl,
+			UploadedBy:   userID,
+			FileName:     version.FileName,
+			FileSize:     version.FileSize,
+			MimeType:     version.MimeType,
+			ThumbnailUrl: version.ThumbnailUrl,
 		})
 	}
 
+	if err := s.saveAttachments(comment.TaskID, attachments...); err != nil {
+		s.DiscardStoredFiles(stored)
+		return nil, err
+	}
+
+	result := make([]dto.GetAttachmentResponse, 0, len(attachments))
+	for _, attachment := range attachments {
+		s.logAttachmentActivity(comment.TaskID, userID, "attachment.uploaded", map[string]interface{}{
+			"attachment_id": attachment.ID,
+			"task_id":       comment.TaskID,
+			"comment_id":    comment.ID,
+			"file_name":     attachment.FileName,
+		})
+
+		attachment.Uploader.ID = userID
+		result = append(result, toAttachmentResponse(attachment, userID))
+	}
 	return result, nil
 }
 
+// DiscardStoredFiles removes stored files that did not end up attached to anything
+func (s *AttachmentService) DiscardStoredFiles(stored []*models.AttachmentVersion) {
+	for _, version := range stored {
+		s.deleteStoredFiles(version)
+	}
+}
+
+// DeleteCommentAttachments deletes the attachments of a comment and its replies along with their
+// stored files; failures are logged since the comment itself is already gone
+func (s *AttachmentService) DeleteCommentAttachments(commentID uuid.UUID) {
+	attachments, err := s.AttachmentRepo.FindByCommentThread(commentID)
+	if err != nil {
+		log.Println("Failed to load comment attachments:", err)
+		return
+	}
+
+	for i := range attachments {
+		if err := s.deleteAttachmentInternal(&attachments[i]); err != nil {
+			log.Println("Failed to delete comment attachment:", err)
+		}
+	}
+}
+
+// GetCommentAttachments retrieves the attachments of the given comments grouped by comment
+func (s *AttachmentService) GetCommentAttachments(commentIDs []uuid.UUID, userID uuid.UUID) (map[uuid.UUID][]dto.GetAttachmentResponse, error) {
+	attachments, err := s.AttachmentRepo.FindByCommentIDs(commentIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make(map[uuid.UUID][]dto.GetAttachmentResponse)
+	for i := range attachments {
+		commentID := *attachments[i].CommentID
+		result[commentID] = append(result[commentID], toAttachmentResponse(&attachments[i], userID))
+	}
+	return result, nil
+}
+
+// OpenAttachment streams the file or thumbnail of an attachment; membership is checked on every
+// access, so removing someone from the project revokes their links immediately
+func (s *AttachmentService) OpenAttachment(attachmentID, userID uuid.UUID, variant string) (io.ReadCloser, *models.Attachment, error) {
+	attachment, err := s.AttachmentRepo.FindByID(attachmentID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil, apperrors.ErrFileNotFound
+		}
+		return nil, nil, err
+	}
+
+	isMember, err := s.AttachmentRepo.IsTaskMember(attachment.TaskID, userID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if !isMember {
+		return nil, nil, apperrors.ErrUnauthorizedProject
+	}
+
+	fileUrl := attachment.FileUrl
+	if variant == DownloadVariantThumbnail {
+		if attachment.ThumbnailUrl == nil {
+			return nil, nil, apperrors.ErrFileNotFound
+		}
+		fileUrl = *attachment.ThumbnailUrl
+	}
+
+	content, err := s.Storage.Open(fileUrl)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, nil, apperrors.ErrFileNotFound
+		}
+		return nil, nil, err
+	}
+	return content, attachment, nil
+}
+
+// GetVersions retrieves the version history of an attachment, newest first
+func (s *AttachmentService) GetVersions(attachmentID, userID uuid.UUID) ([]dto.AttachmentVersionResponse, error) {
+	attachment, err := s.AttachmentRepo.FindByID(attachmentID)
+	if err != nil {
+		return nil, err
+	}
+
+	isMember, err := s.AttachmentRepo.IsTaskMember(attachment.TaskID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if !isMember {
+		return nil, apperrors.ErrUnauthorizedProject
+	}
+
+	versions, err := s.AttachmentRepo.FindVersions(attachmentID)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]dto.AttachmentVersionResponse, 0, len(versions))
+	for i := range versions {
+		result = append(result, toAttachmentVersionResponse(&versions[i], userID, versions[i].Version == attachment.Version))
+	}
+	return result, nil
+}
+
+// OpenAttachmentVersion streams the file of a specific version of an attachment
+func (s *AttachmentService) OpenAttachmentVersion(attachmentID, userID uuid.UUID, number int) (io.ReadCloser, *models.AttachmentVersion, error) {
+	attachment, err := s.AttachmentRepo.FindByID(attachmentID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil, apperrors.ErrFileNotFound
+		}
+		return nil, nil, err
+	}
+
+	isMember, err := s.AttachmentRepo.IsTaskMember(attachment.TaskID, userID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if !isMember {
+		return nil, nil, apperrors.ErrUnauthorizedProject
+	}
+
+	version, err := s.AttachmentRepo.FindVersion(attachmentID, number)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil, apperrors.ErrFileNotFound
+		}
+		return nil, nil, err
+	}
+
+	content, err := s.Storage.Open(version.FileUrl)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, nil, apperrors.ErrFileNotFound
+		}
+		return nil, nil, err
+	}
+	return content, version, nil
+}
+
+// DeleteVersion removes a single version of an attachment; only its uploader or the project
+// owner may do so, and the last remaining version can only go with the attachment itself
+func (s *AttachmentService) DeleteVersion(attachmentID, userID uuid.UUID, number int) error {
+	attachment, err := s.AttachmentRepo.FindByID(attachmentID)
+	if err != nil {
+		return err
+	}
+
+	if err := s.checkTaskWritable(attachment.TaskID, userID); err != nil {
+		return err
+	}
+
+	version, err := s.AttachmentRepo.FindVersion(attachmentID, number)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return apperrors.ErrAttachmentVersionNotFound
+		}
+		return err
+	}
+
+	if version.UploadedBy != userID {
+		isOwner, err := s.AttachmentRepo.IsOwnerOfAttachmentProject(attachmentID, userID)
+		if err != nil {
+			return err
+		}
+		if !isOwner {
+			return apperrors.ErrUnauthorizedOwnerOnly
+		}
+	}
+
+	count, err := s.AttachmentRepo.CountVersions(attachmentID)
+	if err != nil {
+		return err
+	}
+	if count <= 1 {
+		return apperrors.ErrLastAttachmentVersion
+	}
+
+	if err := s.AttachmentRepo.DeleteVersion(version); err != nil {
+		return err
+	}
+	s.deleteStoredFiles(version)
+
+	s.logAttachmentActivity(attachment.TaskID, userID, "attachment.version_deleted", map[string]interface{}{
+		"attachment_id": attachment.ID,
+		"task_id":       attachment.TaskID,
+		"version":       version.Version,
+		"file_name":     version.FileName,
+	})
+	return nil
+}
+
 // DeleteAttachment handles the deletion of an attachment
 func (s *AttachmentService) DeleteAttachment(attachmentID, userID uuid.UUID) error {
 	attachment, err := s.AttachmentRepo.FindByID(attachmentID)
@@ -95,27 +435,278 @@ func (s *AttachmentService) DeleteAttachment(attachmentID, userID uuid.UUID) err
 		return err
 	}
 
-	if attachment.UploadedBy == userID {
-		return s.deleteAttachmentInternal(attachmentID, attachment.FileUrl)
+	if err := s.checkTaskWritable(attachment.TaskID, userID); err != nil {
+		return err
 	}
 
-	isOwner, err := s.AttachmentRepo.IsOwnerOfAttachmentProject(attachmentID, userID)
+	if attachment.UploadedBy != userID {
+		isOwner, err := s.AttachmentRepo.IsOwnerOfAttachmentProject(attachmentID, userID)
+		if err != nil {
+			return err
+		}
+		if !isOwner {
+			return apperrors.ErrUnauthorizedOwnerOnly
+		}
+	}
+
+	if err := s.deleteAttachmentInternal(attachment); err != nil {
+		return err
+	}
+
+	s.logAttachmentActivity(attachment.TaskID, userID, "attachment.deleted", map[string]interface{}{
+		"attachment_id": attachment.ID,
+		"task_id":       attachment.TaskID,
+		"file_name":     attachment.FileName,
+	})
+	return nil
+}
+
+// deleteAttachmentInternal deletes the attachment from the database and then the files of every
+// version from storage, so a failed delete never leaves rows pointing at missing files
+func (s *AttachmentService) deleteAttachmentInternal(attachment *models.Attachment) error {
+	versions, err := s.AttachmentRepo.FindVersions(attachment.ID)
 	if err != nil {
 		return err
 	}
-	if isOwner {
-		return s.deleteAttachmentInternal(attachmentID, attachment.FileUrl)
+
+	if err := s.AttachmentRepo.Delete(attachment.ID); err != nil {
+		return err
 	}
 
-	return apperrors.ErrUnauthorizedOwnerOnly
+	for i := range versions {
+		s.deleteStoredFiles(&versions[i])
+	}
+	return nil
 }
 
-// deleteAttachmentInternal performs the actual deletion of the attachment from cloud storage and database
-func (s *AttachmentService) deleteAttachmentInternal(attachmentID uuid.UUID, fileUrl string) error {
+// checkTaskWritable ensures the user is a member of the task's project and that it is not archived
+func (s *AttachmentService) checkTaskWritable(taskID, userID uuid.UUID) error {
+	isMember, err := s.AttachmentRepo.IsTaskMember(taskID, userID)
+	if err != nil {
+		return err
+	}
+	if !isMember {
+		return apperrors.ErrUnauthorizedProject
+	}
 
-	if err := utils.DeleteFromCloudinary(fileUrl); err != nil {
+	archived, err := s.TaskRepo.IsArchived(taskID)
+	if err != nil {
 		return err
 	}
+	if archived {
+		return apperrors.ErrProjectArchived
+	}
+	return nil
+}
+
+// storeUpload checks an upload against the project's policy and the malware scanner, then stores
+// it with its thumbnail and returns the unsaved version describing it
+func (s *AttachmentService) storeUpload(taskID, userID uuid.UUID, file *multipart.FileHeader) (*models.AttachmentVersion, error) {
+	mimeType, err := utils.DetectMimeType(file)
+	if err != nil {
+		return nil, err
+	}
 
-	return s.AttachmentRepo.Delete(attachmentID)
+	// limits and the malware scan are enforced before anything reaches storage
+	if err := s.UploadPolicyService.CheckTaskUpload(taskID, file.Size, mimeType); err != nil {
+		return nil, err
+	}
+	if err := s.scanUpload(taskID, userID, file, mimeType); err != nil {
+		return nil, err
+	}
+
+	fileUrl, err := storage.UploadFile(s.Storage, "attachments", file, mimeType)
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.AttachmentVersion{
+		FileUrl:      fileUrl,
+		FileName:     file.Filename,
+		FileSize:     file.Size,
+		MimeType:     mimeType,
+		ThumbnailUrl: s.uploadThumbnail(file, mimeType),
+		UploadedBy:   userID,
+	}, nil
+}
+
+// saveAttachments saves new attachments of a task, rejecting them when they exceed the storage
+// quota of its project
+func (s *AttachmentService) saveAttachments(taskID uuid.UUID, attachments ...*models.Attachment) error {
+	quota, err := s.UploadPolicyService.TaskStorageQuota(taskID)
+	if err != nil {
+		return err
+	}
+
+	reserved, err := s.AttachmentRepo.Create(attachments, quota)
+	if err != nil {
+		return err
+	}
+	if !reserved {
+		return apperrors.ErrStorageQuotaExceeded
+	}
+	return nil
+}
+
+// notifyTaskAudience notifies the creator, assignees and watchers of a task about an attachment
+func (s *AttachmentService) notifyTaskAudience(taskID, userID uuid.UUID, notifType, message string) {
+	if s.NotificationService == nil || s.TaskRepo == nil {
+		return
+	}
+
+	audience, err := s.TaskRepo.FindAudienceIDs(taskID)
+	if err != nil {
+		return
+	}
+	go s.NotificationService.NotifyUsers(audience, userID, notifType, "task", taskID, message)
+}
+
+// logAttachmentActivity records an attachment change in the activity log of the task's project
+func (s *AttachmentService) logAttachmentActivity(taskID, userID uuid.UUID, action string, details map[string]interface{}) {
+	if s.ActivityLogService == nil {
+		return
+	}
+
+	projectID, err := s.AttachmentRepo.FindProjectID(taskID)
+	if err != nil {
+		log.Println("Failed to resolve project for activity log:", err)
+		return
+	}
+	s.ActivityLogService.LogActivity(projectID, userID, action, details)
+}
+
+// scanUpload runs the configured malware scanner over an upload; infected files are rejected,
+// and also kept in the quarantine folder for review when quarantining is enabled
+func (s *AttachmentService) scanUpload(taskID, userID uuid.UUID, file *multipart.FileHeader, mimeType string) error {
+	if s.Scanner == nil {
+		return nil
+	}
+
+	src, err := file.Open()
+	if err != nil {
+		return err
+	}
+	result, err := s.Scanner.Scan(src)
+	src.Close()
+	if err != nil {
+		return err
+	}
+	if !result.Infected {
+		return nil
+	}
+
+	if config.QuarantineInfected {
+		quarantineUrl, err := storage.UploadFile(s.Storage, "quarantine", file, mimeType)
+		if err != nil {
+			log.Println("Failed to quarantine infected upload:", err)
+		} else {
+			log.Printf("Quarantined upload %q for task %s by user %s (%s) at %s", file.Filename, taskID, userID, result.Signature, quarantineUrl)
+		}
+	} else {
+		log.Printf("Rejected upload %q for task %s by user %s (%s)", file.Filename, taskID, userID, result.Signature)
+	}
+
+	return apperrors.ErrFileInfected
+}
+
+// uploadThumbnail stores a thumbnail for image uploads; failures only cost the preview,
+// so they are logged instead of failing the upload
+func (s *AttachmentService) uploadThumbnail(file *multipart.FileHeader, mimeType string) *string {
+	if !utils.ThumbnailMimeTypes[mimeType] {
+		return nil
+	}
+
+	thumbnail, err := utils.GenerateThumbnail(file, thumbnailSize)
+	if err != nil {
+		log.Println("Failed to generate thumbnail:", err)
+		return nil
+	}
+
+	thumbnailUrl, err := s.Storage.Put(storage.NewKey("thumbnails", "thumbnail.jpg"), bytes.NewReader(thumbnail), int64(len(thumbnail)), "image/jpeg")
+	if err != nil {
+		log.Println("Failed to upload thumbnail:", err)
+		return nil
+	}
+	return &thumbnailUrl
+}
+
+// deleteStoredFiles removes the files of an attachment version that could not be saved or was deleted
+func (s *AttachmentService) deleteStoredFiles(version *models.AttachmentVersion) {
+	if err := s.Storage.Delete(version.FileUrl); err != nil {
+		log.Println("Failed to delete orphaned attachment file:", err)
+	}
+	if version.ThumbnailUrl != nil {
+		if err := s.Storage.Delete(*version.ThumbnailUrl); err != nil {
+			log.Println("Failed to delete orphaned thumbnail:", err)
+		}
+	}
+}
+
+// signAttachmentURLs builds the short-lived download links of an attachment for a user, so the
+// storage URLs never reach clients
+func signAttachmentURLs(attachment *models.Attachment, userID uuid.UUID) (string, *string, time.Time) {
+	expiresAt := time.Now().Add(config.DownloadURLTTL)
+	basePath := config.DownloadBaseURL + "/attachments/" + attachment.ID.String()
+
+	fileUrl := utils.SignDownloadURL(basePath+"/download", attachment.ID, DownloadVariantFile, userID, expiresAt)
+
+	var thumbnailUrl *string
+	if attachment.ThumbnailUrl != nil {
+		signed := utils.SignDownloadURL(basePath+"/thumbnail", attachment.ID, DownloadVariantThumbnail, userID, expiresAt)
+		thumbnailUrl = &signed
+	}
+
+	return fileUrl, thumbnailUrl, expiresAt
+}
+
+// toAttachmentResponse maps an attachment to its response with signed download links
+func toAttachmentResponse(attachment *models.Attachment, userID uuid.UUID) dto.GetAttachmentResponse {
+	fileUrl, thumbnailUrl, expiresAt := signAttachmentURLs(attachment, userID)
+	response := dto.GetAttachmentResponse{
+		ID:           attachment.ID.String(),
+		TaskID:       attachment.TaskID.String(),
+		FileUrl:      fileUrl,
+		FileName:     attachment.FileName,
+		FileSize:     attachment.FileSize,
+		MimeType:     attachment.MimeType,
+		ThumbnailUrl: thumbnailUrl,
+		UrlExpiresAt: expiresAt,
+		Version:      attachment.Version,
+		UploadedBy:   attachment.UploadedBy.String(),
+		CreatedAt:    attachment.CreatedAt,
+	}
+	if attachment.CommentID != nil {
+		commentID := attachment.CommentID.String()
+		response.CommentID = &commentID
+	}
+	response.Uploader.ID = attachment.Uploader.ID.String()
+	response.Uploader.Name = attachment.Uploader.Name
+	return response
+}
+
+// VersionDownloadVariant is the signature variant of the download link of an attachment version
+func VersionDownloadVariant(number int) string {
+	return "version-" + strconv.Itoa(number)
+}
+
+// toAttachmentVersionResponse maps a version to its response with a signed download link
+func toAttachmentVersionResponse(version *models.AttachmentVersion, userID uuid.UUID, isLatest bool) dto.AttachmentVersionResponse {
+	expiresAt := time.Now().Add(config.DownloadURLTTL)
+	number := strconv.Itoa(version.Version)
+	path := config.DownloadBaseURL + "/attachments/" + version.AttachmentID.String() + "/versions/" + number + "/download"
+
+	response := dto.AttachmentVersionResponse{
+		Version:      version.Version,
+		FileUrl:      utils.SignDownloadURL(path, version.AttachmentID, VersionDownloadVariant(version.Version), userID, expiresAt),
+		FileName:     version.FileName,
+		FileSize:     version.FileSize,
+		MimeType:     version.MimeType,
+		UrlExpiresAt: expiresAt,
+		IsLatest:     isLatest,
+		UploadedBy:   version.UploadedBy.String(),
+		CreatedAt:    version.CreatedAt,
+	}
+	response.Uploader.ID = version.Uploader.ID.String()
+	response.Uploader.Name = version.Uploader.Name
+	return response
 }
@@ -1,6 +1,8 @@
 package services
 
 import (
+	"time"
+
 	"github.com/Hann-arc/task-management-backend/internal/dto"
 	apperrors "github.com/Hann-arc/task-management-backend/internal/errors"
 	"github.com/Hann-arc/task-management-backend/internal/models"
@@ -31,6 +33,14 @@ func (s *BoardService) CreateBoard(projectID, userID uuid.UUID, name string) (*d
 		return nil, apperrors.ErrUnauthorizedBoardAction
 	}
 
+	archived, err := s.ProjectRepo.IsArchived(projectID)
+	if err != nil {
+		return nil, err
+	}
+	if archived {
+		return nil, apperrors.ErrProjectArchived
+	}
+
 	count, err := s.BoardRepo.GetBoardCount(projectID)
 	if err != nil {
 		return nil, err
@@ -66,9 +76,9 @@ func (s *BoardService) CreateBoard(projectID, userID uuid.UUID, name string) (*d
 	}, nil
 }
 
-// GetBoards retrieves all boards for a given project
-func (s *BoardService) GetBoards(projectID uuid.UUID) ([]dto.BoardResponse, error) {
-	boards, err := s.BoardRepo.FindByProjectID(projectID)
+// GetBoards retrieves the active or the archived boards of a given project
+func (s *BoardService) GetBoards(projectID uuid.UUID, archived bool) ([]dto.BoardResponse, error) {
+	boards, err := s.BoardRepo.FindByProjectID(projectID, archived)
 	if err != nil {
 		return nil, err
 	}
@@ -80,6 +90,7 @@ func (s *BoardService) GetBoards(projectID uuid.UUID) ([]dto.BoardResponse, erro
 			Name:       b.Name,
 			OrderIndex: b.OrderIndex,
 			ProjectID:  b.ProjectID.String(),
+			ArchivedAt: b.ArchivedAt,
 			CreatedAt:  b.CreatedAt,
 			UpdatedAt:  b.UpdatedAt,
 		})
@@ -106,6 +117,14 @@ func (s *BoardService) UpdateBoard(boardID, userID uuid.UUID, name *string, orde
 		return nil, apperrors.ErrNoFieldsToUpdate
 	}
 
+	archived, err := s.ProjectRepo.IsBoardArchived(boardID)
+	if err != nil {
+		return nil, err
+	}
+	if archived {
+		return nil, apperrors.ErrProjectArchived
+	}
+
 	var newOrderIndex *int
 	if orderIndex != nil {
 		maxOrder, err := s.BoardRepo.GetMaxOrderIndex(board.ProjectID)
@@ -174,6 +193,7 @@ func (s *BoardService) UpdateBoard(boardID, userID uuid.UUID, name *string, orde
 		Name:       updatedBoard.Name,
 		OrderIndex: updatedBoard.OrderIndex,
 		ProjectID:  updatedBoard.ProjectID.String(),
+		ArchivedAt: updatedBoard.ArchivedAt,
 		CreatedAt:  updatedBoard.CreatedAt,
 		UpdatedAt:  updatedBoard.UpdatedAt,
 	}, nil
@@ -194,6 +214,14 @@ func (s *BoardService) DeleteBoard(boardID, userID uuid.UUID) error {
 		return apperrors.ErrUnauthorizedBoardAction
 	}
 
+	archived, err := s.ProjectRepo.IsBoardArchived(boardID)
+	if err != nil {
+		return err
+	}
+	if archived {
+		return apperrors.ErrProjectArchived
+	}
+
 	tx := s.DB.Begin()
 	if tx.Error != nil {
 		return tx.Error
@@ -219,3 +247,60 @@ func (s *BoardService) DeleteBoard(boardID, userID uuid.UUID) error {
 
 	return tx.Commit().Error
 }
+
+// ArchiveBoard makes a board read-only and hides it from the default board listing
+func (s *BoardService) ArchiveBoard(boardID, userID uuid.UUID) (*dto.BoardResponse, error) {
+	now := time.Now()
+	return s.setArchived(boardID, userID, &now, "board.archived")
+}
+
+// UnarchiveBoard restores an archived board; the project itself must not be archived
+func (s *BoardService) UnarchiveBoard(boardID, userID uuid.UUID) (*dto.BoardResponse, error) {
+	return s.setArchived(boardID, userID, nil, "board.unarchived")
+}
+
+// setArchived changes the archived state of a board; only the project owner can do it
+func (s *BoardService) setArchived(boardID, userID uuid.UUID, archivedAt *time.Time, action string) (*dto.BoardResponse, error) {
+	board, err := s.BoardRepo.FindByID(boardID)
+	if err != nil {
+		return nil, err
+	}
+
+	isOwner, err := s.ProjectRepo.IsOwner(board.ProjectID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if !isOwner {
+		return nil, apperrors.ErrUnauthorizedBoardAction
+	}
+
+	projectArchived, err := s.ProjectRepo.IsArchived(board.ProjectID)
+	if err != nil {
+		return nil, err
+	}
+	if projectArchived {
+		return nil, apperrors.ErrProjectArchived
+	}
+
+	if err := s.BoardRepo.SetArchived(boardID, archivedAt); err != nil {
+		return nil, err
+	}
+
+	// Log activity
+	if s.ActivityLogService != nil {
+		s.ActivityLogService.LogActivity(board.ProjectID, userID, action, map[string]interface{}{
+			"board_id": boardID.String(),
+		})
+	}
+
+	board.ArchivedAt = archivedAt
+	return &dto.BoardResponse{
+		ID:         board.ID.String(),
+		Name:       board.Name,
+		OrderIndex: board.OrderIndex,
+		ProjectID:  board.ProjectID.String(),
+		ArchivedAt: board.ArchivedAt,
+		CreatedAt:  board.CreatedAt,
+		UpdatedAt:  board.UpdatedAt,
+	}, nil
+}
@@ -2,6 +2,9 @@ package services
 
 import (
 	"errors"
+	"log"
+	"mime/multipart"
+	"strings"
 
 	"github.com/Hann-arc/task-management-backend/internal/dto"
 	apperrors "github.com/Hann-arc/task-management-backend/internal/errors"
@@ -16,15 +19,17 @@ type CommentService struct {
 	TaskRepo            *repository.TaskRepository
 	ActivityLogService  *ActivityLogService
 	NotificationService *NotificationService
+	AttachmentService   *AttachmentService
+	MentionService      *MentionService
 }
 
 // NewCommentService creates a new instance of CommentService
-func NewCommentService(commentRepo *repository.CommentRepository, taskRepo *repository.TaskRepository, activityLogService *ActivityLogService, notificationService *NotificationService) *CommentService {
-	return &CommentService{CommentRepo: commentRepo, TaskRepo: taskRepo, ActivityLogService: activityLogService, NotificationService: notificationService}
+func NewCommentService(commentRepo *repository.CommentRepository, taskRepo *repository.TaskRepository, activityLogService *ActivityLogService, notificationService *NotificationService, attachmentService *AttachmentService, mentionService *MentionService) *CommentService {
+	return &CommentService{CommentRepo: commentRepo, TaskRepo: taskRepo, ActivityLogService: activityLogService, NotificationService: notificationService, AttachmentService: attachmentService, MentionService: mentionService}
 }
 
-// CreateMainComment handles the creation of a main comment on a task
-func (s *CommentService) CreateMainComment(taskId, userID uuid.UUID, content string) (*dto.CommentResponse, error) {
+// CreateMainComment handles the creation of a main comment on a task, attaching any uploaded files
+func (s *CommentService) CreateMainComment(taskId, userID uuid.UUID, content string, files []*multipart.FileHeader) (*dto.CommentResponse, error) {
 	isMember, err := s.CommentRepo.IsTaskMember(taskId, userID)
 
 	if err != nil {
@@ -35,6 +40,20 @@ func (s *CommentService) CreateMainComment(taskId, userID uuid.UUID, content str
 		return nil, apperrors.ErrUnauthorizedProject
 	}
 
+	archived, err := s.TaskRepo.IsArchived(taskId)
+	if err != nil {
+		return nil, err
+	}
+	if archived {
+		return nil, apperrors.ErrProjectArchived
+	}
+
+	// files are checked and stored first so a rejected file does not leave a half-made comment
+	stored, err := s.AttachmentService.StoreCommentFiles(taskId, userID, files)
+	if err != nil {
+		return nil, err
+	}
+
 	comment := &models.Comment{
 		TaskID:  taskId,
 		UserID:  userID,
@@ -42,9 +61,18 @@ func (s *CommentService) CreateMainComment(taskId, userID uuid.UUID, content str
 	}
 
 	if err := s.CommentRepo.Create(comment); err != nil {
+		s.AttachmentService.DiscardStoredFiles(stored)
+		return nil, err
+	}
+
+	attachments, err := s.AttachmentService.AttachStoredFiles(comment, userID, stored)
+	if err != nil {
+		s.discardComment(comment.ID)
 		return nil, err
 	}
 
+	s.syncMentions(comment, userID)
+
 	// Log activity
 	if s.ActivityLogService != nil {
 		var projectID uuid.UUID
@@ -62,41 +90,28 @@ func (s *CommentService) CreateMainComment(taskId, userID uuid.UUID, content str
 		})
 	}
 
-	// Send notifications
+	// Send notifications to the creator, assignees and watchers of the task
 	if s.NotificationService != nil {
-		var task models.Task
-		s.TaskRepo.DB.Preload("Assignee").Preload("Creator").First(&task, taskId)
-
-		// Notification to assignee
-		if task.AssigneeID != nil && *task.AssigneeID != userID {
-			go s.NotificationService.CreateNotification(
-				*task.AssigneeID,
-				userID,
-				"comment.added",
-				"task",
-				taskId,
-				"You have a new comment on your task",
-			)
-		}
-
-		// Notification to task creator
-		if task.CreatedBy != userID {
-			go s.NotificationService.CreateNotification(
-				task.CreatedBy,
+		audience, err := s.TaskRepo.FindAudienceIDs(taskId)
+		if err == nil {
+			go s.NotificationService.NotifyUsers(
+				audience,
 				userID,
 				"comment.added",
 				"task",
 				taskId,
-				"New comment on your task",
+				"New comment on a task you follow",
 			)
 		}
 	}
 
-	return s.buildCommentResponse(comment), nil
+	res := s.buildCommentResponse(comment)
+	res.Attachments = attachments
+	return res, nil
 }
 
-// CreateReply handles the creation of a reply to an existing comment
-func (s *CommentService) CreateReply(targetCommentID, userID uuid.UUID, content string) (*dto.CommentResponse, error) {
+// CreateReply handles the creation of a reply to an existing comment, attaching any uploaded files
+func (s *CommentService) CreateReply(targetCommentID, userID uuid.UUID, content string, files []*multipart.FileHeader) (*dto.CommentResponse, error) {
 	targetComment, err := s.CommentRepo.FindByID(targetCommentID)
 
 	if err != nil {
@@ -116,6 +131,14 @@ func (s *CommentService) CreateReply(targetCommentID, userID uuid.UUID, content
 		return nil, apperrors.ErrUnauthorizedProject
 	}
 
+	archived, err := s.TaskRepo.IsArchived(targetComment.TaskID)
+	if err != nil {
+		return nil, err
+	}
+	if archived {
+		return nil, apperrors.ErrProjectArchived
+	}
+
 	var actualParentID uuid.UUID
 
 	if targetComment.ParentID == nil {
@@ -124,6 +147,11 @@ func (s *CommentService) CreateReply(targetCommentID, userID uuid.UUID, content
 		actualParentID = *targetComment.ParentID
 	}
 
+	stored, err := s.AttachmentService.StoreCommentFiles(targetComment.TaskID, userID, files)
+	if err != nil {
+		return nil, err
+	}
+
 	comment := models.Comment{
 		TaskID:   targetComment.TaskID,
 		UserID:   userID,
@@ -132,9 +160,18 @@ func (s *CommentService) CreateReply(targetCommentID, userID uuid.UUID, content
 	}
 
 	if err := s.CommentRepo.Create(&comment); err != nil {
+		s.AttachmentService.DiscardStoredFiles(stored)
+		return nil, err
+	}
+
+	attachments, err := s.AttachmentService.AttachStoredFiles(&comment, userID, stored)
+	if err != nil {
+		s.discardComment(comment.ID)
 		return nil, err
 	}
 
+	s.syncMentions(&comment, userID)
+
 	// Log activity
 	if s.ActivityLogService != nil {
 		var projectID uuid.UUID
@@ -152,37 +189,61 @@ func (s *CommentService) CreateReply(targetCommentID, userID uuid.UUID, content
 		})
 	}
 
+	// Send notifications to the creator, assignees and watchers of the task
 	if s.NotificationService != nil {
-		var task models.Task
-		s.TaskRepo.DB.Preload("Assignee").Preload("Creator").First(&task, task.ID)
-
-		// Notifikasi ke assignee
-		if task.AssigneeID != nil && *task.AssigneeID != userID {
-			go s.NotificationService.CreateNotification(
-				*task.AssigneeID,
+		audience, err := s.TaskRepo.FindAudienceIDs(comment.TaskID)
+		if err == nil {
+			go s.NotificationService.NotifyUsers(
+				audience,
 				userID,
 				"comment.added",
 				"task",
-				task.ID,
-				"You have a new comment on your task",
+				comment.TaskID,
+				"New reply on a task you follow",
 			)
 		}
+	}
 
-		// Notifikasi ke creator task
-		if task.CreatedBy != userID {
-			go s.NotificationService.CreateNotification(
-				task.CreatedBy,
-				userID,
-				"comment.added",
-				"task",
-				task.ID,
-				"New comment on your task",
-			)
+	res := s.buildCommentResponse(&comment)
+	res.Attachments = attachments
+	return res, nil
+}
+
+// AddAttachments attaches files to an existing comment; only its author may do so
+func (s *CommentService) AddAttachments(commentID, userID uuid.UUID, files []*multipart.FileHeader) ([]dto.GetAttachmentResponse, error) {
+	comment, err := s.CommentRepo.FindByID(commentID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, apperrors.ErrCommentNotFound
 		}
+		return nil, err
 	}
 
-	return s.buildCommentResponse(&comment), nil
+	isMember, err := s.CommentRepo.IsTaskMember(comment.TaskID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if !isMember {
+		return nil, apperrors.ErrUnauthorizedProject
+	}
+	if comment.UserID != userID {
+		return nil, apperrors.ErrUnauthorizedOwnerOnly
+	}
 
+	archived, err := s.TaskRepo.IsArchived(comment.TaskID)
+	if err != nil {
+		return nil, err
+	}
+	if archived {
+		return nil, apperrors.ErrProjectArchived
+	}
+
+	stored, err := s.AttachmentService.StoreCommentFiles(comment.TaskID, userID, files)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.AttachmentService.AttachStoredFiles(comment, userID, stored)
 }
 
 // GetCommentsByTask retrieves all comments and their replies for a given task
@@ -203,25 +264,166 @@ func (s *CommentService) GetCommentsByTask(taskID, userID uuid.UUID) ([]dto.Comm
 		return nil, err
 	}
 
+	var commentIDs []uuid.UUID
+	for _, main := range mainComments {
+		commentIDs = append(commentIDs, main.ID)
+		for _, reply := range main.Replies {
+			commentIDs = append(commentIDs, reply.ID)
+		}
+	}
+
+	attachments, err := s.AttachmentService.GetCommentAttachments(commentIDs, userID)
+	if err != nil {
+		return nil, err
+	}
+
 	var result []dto.CommentResponse
 	for _, main := range mainComments {
-		result = append(result, *s.buildCommentResponse(&main))
+		res := s.buildCommentResponse(&main)
+		if files, ok := attachments[main.ID]; ok {
+			res.Attachments = files
+		}
+		result = append(result, *res)
 
 		for _, reply := range main.Replies {
-			result = append(result, *s.buildCommentResponse(&reply))
+			res := s.buildCommentResponse(&reply)
+			if files, ok := attachments[reply.ID]; ok {
+				res.Attachments = files
+			}
+			result = append(result, *res)
+		}
+	}
+
+	return result, nil
+}
+
+// UpdateComment edits the content of a comment; only its author may do so, and the previous
+// content is kept in the comment's edit history
+func (s *CommentService) UpdateComment(commentID, userID uuid.UUID, content string) (*dto.CommentResponse, error) {
+	if strings.TrimSpace(content) == "" {
+		return nil, apperrors.ErrEmptyComment
+	}
+
+	comment, err := s.CommentRepo.FindByIDWithUser(commentID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, apperrors.ErrCommentNotFound
+		}
+		return nil, err
+	}
+
+	isMember, err := s.CommentRepo.IsTaskMember(comment.TaskID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if !isMember {
+		return nil, apperrors.ErrUnauthorizedProject
+	}
+	if comment.UserID != userID {
+		return nil, apperrors.ErrNotCommentAuthor
+	}
+
+	archived, err := s.TaskRepo.IsArchived(comment.TaskID)
+	if err != nil {
+		return nil, err
+	}
+	if archived {
+		return nil, apperrors.ErrProjectArchived
+	}
+
+	if content != comment.Content {
+		if err := s.CommentRepo.UpdateContent(comment, userID, content); err != nil {
+			return nil, err
+		}
+		s.syncMentions(comment, userID)
+
+		// Log activity
+		if s.ActivityLogService != nil {
+			var projectID uuid.UUID
+			s.TaskRepo.DB.Model(&models.Task{}).
+				Select("projects.id").
+				Joins("JOIN boards ON tasks.board_id = boards.id").
+				Joins("JOIN projects ON boards.project_id = projects.id").
+				Where("tasks.id = ?", comment.TaskID).
+				Scan(&projectID)
+
+			s.ActivityLogService.LogActivity(projectID, userID, "comment.edited", map[string]interface{}{
+				"comment_id": comment.ID.String(),
+				"task_id":    comment.TaskID.String(),
+			})
 		}
 	}
 
+	attachments, err := s.AttachmentService.GetCommentAttachments([]uuid.UUID{comment.ID}, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	res := s.buildCommentResponse(comment)
+	if files, ok := attachments[comment.ID]; ok {
+		res.Attachments = files
+	}
+	return res, nil
+}
+
+// GetCommentHistory retrieves the earlier versions of a comment; only the project owner may see them
+func (s *CommentService) GetCommentHistory(commentID, userID uuid.UUID) ([]dto.CommentEditResponse, error) {
+	comment, err := s.CommentRepo.FindByID(commentID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, apperrors.ErrCommentNotFound
+		}
+		return nil, err
+	}
+
+	isOwner, err := s.CommentRepo.IsOwnerOfTaskProject(comment.TaskID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if !isOwner {
+		return nil, apperrors.ErrUnauthorizedOwnerOnly
+	}
+
+	edits, err := s.CommentRepo.FindEdits(commentID)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]dto.CommentEditResponse, 0, len(edits))
+	for _, edit := range edits {
+		result = append(result, dto.CommentEditResponse{
+			ID:        edit.ID.String(),
+			CommentID: edit.CommentID.String(),
+			Content:   edit.Content,
+			Editor: dto.CommentUser{
+				ID:        edit.Editor.ID.String(),
+				Name:      edit.Editor.Name,
+				AvatarUrl: edit.Editor.AvatarUrl,
+			},
+			EditedAt: edit.CreatedAt,
+		})
+	}
+
 	return result, nil
 }
 
-// DeleteComment handles the deletion of a comment
+// DeleteComment handles the deletion of a comment. The comment and its replies go to the trash,
+// but their attachments are deleted right away so the files stop counting against the project
+// quota; restoring the comment brings back its text only
 func (s *CommentService) DeleteComment(commentID, userID uuid.UUID) error {
 	comment, err := s.CommentRepo.FindByID(commentID)
 	if err != nil {
 		return err
 	}
 
+	archived, err := s.TaskRepo.IsArchived(comment.TaskID)
+	if err != nil {
+		return err
+	}
+	if archived {
+		return apperrors.ErrProjectArchived
+	}
+
 	isOwner, err := s.CommentRepo.IsOwnerOfTaskProject(comment.TaskID, userID)
 	if err != nil {
 		return err
@@ -244,12 +446,40 @@ func (s *CommentService) DeleteComment(commentID, userID uuid.UUID) error {
 			})
 		}
 
-		return s.CommentRepo.SoftDelete(commentID)
+		if err := s.CommentRepo.SoftDelete(commentID); err != nil {
+			return err
+		}
+		s.AttachmentService.DeleteCommentAttachments(commentID)
+		return nil
 	}
 
 	return apperrors.ErrUnauthorizedOwnerOnly
 }
 
+// discardComment removes a comment whose files could not be attached, so a failed request
+// leaves nothing behind
+func (s *CommentService) discardComment(commentID uuid.UUID) {
+	if err := s.CommentRepo.Delete(commentID); err != nil {
+		log.Println("Failed to remove comment after attaching files failed:", err)
+	}
+}
+
+// syncMentions stores and notifies the members mentioned in a comment; a failure only costs the
+// mention links, so it is logged instead of failing the comment
+func (s *CommentService) syncMentions(comment *models.Comment, userID uuid.UUID) {
+	if s.MentionService == nil {
+		return
+	}
+
+	projectID, err := s.TaskRepo.FindProjectID(comment.TaskID)
+	if err == nil {
+		err = s.MentionService.SyncCommentMentions(comment, projectID, userID)
+	}
+	if err != nil {
+		log.Println("Failed to save comment mentions:", err)
+	}
+}
+
 // buildCommentResponse transforms a Comment model into a CommentResponse DTO
 func (s *CommentService) buildCommentResponse(comment *models.Comment) *dto.CommentResponse {
 	res := &dto.CommentResponse{
@@ -262,8 +492,12 @@ func (s *CommentService) buildCommentResponse(comment *models.Comment) *dto.Comm
 			Name:      comment.User.Name,
 			AvatarUrl: comment.User.AvatarUrl,
 		},
-		CreatedAt: comment.CreatedAt,
-		UpdatedAt: comment.UpdatedAt,
+		CreatedAt:   comment.CreatedAt,
+		UpdatedAt:   comment.UpdatedAt,
+		IsEdited:    comment.EditedAt != nil,
+		EditedAt:    comment.EditedAt,
+		Mentions:    buildMentionResponses(comment.Mentions),
+		Attachments: []dto.GetAttachmentResponse{},
 	}
 
 	if comment.ParentID != nil {
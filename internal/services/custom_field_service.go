@@ -0,0 +1,442 @@
+package services
+
+import (
+	"encoding/json"
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Hann-arc/task-management-backend/internal/dto"
+	apperrors "github.com/Hann-arc/task-management-backend/internal/errors"
+	"github.com/Hann-arc/task-management-backend/internal/models"
+	"github.com/Hann-arc/task-management-backend/internal/repository"
+	"github.com/Hann-arc/task-management-backend/internal/utils"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+var validCustomFieldTypes = map[string]bool{
+	models.CustomFieldTypeText:         true,
+	models.CustomFieldTypeNumber:       true,
+	models.CustomFieldTypeDate:         true,
+	models.CustomFieldTypeSingleSelect: true,
+	models.CustomFieldTypeMultiSelect:  true,
+	models.CustomFieldTypeUser:         true,
+	models.CustomFieldTypeCheckbox:     true,
+}
+
+type CustomFieldService struct {
+	CustomFieldRepo    *repository.CustomFieldRepository
+	ProjectRepo        *repository.ProjectRepository
+	ActivityLogService *ActivityLogService
+}
+
+// NewCustomFieldService creates a new instance of CustomFieldService
+func NewCustomFieldService(
+	customFieldRepo *repository.CustomFieldRepository,
+	projectRepo *repository.ProjectRepository,
+	activityLogService *ActivityLogService,
+) *CustomFieldService {
+	return &CustomFieldService{
+		CustomFieldRepo:    customFieldRepo,
+		ProjectRepo:        projectRepo,
+		ActivityLogService: activityLogService,
+	}
+}
+
+// CreateField defines a new custom field in a project; only the project owner can manage fields
+func (s *CustomFieldService) CreateField(projectID, userID uuid.UUID, req *dto.CreateCustomFieldRequest) (*dto.CustomFieldResponse, error) {
+	isOwner, err := s.ProjectRepo.IsOwner(projectID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if !isOwner {
+		return nil, apperrors.ErrUnauthorizedOwnerOnly
+	}
+
+	archived, err := s.ProjectRepo.IsArchived(projectID)
+	if err != nil {
+		return nil, err
+	}
+	if archived {
+		return nil, apperrors.ErrProjectArchived
+	}
+
+	name := strings.TrimSpace(req.Name)
+	if name == "" || !validCustomFieldTypes[req.Type] {
+		return nil, apperrors.ErrInvalidCustomField
+	}
+
+	options, err := normalizeCustomFieldOptions(req.Type, req.Options)
+	if err != nil {
+		return nil, err
+	}
+
+	exists, err := s.CustomFieldRepo.NameExists(projectID, name, nil)
+	if err != nil {
+		return nil, err
+	}
+	if exists {
+		return nil, apperrors.ErrCustomFieldExists
+	}
+
+	field := &models.CustomField{
+		ID:        uuid.New(),
+		ProjectID: projectID,
+		Name:      name,
+		Type:      req.Type,
+		Options:   options,
+		Required:  req.Required,
+		CreatedBy: userID,
+	}
+	if req.Position != nil {
+		field.Position = *req.Position
+	}
+
+	if err := s.CustomFieldRepo.Create(field); err != nil {
+		return nil, err
+	}
+
+	// Log activity
+	if s.ActivityLogService != nil {
+		s.ActivityLogService.LogActivity(projectID, userID, "custom_field.created", map[string]interface{}{
+			"field_id": field.ID.String(),
+			"name":     field.Name,
+			"type":     field.Type,
+		})
+	}
+
+	return buildCustomFieldResponse(field), nil
+}
+
+// GetFields retrieves the custom field definitions of a project
+func (s *CustomFieldService) GetFields(projectID, userID uuid.UUID) ([]dto.CustomFieldResponse, error) {
+	isMember, err := s.ProjectRepo.IsMember(projectID, userID)
+	if err != nil {
+		return nil, err
+	}
+	isOwner, err := s.ProjectRepo.IsOwner(projectID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if !isMember && !isOwner {
+		return nil, apperrors.ErrUnauthorizedProject
+	}
+
+	fields, err := s.CustomFieldRepo.FindByProjectID(projectID)
+	if err != nil {
+		return nil, err
+	}
+
+	result := []dto.CustomFieldResponse{}
+	for _, f := range fields {
+		result = append(result, *buildCustomFieldResponse(&f))
+	}
+	return result, nil
+}
+
+// UpdateField modifies a custom field definition; its type cannot be changed
+func (s *CustomFieldService) UpdateField(fieldID, userID uuid.UUID, req *dto.UpdateCustomFieldRequest) (*dto.CustomFieldResponse, error) {
+	field, err := s.findFieldAsOwner(fieldID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	data := map[string]interface{}{}
+	if req.Name != nil {
+		name := strings.TrimSpace(*req.Name)
+		if name == "" {
+			return nil, apperrors.ErrInvalidCustomField
+		}
+		exists, err := s.CustomFieldRepo.NameExists(field.ProjectID, name, &field.ID)
+		if err != nil {
+			return nil, err
+		}
+		if exists {
+			return nil, apperrors.ErrCustomFieldExists
+		}
+		data["name"] = name
+	}
+	if req.Options != nil {
+		options, err := normalizeCustomFieldOptions(field.Type, *req.Options)
+		if err != nil {
+			return nil, err
+		}
+		data["options"] = options
+	}
+	if req.Required != nil {
+		data["required"] = *req.Required
+	}
+	if req.Position != nil {
+		data["position"] = *req.Position
+	}
+
+	if len(data) == 0 {
+		return nil, apperrors.ErrNoFieldsToUpdate
+	}
+
+	if err := s.CustomFieldRepo.Update(fieldID, data); err != nil {
+		return nil, err
+	}
+
+	// Log activity
+	if s.ActivityLogService != nil {
+		details := map[string]interface{}{"field_id": fieldID.String()}
+		if req.Name != nil {
+			details["name"] = data["name"]
+		}
+		if req.Options != nil {
+			details["options"] = *req.Options
+		}
+		if req.Required != nil {
+			details["required"] = *req.Required
+		}
+		s.ActivityLogService.LogActivity(field.ProjectID, userID, "custom_field.updated", details)
+	}
+
+	updated, err := s.CustomFieldRepo.FindByID(fieldID)
+	if err != nil {
+		return nil, err
+	}
+	return buildCustomFieldResponse(updated), nil
+}
+
+// DeleteField removes a custom field definition and all of its task values
+func (s *CustomFieldService) DeleteField(fieldID, userID uuid.UUID) error {
+	field, err := s.findFieldAsOwner(fieldID, userID)
+	if err != nil {
+		return err
+	}
+
+	if err := s.CustomFieldRepo.Delete(fieldID); err != nil {
+		return err
+	}
+
+	// Log activity
+	if s.ActivityLogService != nil {
+		s.ActivityLogService.LogActivity(field.ProjectID, userID, "custom_field.deleted", map[string]interface{}{
+			"field_id": fieldID.String(),
+			"name":     field.Name,
+		})
+	}
+
+	return nil
+}
+
+// findFieldAsOwner loads a custom field and ensures the user owns its project and that it is not archived
+func (s *CustomFieldService) findFieldAsOwner(fieldID, userID uuid.UUID) (*models.CustomField, error) {
+	field, err := s.CustomFieldRepo.FindByID(fieldID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, apperrors.ErrCustomFieldNotFound
+		}
+		return nil, err
+	}
+
+	isOwner, err := s.ProjectRepo.IsOwner(field.ProjectID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if !isOwner {
+		return nil, apperrors.ErrUnauthorizedOwnerOnly
+	}
+
+	archived, err := s.ProjectRepo.IsArchived(field.ProjectID)
+	if err != nil {
+		return nil, err
+	}
+	if archived {
+		return nil, apperrors.ErrProjectArchived
+	}
+	return field, nil
+}
+
+// normalizeCustomFieldOptions validates the options of a field and encodes them as JSON;
+// only select fields have options
+func normalizeCustomFieldOptions(fieldType string, options []string) ([]byte, error) {
+	isSelect := fieldType == models.CustomFieldTypeSingleSelect || fieldType == models.CustomFieldTypeMultiSelect
+	if !isSelect {
+		if len(options) > 0 {
+			return nil, apperrors.ErrInvalidCustomField
+		}
+		return nil, nil
+	}
+
+	var cleaned []string
+	seen := map[string]bool{}
+	for _, o := range options {
+		o = strings.TrimSpace(o)
+		if o == "" || seen[o] {
+			continue
+		}
+		seen[o] = true
+		cleaned = append(cleaned, o)
+	}
+	if len(cleaned) == 0 {
+		return nil, apperrors.ErrInvalidCustomField
+	}
+	return json.Marshal(cleaned)
+}
+
+// customFieldOptions decodes the options of a select field
+func customFieldOptions(field *models.CustomField) []string {
+	var options []string
+	if len(field.Options) > 0 {
+		_ = json.Unmarshal(field.Options, &options)
+	}
+	return options
+}
+
+// normalizeCustomFieldValue validates a JSON decoded value against its field definition and
+// returns the canonical value to store; isProjectUser checks user field values
+func normalizeCustomFieldValue(field *models.CustomField, raw interface{}, isProjectUser func(uuid.UUID) (bool, error)) (interface{}, error) {
+	switch field.Type {
+	case models.CustomFieldTypeText:
+		v, ok := raw.(string)
+		if !ok {
+			return nil, apperrors.ErrInvalidCustomFieldValue
+		}
+		return v, nil
+
+	case models.CustomFieldTypeNumber:
+		v, ok := raw.(float64)
+		if !ok {
+			return nil, apperrors.ErrInvalidCustomFieldValue
+		}
+		return v, nil
+
+	case models.CustomFieldTypeDate:
+		v, ok := raw.(string)
+		if !ok {
+			return nil, apperrors.ErrInvalidCustomFieldValue
+		}
+		return normalizeCustomFieldDate(v)
+
+	case models.CustomFieldTypeSingleSelect:
+		v, ok := raw.(string)
+		if !ok || !containsString(customFieldOptions(field), v) {
+			return nil, apperrors.ErrInvalidCustomFieldValue
+		}
+		return v, nil
+
+	case models.CustomFieldTypeMultiSelect:
+		items, ok := raw.([]interface{})
+		if !ok {
+			return nil, apperrors.ErrInvalidCustomFieldValue
+		}
+		options := customFieldOptions(field)
+		values := []string{}
+		seen := map[string]bool{}
+		for _, item := range items {
+			v, ok := item.(string)
+			if !ok || !containsString(options, v) {
+				return nil, apperrors.ErrInvalidCustomFieldValue
+			}
+			if !seen[v] {
+				seen[v] = true
+				values = append(values, v)
+			}
+		}
+		return values, nil
+
+	case models.CustomFieldTypeUser:
+		v, ok := raw.(string)
+		if !ok {
+			return nil, apperrors.ErrInvalidCustomFieldValue
+		}
+		id, err := uuid.Parse(v)
+		if err != nil {
+			return nil, apperrors.ErrInvalidCustomFieldValue
+		}
+		allowed, err := isProjectUser(id)
+		if err != nil {
+			return nil, err
+		}
+		if !allowed {
+			return nil, apperrors.ErrInvalidCustomFieldValue
+		}
+		return id.String(), nil
+
+	case models.CustomFieldTypeCheckbox:
+		v, ok := raw.(bool)
+		if !ok {
+			return nil, apperrors.ErrInvalidCustomFieldValue
+		}
+		return v, nil
+	}
+
+	return nil, apperrors.ErrInvalidCustomFieldValue
+}
+
+// parseCustomFieldFilter converts a filter value from the query string into the value compared in SQL
+func parseCustomFieldFilter(field *models.CustomField, raw string) (interface{}, error) {
+	switch field.Type {
+	case models.CustomFieldTypeText:
+		return "%" + utils.EscapeLikePattern(raw) + "%", nil
+	case models.CustomFieldTypeNumber:
+		v, err := strconv.ParseFloat(raw, 64)
+		if err != nil {
+			return nil, apperrors.ErrInvalidTaskQuery
+		}
+		return v, nil
+	case models.CustomFieldTypeDate:
+		v, err := normalizeCustomFieldDate(raw)
+		if err != nil {
+			return nil, apperrors.ErrInvalidTaskQuery
+		}
+		return v, nil
+	case models.CustomFieldTypeUser:
+		id, err := uuid.Parse(raw)
+		if err != nil {
+			return nil, apperrors.ErrInvalidTaskQuery
+		}
+		return id.String(), nil
+	case models.CustomFieldTypeCheckbox:
+		v, err := strconv.ParseBool(raw)
+		if err != nil {
+			return nil, apperrors.ErrInvalidTaskQuery
+		}
+		return v, nil
+	default:
+		return raw, nil
+	}
+}
+
+// normalizeCustomFieldDate accepts a date or an RFC 3339 timestamp and returns the date part
+func normalizeCustomFieldDate(value string) (string, error) {
+	if t, err := time.Parse("2006-01-02", value); err == nil {
+		return t.Format("2006-01-02"), nil
+	}
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return "", apperrors.ErrInvalidCustomFieldValue
+	}
+	return t.Format("2006-01-02"), nil
+}
+
+// containsString reports whether the slice contains the value
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+// buildCustomFieldResponse converts a custom field model into its response DTO
+func buildCustomFieldResponse(field *models.CustomField) *dto.CustomFieldResponse {
+	return &dto.CustomFieldResponse{
+		ID:        field.ID.String(),
+		ProjectID: field.ProjectID.String(),
+		Name:      field.Name,
+		Type:      field.Type,
+		Options:   customFieldOptions(field),
+		Required:  field.Required,
+		Position:  field.Position,
+		CreatedBy: field.CreatedBy.String(),
+		CreatedAt: field.CreatedAt,
+		UpdatedAt: field.UpdatedAt,
+	}
+}
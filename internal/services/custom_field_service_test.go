@@ -0,0 +1,102 @@
+package services
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	apperrors "github.com/Hann-arc/task-management-backend/internal/errors"
+	"github.com/Hann-arc/task-management-backend/internal/models"
+	"github.com/google/uuid"
+)
+
+func TestNormalizeCustomFieldValue(t *testing.T) {
+	member := uuid.New()
+	outsider := uuid.New()
+	isProjectUser := func(id uuid.UUID) (bool, error) {
+		return id == member, nil
+	}
+
+	selectOptions := []byte(`["Low","High"]`)
+
+	tests := []struct {
+		name      string
+		fieldType string
+		raw       interface{}
+		want      interface{}
+	}{
+		{"text", models.CustomFieldTypeText, "hello", "hello"},
+		{"text from number", models.CustomFieldTypeText, 3.0, nil},
+		{"number", models.CustomFieldTypeNumber, 4.5, 4.5},
+		{"number from string", models.CustomFieldTypeNumber, "4.5", nil},
+		{"date", models.CustomFieldTypeDate, "2025-03-01", "2025-03-01"},
+		{"date from timestamp", models.CustomFieldTypeDate, "2025-03-01T10:00:00Z", "2025-03-01"},
+		{"malformed date", models.CustomFieldTypeDate, "01/03/2025", nil},
+		{"single select", models.CustomFieldTypeSingleSelect, "High", "High"},
+		{"single select outside options", models.CustomFieldTypeSingleSelect, "Medium", nil},
+		{"multi select drops duplicates", models.CustomFieldTypeMultiSelect, []interface{}{"High", "Low", "High"}, []string{"High", "Low"}},
+		{"empty multi select", models.CustomFieldTypeMultiSelect, []interface{}{}, []string{}},
+		{"multi select outside options", models.CustomFieldTypeMultiSelect, []interface{}{"High", "Medium"}, nil},
+		{"multi select from string", models.CustomFieldTypeMultiSelect, "High", nil},
+		{"project user", models.CustomFieldTypeUser, member.String(), member.String()},
+		{"user outside the project", models.CustomFieldTypeUser, outsider.String(), nil},
+		{"malformed user", models.CustomFieldTypeUser, "someone", nil},
+		{"checkbox", models.CustomFieldTypeCheckbox, true, true},
+		{"checkbox from string", models.CustomFieldTypeCheckbox, "true", nil},
+		{"unknown type", "color", "red", nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			field := &models.CustomField{Type: tt.fieldType, Options: selectOptions}
+
+			got, err := normalizeCustomFieldValue(field, tt.raw, isProjectUser)
+			if tt.want == nil {
+				if !errors.Is(err, apperrors.ErrInvalidCustomFieldValue) {
+					t.Fatalf("expected ErrInvalidCustomFieldValue, got %v", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("expected %#v, got %#v", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestNormalizeCustomFieldOptions(t *testing.T) {
+	tests := []struct {
+		name      string
+		fieldType string
+		options   []string
+		want      string
+		wantErr   bool
+	}{
+		{"select options are trimmed and deduplicated", models.CustomFieldTypeSingleSelect, []string{" Low ", "High", "Low", ""}, `["Low","High"]`, false},
+		{"multi select", models.CustomFieldTypeMultiSelect, []string{"A"}, `["A"]`, false},
+		{"select without options", models.CustomFieldTypeMultiSelect, []string{" "}, "", true},
+		{"other types have no options", models.CustomFieldTypeText, nil, "", false},
+		{"options on another type", models.CustomFieldTypeNumber, []string{"1"}, "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := normalizeCustomFieldOptions(tt.fieldType, tt.options)
+			if tt.wantErr {
+				if !errors.Is(err, apperrors.ErrInvalidCustomField) {
+					t.Fatalf("expected ErrInvalidCustomField, got %v", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if string(got) != tt.want {
+				t.Errorf("expected %s, got %s", tt.want, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,64 @@
+package services
+
+import (
+	"errors"
+	"os"
+	"strings"
+
+	apperrors "github.com/Hann-arc/task-management-backend/internal/errors"
+	"github.com/Hann-arc/task-management-backend/internal/repository"
+	"github.com/Hann-arc/task-management-backend/internal/storage"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type FileService struct {
+	AttachmentRepo *repository.AttachmentRepository
+	Storage        storage.Storage
+}
+
+// NewFileService creates a new instance of FileService
+func NewFileService(attachmentRepo *repository.AttachmentRepository, store storage.Storage) *FileService {
+	return &FileService{AttachmentRepo: attachmentRepo, Storage: store}
+}
+
+// ResolveLocalFile returns the path of a file kept by the local storage driver once the user
+// is allowed to read it; avatars are public to signed-in users, attachments to project members
+func (s *FileService) ResolveLocalFile(key string, userID uuid.UUID) (string, error) {
+	local, ok := s.Storage.(*storage.LocalStorage)
+	if !ok {
+		return "", apperrors.ErrFileNotFound
+	}
+
+	filePath, err := local.Path(key)
+	if err != nil {
+		return "", apperrors.ErrFileNotFound
+	}
+
+	if !strings.HasPrefix(key, "avatars/") {
+		attachment, err := s.AttachmentRepo.FindByFileUrl(local.URL(key))
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return "", apperrors.ErrFileNotFound
+			}
+			return "", err
+		}
+
+		isMember, err := s.AttachmentRepo.IsTaskMember(attachment.TaskID, userID)
+		if err != nil {
+			return "", err
+		}
+		if !isMember {
+			return "", apperrors.ErrUnauthorizedProject
+		}
+	}
+
+	if _, err := os.Stat(filePath); err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return "", apperrors.ErrFileNotFound
+		}
+		return "", err
+	}
+
+	return filePath, nil
+}
@@ -0,0 +1,189 @@
+package services
+
+import (
+	"strings"
+
+	"github.com/Hann-arc/task-management-backend/internal/dto"
+	"github.com/Hann-arc/task-management-backend/internal/models"
+	"github.com/Hann-arc/task-management-backend/internal/repository"
+	"github.com/Hann-arc/task-management-backend/internal/utils"
+	"github.com/google/uuid"
+)
+
+type MentionService struct {
+	MentionRepo         *repository.MentionRepository
+	NotificationService *NotificationService
+}
+
+// mentionTarget is a project member resolved from a mention handle
+type mentionTarget struct {
+	User   models.User
+	Handle string
+}
+
+// NewMentionService creates a new instance of MentionService
+func NewMentionService(mentionRepo *repository.MentionRepository, notificationService *NotificationService) *MentionService {
+	return &MentionService{MentionRepo: mentionRepo, NotificationService: notificationService}
+}
+
+// SyncCommentMentions stores the project members mentioned in a comment and notifies the ones
+// who were not mentioned in it before
+func (s *MentionService) SyncCommentMentions(comment *models.Comment, projectID, actorID uuid.UUID) error {
+	targets, err := s.resolve(projectID, comment.Content)
+	if err != nil {
+		return err
+	}
+
+	previous, err := s.MentionRepo.FindCommentMentionIDs(comment.ID)
+	if err != nil {
+		return err
+	}
+
+	mentions := make([]models.CommentMention, 0, len(targets))
+	for _, target := range targets {
+		mentions = append(mentions, models.CommentMention{
+			ID:        uuid.New(),
+			CommentID: comment.ID,
+			UserID:    target.User.ID,
+			Handle:    target.Handle,
+		})
+	}
+	if err := s.MentionRepo.ReplaceCommentMentions(comment.ID, mentions); err != nil {
+		return err
+	}
+
+	// the resolved users are attached after saving so the response can show their names
+	for i := range mentions {
+		mentions[i].User = targets[i].User
+	}
+	comment.Mentions = mentions
+
+	s.notify(targets, previous, actorID, "comment.mentioned", comment.TaskID, "You were mentioned in a comment")
+	return nil
+}
+
+// SyncTaskMentions stores the project members mentioned in a task description and notifies the
+// ones who were not mentioned in it before
+func (s *MentionService) SyncTaskMentions(task *models.Task, projectID, actorID uuid.UUID) error {
+	targets, err := s.resolve(projectID, task.Description)
+	if err != nil {
+		return err
+	}
+
+	previous, err := s.MentionRepo.FindTaskMentionIDs(task.ID)
+	if err != nil {
+		return err
+	}
+
+	mentions := make([]models.TaskMention, 0, len(targets))
+	for _, target := range targets {
+		mentions = append(mentions, models.TaskMention{
+			ID:     uuid.New(),
+			TaskID: task.ID,
+			UserID: target.User.ID,
+			Handle: target.Handle,
+		})
+	}
+	if err := s.MentionRepo.ReplaceTaskMentions(task.ID, mentions); err != nil {
+		return err
+	}
+
+	s.notify(targets, previous, actorID, "task.mentioned", task.ID, "You were mentioned in a task")
+	return nil
+}
+
+// resolve matches the handles mentioned in a text against the project's owner and members;
+// "@email" matches the address, any other handle the member's name or email local part, and
+// handles matching more than one member are ignored
+func (s *MentionService) resolve(projectID uuid.UUID, text string) ([]mentionTarget, error) {
+	handles := utils.ParseMentions(text)
+	if len(handles) == 0 {
+		return nil, nil
+	}
+
+	users, err := s.MentionRepo.FindProjectUsers(projectID)
+	if err != nil {
+		return nil, err
+	}
+
+	var targets []mentionTarget
+	seen := map[uuid.UUID]bool{}
+	for _, handle := range handles {
+		var matches []models.User
+		for _, user := range users {
+			if mentionMatches(handle, user) {
+				matches = append(matches, user)
+			}
+		}
+		if len(matches) != 1 || seen[matches[0].ID] {
+			continue
+		}
+
+		seen[matches[0].ID] = true
+		targets = append(targets, mentionTarget{User: matches[0], Handle: "@" + handle})
+	}
+	return targets, nil
+}
+
+// notify sends a mention notification to the resolved users that were not mentioned before
+func (s *MentionService) notify(targets []mentionTarget, previous []uuid.UUID, actorID uuid.UUID, action string, taskID uuid.UUID, message string) {
+	if s.NotificationService == nil {
+		return
+	}
+
+	already := map[uuid.UUID]bool{}
+	for _, id := range previous {
+		already[id] = true
+	}
+
+	var recipients []uuid.UUID
+	for _, target := range targets {
+		if !already[target.User.ID] {
+			recipients = append(recipients, target.User.ID)
+		}
+	}
+	if len(recipients) > 0 {
+		go s.NotificationService.NotifyUsers(recipients, actorID, action, "task", taskID, message)
+	}
+}
+
+// mentionMatches reports whether a handle refers to the user
+func mentionMatches(handle string, user models.User) bool {
+	if strings.Contains(handle, "@") {
+		return strings.EqualFold(handle, user.Email)
+	}
+
+	normalized := utils.NormalizeMentionHandle(handle)
+	if normalized == "" {
+		return false
+	}
+
+	localPart, _, _ := strings.Cut(user.Email, "@")
+	return normalized == utils.NormalizeMentionHandle(user.Name) || normalized == utils.NormalizeMentionHandle(localPart)
+}
+
+// buildMentionResponses maps the mentions of a comment to their responses
+func buildMentionResponses(mentions []models.CommentMention) []dto.MentionResponse {
+	result := make([]dto.MentionResponse, 0, len(mentions))
+	for _, m := range mentions {
+		result = append(result, dto.MentionResponse{
+			UserID: m.UserID.String(),
+			Handle: m.Handle,
+			Name:   m.User.Name,
+		})
+	}
+	return result
+}
+
+// buildTaskMentionResponses maps the mentions of a task description to their responses
+func buildTaskMentionResponses(mentions []models.TaskMention) []dto.MentionResponse {
+	result := make([]dto.MentionResponse, 0, len(mentions))
+	for _, m := range mentions {
+		result = append(result, dto.MentionResponse{
+			UserID: m.UserID.String(),
+			Handle: m.Handle,
+			Name:   m.User.Name,
+		})
+	}
+	return result
+}
@@ -0,0 +1,33 @@
+package services
+
+import (
+	"testing"
+
+	"github.com/Hann-arc/task-management-backend/internal/models"
+)
+
+func TestMentionMatches(t *testing.T) {
+	user := models.User{Name: "Jane Doe", Email: "j.smith@example.com"}
+
+	tests := []struct {
+		name   string
+		handle string
+		want   bool
+	}{
+		{"name without spaces", "janedoe", true},
+		{"name with punctuation", "Jane.Doe", true},
+		{"email local part", "jsmith", true},
+		{"full email", "J.Smith@Example.com", true},
+		{"other email", "jane@example.com", false},
+		{"partial name", "jane", false},
+		{"punctuation only", "._", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := mentionMatches(tt.handle, user); got != tt.want {
+				t.Errorf("expected %v, got %v", tt.want, got)
+			}
+		})
+	}
+}
@@ -1,6 +1,8 @@
 package services
 
 import (
+	"log"
+
 	"github.com/Hann-arc/task-management-backend/internal/dto"
 	"github.com/Hann-arc/task-management-backend/internal/models"
 	"github.com/Hann-arc/task-management-backend/internal/repository"
@@ -56,6 +58,27 @@ func (s *NotificationService) CreateNotification(
 	return nil
 }
 
+// NotifyUsers sends the same notification to every user in the list except the actor
+func (s *NotificationService) NotifyUsers(
+	userIDs []uuid.UUID,
+	actorID uuid.UUID,
+	action, referenceType string,
+	relatedID uuid.UUID,
+	message string,
+) {
+	seen := map[uuid.UUID]bool{actorID: true}
+	for _, userID := range userIDs {
+		if seen[userID] {
+			continue
+		}
+		seen[userID] = true
+
+		if err := s.CreateNotification(userID, actorID, action, referenceType, relatedID, message); err != nil {
+			log.Printf("Failed to send %s notification to %s: %v", action, userID, err)
+		}
+	}
+}
+
 // GetNotifications retrieves notifications for a specific user with pagination
 func (s *NotificationService) GetNotifications(userID uuid.UUID, limit, offset int) ([]dto.NotificationResponse, error) {
 	notifications, err := s.Repo.FindByUserID(userID, limit, offset)
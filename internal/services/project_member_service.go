@@ -166,14 +166,32 @@ func (s *ProjectMemberService) RemoveMember(projectID, ownerID, targetUserID uui
 		return err
 	}
 
+	// a removed member can no longer open the project, so their assignments go with them
+	unassignedTaskIDs, err := s.ProjectMemberRepo.DeleteWithAssignments(projectID, targetUserID)
+	if err != nil {
+		return err
+	}
+
 	// Log activity
 	if s.ActivityLogService != nil {
 		s.ActivityLogService.LogActivity(projectID, ownerID, "member.removed", map[string]interface{}{
 			"member_id": targetUserID.String(),
 		})
+
+		if len(unassignedTaskIDs) > 0 {
+			taskIDs := make([]string, 0, len(unassignedTaskIDs))
+			for _, id := range unassignedTaskIDs {
+				taskIDs = append(taskIDs, id.String())
+			}
+			s.ActivityLogService.LogActivity(projectID, ownerID, "task.unassigned", map[string]interface{}{
+				"member_id": targetUserID.String(),
+				"task_ids":  taskIDs,
+				"reason":    "member_removed",
+			})
+		}
 	}
 
-	return s.ProjectMemberRepo.Delete(projectID, targetUserID)
+	return nil
 }
 
 // buildMemberResponse builds a response for a project member
@@ -2,6 +2,8 @@ package services
 
 import (
 	"errors"
+	"strings"
+	"time"
 
 	"github.com/Hann-arc/task-management-backend/internal/dto"
 	apperrors "github.com/Hann-arc/task-management-backend/internal/errors"
@@ -49,15 +51,17 @@ func (s *ProjectService) CreateProject(req *dto.CreateProjectRequest, ownerID uu
 		Name:        project.Name,
 		Description: project.Description,
 		OwnerID:     project.OwnerID.String(),
+		IsTemplate:  project.IsTemplate,
+		ArchivedAt:  project.ArchivedAt,
 		CreatedAt:   project.CreatedAt,
 		UpdatedAt:   project.UpdatedAt,
 		DeletedAt:   utils.ToTimePtr(project.DeletedAt),
 	}, nil
 }
 
-// GetProjects retrieves all projects for a specific user
-func (s *ProjectService) GetProjects(userID uuid.UUID) ([]dto.ProjectResponse, error) {
-	projects, err := s.ProjectRepo.FindAllByUser(userID)
+// GetProjects retrieves the active or the archived projects of a specific user
+func (s *ProjectService) GetProjects(userID uuid.UUID, archived bool) ([]dto.ProjectResponse, error) {
+	projects, err := s.ProjectRepo.FindAllByUser(userID, archived)
 	if err != nil {
 		return nil, err
 	}
@@ -69,6 +73,8 @@ func (s *ProjectService) GetProjects(userID uuid.UUID) ([]dto.ProjectResponse, e
 			Name:        p.Name,
 			Description: p.Description,
 			OwnerID:     p.OwnerID.String(),
+			IsTemplate:  p.IsTemplate,
+			ArchivedAt:  p.ArchivedAt,
 			CreatedAt:   p.CreatedAt,
 			UpdatedAt:   p.UpdatedAt,
 			DeletedAt:   utils.ToTimePtr(p.DeletedAt),
@@ -125,6 +131,8 @@ func (s *ProjectService) GetProjectDetail(projectID, userID uuid.UUID) (*dto.Pro
 		Name:        project.Name,
 		Description: project.Description,
 		Owner:       ownerResp,
+		IsTemplate:  project.IsTemplate,
+		ArchivedAt:  project.ArchivedAt,
 		Boards:      boards,
 		CreatedAt:   project.CreatedAt,
 		UpdatedAt:   project.UpdatedAt,
@@ -132,6 +140,84 @@ func (s *ProjectService) GetProjectDetail(projectID, userID uuid.UUID) (*dto.Pro
 	}, nil
 }
 
+// GetProjectStats computes the dashboard statistics of a project with aggregate queries
+func (s *ProjectService) GetProjectStats(projectID, userID uuid.UUID) (*dto.ProjectStatsResponse, error) {
+	isOwner, err := s.ProjectRepo.IsOwner(projectID, userID)
+	if err != nil {
+		return nil, err
+	}
+	isMember := false
+	if !isOwner {
+		isMember, err = s.ProjectRepo.IsMember(projectID, userID)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if !isOwner && !isMember {
+		return nil, apperrors.ErrUnauthorizedProject
+	}
+
+	now := time.Now()
+
+	totals, err := s.ProjectRepo.CountTaskTotals(projectID, now)
+	if err != nil {
+		return nil, err
+	}
+	byBoard, err := s.ProjectRepo.CountTasksByBoard(projectID, now)
+	if err != nil {
+		return nil, err
+	}
+	byPriority, err := s.ProjectRepo.CountTasksByPriority(projectID, now)
+	if err != nil {
+		return nil, err
+	}
+	byAssignee, err := s.ProjectRepo.CountTasksByAssignee(projectID, now)
+	if err != nil {
+		return nil, err
+	}
+	activeMembers, err := s.ProjectRepo.FindMostActiveMembers(projectID, now.AddDate(0, 0, -30), 5)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &dto.ProjectStatsResponse{
+		ProjectID:         projectID.String(),
+		Totals:            dto.ProjectTaskTotals(*totals),
+		ByBoard:           buildTaskCountStats(byBoard, true),
+		ByPriority:        buildTaskCountStats(byPriority, false),
+		ByAssignee:        buildTaskCountStats(byAssignee, true),
+		MostActiveMembers: []dto.MemberActivityStats{},
+		GeneratedAt:       now,
+	}
+	for _, m := range activeMembers {
+		resp.MostActiveMembers = append(resp.MostActiveMembers, dto.MemberActivityStats{
+			UserID:  m.UserID.String(),
+			Name:    m.Name,
+			Actions: m.Actions,
+		})
+	}
+
+	return resp, nil
+}
+
+// buildTaskCountStats converts aggregated task counters into their response DTOs
+func buildTaskCountStats(rows []repository.TaskCountRow, withID bool) []dto.TaskCountStats {
+	result := []dto.TaskCountStats{}
+	for _, row := range rows {
+		stats := dto.TaskCountStats{
+			Name:    row.Name,
+			Total:   row.Total,
+			Open:    row.Open,
+			Overdue: row.Overdue,
+		}
+		if withID {
+			stats.ID = row.ID.String()
+		}
+		result = append(result, stats)
+	}
+	return result
+}
+
 // UpdateProject updates the details of a specific project
 func (s *ProjectService) UpdateProject(projectID, userID uuid.UUID, req *dto.UpdateProjectRequest) (*dto.ProjectResponse, error) {
 	isOwner, err := s.ProjectRepo.IsOwner(projectID, userID)
@@ -146,6 +232,14 @@ func (s *ProjectService) UpdateProject(projectID, userID uuid.UUID, req *dto.Upd
 		return nil, apperrors.ErrNoFieldsToUpdate
 	}
 
+	archived, err := s.ProjectRepo.IsArchived(projectID)
+	if err != nil {
+		return nil, err
+	}
+	if archived {
+		return nil, apperrors.ErrProjectArchived
+	}
+
 	data := map[string]interface{}{}
 	if req.Name != nil {
 		data["name"] = *req.Name
@@ -182,12 +276,57 @@ func (s *ProjectService) UpdateProject(projectID, userID uuid.UUID, req *dto.Upd
 		Name:        updatedProject.Name,
 		Description: updatedProject.Description,
 		OwnerID:     updatedProject.OwnerID.String(),
+		IsTemplate:  updatedProject.IsTemplate,
+		ArchivedAt:  updatedProject.ArchivedAt,
 		CreatedAt:   updatedProject.CreatedAt,
 		UpdatedAt:   updatedProject.UpdatedAt,
 		DeletedAt:   utils.ToTimePtr(updatedProject.DeletedAt),
 	}, nil
 }
 
+// ArchiveProject makes a project read-only and hides it from the default project listing
+func (s *ProjectService) ArchiveProject(projectID, userID uuid.UUID) (*dto.ProjectResponse, error) {
+	now := time.Now()
+	return s.setArchived(projectID, userID, &now, "project.archived")
+}
+
+// UnarchiveProject restores an archived project to the active state
+func (s *ProjectService) UnarchiveProject(projectID, userID uuid.UUID) (*dto.ProjectResponse, error) {
+	return s.setArchived(projectID, userID, nil, "project.unarchived")
+}
+
+// setArchived changes the archived state of a project; only the project owner can do it
+func (s *ProjectService) setArchived(projectID, userID uuid.UUID, archivedAt *time.Time, action string) (*dto.ProjectResponse, error) {
+	isOwner, err := s.ProjectRepo.IsOwner(projectID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if !isOwner {
+		return nil, apperrors.ErrUnauthorizedOwnerOnly
+	}
+
+	if err := s.ProjectRepo.SetArchived(projectID, archivedAt); err != nil {
+		return nil, err
+	}
+
+	project, err := s.ProjectRepo.FindByID(projectID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, apperrors.ErrProjectNotFound
+		}
+		return nil, err
+	}
+
+	// Log activity
+	if s.ActivityLogService != nil {
+		s.ActivityLogService.LogActivity(projectID, userID, action, map[string]interface{}{
+			"project_id": projectID.String(),
+		})
+	}
+
+	return buildProjectResponse(project), nil
+}
+
 // DeleteProject deletes a specific project
 func (s *ProjectService) DeleteProject(projectID, userID uuid.UUID) error {
 	isOwner, err := s.ProjectRepo.IsOwner(projectID, userID)
@@ -207,3 +346,169 @@ func (s *ProjectService) DeleteProject(projectID, userID uuid.UUID) error {
 
 	return s.ProjectRepo.SoftDelete(projectID)
 }
+
+// DuplicateProject copies a project into a new one owned by the user; the owner can copy any
+// project, members can only create projects from templates
+func (s *ProjectService) DuplicateProject(projectID, userID uuid.UUID, req *dto.DuplicateProjectRequest) (*dto.ProjectResponse, error) {
+	source, err := s.findCopySource(projectID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	name := strings.TrimSpace(req.Name)
+	if name == "" {
+		return nil, apperrors.ErrInvalidProjectCopy
+	}
+	description := source.Description
+	if req.Description != nil {
+		description = *req.Description
+	}
+
+	shiftDays := 0
+	if req.StartDate != nil && *req.StartDate != "" {
+		startDate, err := time.Parse("2006-01-02", *req.StartDate)
+		if err != nil {
+			return nil, apperrors.ErrInvalidProjectCopy
+		}
+		shiftDays = daysBetween(source.CreatedAt, startDate)
+	}
+
+	project, err := s.ProjectRepo.Duplicate(projectID, repository.ProjectCopyOptions{
+		Name:             name,
+		Description:      description,
+		OwnerID:          userID,
+		IncludeTasks:     req.IncludeTasks,
+		IncludeMembers:   req.IncludeMembers,
+		DueDateShiftDays: shiftDays,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// Log activity
+	if s.ActivityLogService != nil {
+		s.ActivityLogService.LogActivity(project.ID, userID, "project.duplicated", map[string]interface{}{
+			"project_id":        project.ID.String(),
+			"source_project_id": projectID.String(),
+			"name":              project.Name,
+			"include_tasks":     req.IncludeTasks,
+			"include_members":   req.IncludeMembers,
+		})
+	}
+
+	return buildProjectResponse(project), nil
+}
+
+// SaveAsTemplate copies a project into a new project template; members are never carried over
+// and task due dates keep their offset from the source project's creation
+func (s *ProjectService) SaveAsTemplate(projectID, userID uuid.UUID, req *dto.SaveProjectTemplateRequest) (*dto.ProjectResponse, error) {
+	isOwner, err := s.ProjectRepo.IsOwner(projectID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if !isOwner {
+		return nil, apperrors.ErrUnauthorizedOwnerOnly
+	}
+
+	source, err := s.ProjectRepo.FindByID(projectID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, apperrors.ErrProjectNotFound
+		}
+		return nil, err
+	}
+
+	name := strings.TrimSpace(req.Name)
+	if name == "" {
+		return nil, apperrors.ErrInvalidProjectCopy
+	}
+	description := source.Description
+	if req.Description != nil {
+		description = *req.Description
+	}
+
+	template, err := s.ProjectRepo.Duplicate(projectID, repository.ProjectCopyOptions{
+		Name:             name,
+		Description:      description,
+		OwnerID:          userID,
+		IsTemplate:       true,
+		IncludeTasks:     req.IncludeTasks,
+		DueDateShiftDays: daysBetween(source.CreatedAt, time.Now()),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// Log activity
+	if s.ActivityLogService != nil {
+		s.ActivityLogService.LogActivity(projectID, userID, "project.saved_as_template", map[string]interface{}{
+			"project_id":  projectID.String(),
+			"template_id": template.ID.String(),
+			"name":        template.Name,
+		})
+	}
+
+	return buildProjectResponse(template), nil
+}
+
+// GetTemplates retrieves the project templates available to the user
+func (s *ProjectService) GetTemplates(userID uuid.UUID) ([]dto.ProjectResponse, error) {
+	templates, err := s.ProjectRepo.FindTemplatesByUser(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	result := []dto.ProjectResponse{}
+	for _, t := range templates {
+		result = append(result, *buildProjectResponse(&t))
+	}
+	return result, nil
+}
+
+// findCopySource loads the project to duplicate and checks the user may copy it
+func (s *ProjectService) findCopySource(projectID, userID uuid.UUID) (*models.Project, error) {
+	source, err := s.ProjectRepo.FindByID(projectID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, apperrors.ErrProjectNotFound
+		}
+		return nil, err
+	}
+
+	if source.OwnerID == userID {
+		return source, nil
+	}
+	if !source.IsTemplate {
+		return nil, apperrors.ErrUnauthorizedOwnerOnly
+	}
+	isMember, err := s.ProjectRepo.IsMember(projectID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if !isMember {
+		return nil, apperrors.ErrUnauthorizedProject
+	}
+	return source, nil
+}
+
+// daysBetween returns the number of calendar days from the date of one time to the date of another
+func daysBetween(from, to time.Time) int {
+	fromDay := time.Date(from.Year(), from.Month(), from.Day(), 0, 0, 0, 0, time.UTC)
+	toDay := time.Date(to.Year(), to.Month(), to.Day(), 0, 0, 0, 0, time.UTC)
+	return int(toDay.Sub(fromDay).Hours() / 24)
+}
+
+// buildProjectResponse converts a project model into its response DTO
+func buildProjectResponse(project *models.Project) *dto.ProjectResponse {
+	return &dto.ProjectResponse{
+		ID:          project.ID.String(),
+		Name:        project.Name,
+		Description: project.Description,
+		OwnerID:     project.OwnerID.String(),
+		IsTemplate:  project.IsTemplate,
+		ArchivedAt:  project.ArchivedAt,
+		CreatedAt:   project.CreatedAt,
+		UpdatedAt:   project.UpdatedAt,
+		DeletedAt:   utils.ToTimePtr(project.DeletedAt),
+	}
+}
@@ -0,0 +1,113 @@
+package services
+
+import (
+	"html"
+	"strings"
+
+	"github.com/Hann-arc/task-management-backend/internal/dto"
+	apperrors "github.com/Hann-arc/task-management-backend/internal/errors"
+	"github.com/Hann-arc/task-management-backend/internal/repository"
+	"github.com/google/uuid"
+)
+
+type SearchService struct {
+	SearchRepo *repository.SearchRepository
+}
+
+// NewSearchService creates a new instance of SearchService
+func NewSearchService(searchRepo *repository.SearchRepository) *SearchService {
+	return &SearchService{SearchRepo: searchRepo}
+}
+
+// Search runs a full-text search across the projects the user belongs to, grouped by entity type
+func (s *SearchService) Search(userID uuid.UUID, query *dto.SearchQuery) (*dto.SearchResponse, error) {
+	q := strings.TrimSpace(query.Query)
+	if q == "" {
+		return nil, apperrors.ErrEmptySearchQuery
+	}
+
+	types := map[string]bool{"task": true, "comment": true, "project": true}
+	if query.Types != "" {
+		types = map[string]bool{}
+		for _, t := range strings.Split(query.Types, ",") {
+			t = strings.TrimSpace(t)
+			if t != "task" && t != "comment" && t != "project" {
+				return nil, apperrors.ErrInvalidSearchType
+			}
+			types[t] = true
+		}
+	}
+
+	limit := query.Limit
+	if limit <= 0 {
+		limit = 10
+	}
+	if limit > 50 {
+		limit = 50
+	}
+
+	result := &dto.SearchResponse{
+		Tasks:    []dto.TaskSearchResult{},
+		Comments: []dto.CommentSearchResult{},
+		Projects: []dto.ProjectSearchResult{},
+	}
+
+	if types["task"] {
+		rows, err := s.SearchRepo.SearchTasks(userID, q, limit)
+		if err != nil {
+			return nil, err
+		}
+		for _, r := range rows {
+			result.Tasks = append(result.Tasks, dto.TaskSearchResult{
+				ID:        r.ID.String(),
+				Title:     r.Title,
+				BoardID:   r.BoardID.String(),
+				ProjectID: r.ProjectID.String(),
+				Rank:      r.Rank,
+				Highlight: renderHighlight(r.Highlight),
+			})
+		}
+	}
+
+	if types["comment"] {
+		rows, err := s.SearchRepo.SearchComments(userID, q, limit)
+		if err != nil {
+			return nil, err
+		}
+		for _, r := range rows {
+			result.Comments = append(result.Comments, dto.CommentSearchResult{
+				ID:        r.ID.String(),
+				TaskID:    r.TaskID.String(),
+				ProjectID: r.ProjectID.String(),
+				UserID:    r.UserID.String(),
+				Rank:      r.Rank,
+				Highlight: renderHighlight(r.Highlight),
+			})
+		}
+	}
+
+	if types["project"] {
+		rows, err := s.SearchRepo.SearchProjects(userID, q, limit)
+		if err != nil {
+			return nil, err
+		}
+		for _, r := range rows {
+			result.Projects = append(result.Projects, dto.ProjectSearchResult{
+				ID:        r.ID.String(),
+				Name:      r.Name,
+				Rank:      r.Rank,
+				Highlight: renderHighlight(r.Highlight),
+			})
+		}
+	}
+
+	return result, nil
+}
+
+// renderHighlight escapes a search headline and turns its markers into <mark> tags, so user
+// content is never sent as markup
+func renderHighlight(headline string) string {
+	escaped := html.EscapeString(headline)
+	escaped = strings.ReplaceAll(escaped, repository.HighlightStart, "<mark>")
+	return strings.ReplaceAll(escaped, repository.HighlightStop, "</mark>")
+}
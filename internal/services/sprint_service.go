@@ -0,0 +1,621 @@
+package services
+
+import (
+	"errors"
+	"time"
+
+	"github.com/Hann-arc/task-management-backend/internal/dto"
+	apperrors "github.com/Hann-arc/task-management-backend/internal/errors"
+	"github.com/Hann-arc/task-management-backend/internal/models"
+	"github.com/Hann-arc/task-management-backend/internal/repository"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// sprintBoardStatuses are the columns of a sprint board, in display order
+var sprintBoardStatuses = []string{models.TaskStatusTodo, models.TaskStatusInProgress, models.TaskStatusDone}
+
+type SprintService struct {
+	SprintRepo         *repository.SprintRepository
+	TaskRepo           *repository.TaskRepository
+	ProjectRepo        *repository.ProjectRepository
+	TaskService        *TaskService
+	ActivityLogService *ActivityLogService
+}
+
+// NewSprintService creates a new instance of SprintService
+func NewSprintService(
+	sprintRepo *repository.SprintRepository,
+	taskRepo *repository.TaskRepository,
+	projectRepo *repository.ProjectRepository,
+	taskService *TaskService,
+	activityLogService *ActivityLogService,
+) *SprintService {
+	return &SprintService{
+		SprintRepo:         sprintRepo,
+		TaskRepo:           taskRepo,
+		ProjectRepo:        projectRepo,
+		TaskService:        taskService,
+		ActivityLogService: activityLogService,
+	}
+}
+
+// CreateSprint plans a new sprint in a project; only the project owner can manage sprints
+func (s *SprintService) CreateSprint(projectID, userID uuid.UUID, req *dto.CreateSprintRequest) (*dto.SprintResponse, error) {
+	if err := s.checkOwner(projectID, userID); err != nil {
+		return nil, err
+	}
+	if err := s.checkNotArchived(projectID); err != nil {
+		return nil, err
+	}
+
+	if req.Name == "" {
+		return nil, apperrors.ErrInvalidSprintData
+	}
+	startDate, endDate, err := parseSprintDates(req.StartDate, req.EndDate)
+	if err != nil {
+		return nil, err
+	}
+
+	sprint := &models.Sprint{
+		ID:        uuid.New(),
+		ProjectID: projectID,
+		Name:      req.Name,
+		Goal:      req.Goal,
+		StartDate: startDate,
+		EndDate:   endDate,
+		State:     models.SprintStatePlanned,
+		CreatedBy: userID,
+	}
+
+	if err := s.SprintRepo.Create(sprint); err != nil {
+		return nil, err
+	}
+
+	// Log activity
+	if s.ActivityLogService != nil {
+		s.ActivityLogService.LogActivity(projectID, userID, "sprint.created", map[string]interface{}{
+			"sprint_id":  sprint.ID.String(),
+			"name":       sprint.Name,
+			"start_date": sprint.StartDate,
+			"end_date":   sprint.EndDate,
+		})
+	}
+
+	return s.buildSprintResponse(sprint)
+}
+
+// GetSprints retrieves all sprints of a project
+func (s *SprintService) GetSprints(projectID, userID uuid.UUID) ([]dto.SprintResponse, error) {
+	if err := s.checkMember(projectID, userID); err != nil {
+		return nil, err
+	}
+
+	sprints, err := s.SprintRepo.FindByProjectID(projectID)
+	if err != nil {
+		return nil, err
+	}
+
+	result := []dto.SprintResponse{}
+	for _, sprint := range sprints {
+		resp, err := s.buildSprintResponse(&sprint)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, *resp)
+	}
+	return result, nil
+}
+
+// GetSprint retrieves a single sprint with its point totals
+func (s *SprintService) GetSprint(sprintID, userID uuid.UUID) (*dto.SprintResponse, error) {
+	sprint, err := s.findSprint(sprintID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.checkMember(sprint.ProjectID, userID); err != nil {
+		return nil, err
+	}
+
+	return s.buildSprintResponse(sprint)
+}
+
+// UpdateSprint modifies the details of a sprint that has not been closed
+func (s *SprintService) UpdateSprint(sprintID, userID uuid.UUID, req *dto.UpdateSprintRequest) (*dto.SprintResponse, error) {
+	sprint, err := s.findSprint(sprintID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.checkOwner(sprint.ProjectID, userID); err != nil {
+		return nil, err
+	}
+	if err := s.checkNotArchived(sprint.ProjectID); err != nil {
+		return nil, err
+	}
+	if sprint.State == models.SprintStateClosed {
+		return nil, apperrors.ErrInvalidSprintState
+	}
+
+	data := map[string]interface{}{}
+	if req.Name != nil {
+		if *req.Name == "" {
+			return nil, apperrors.ErrInvalidSprintData
+		}
+		data["name"] = *req.Name
+	}
+	if req.Goal != nil {
+		data["goal"] = *req.Goal
+	}
+
+	startDate := sprint.StartDate.Format(time.RFC3339)
+	endDate := sprint.EndDate.Format(time.RFC3339)
+	if req.StartDate != nil {
+		startDate = *req.StartDate
+	}
+	if req.EndDate != nil {
+		endDate = *req.EndDate
+	}
+	if req.StartDate != nil || req.EndDate != nil {
+		start, end, err := parseSprintDates(startDate, endDate)
+		if err != nil {
+			return nil, err
+		}
+		data["start_date"] = start
+		data["end_date"] = end
+	}
+
+	if len(data) == 0 {
+		return nil, apperrors.ErrNoFieldsToUpdate
+	}
+
+	if err := s.SprintRepo.Update(sprintID, data); err != nil {
+		return nil, err
+	}
+
+	// Log activity
+	if s.ActivityLogService != nil {
+		details := map[string]interface{}{"sprint_id": sprintID.String()}
+		for k, v := range data {
+			details[k] = v
+		}
+		s.ActivityLogService.LogActivity(sprint.ProjectID, userID, "sprint.updated", details)
+	}
+
+	updated, err := s.findSprint(sprintID)
+	if err != nil {
+		return nil, err
+	}
+	return s.buildSprintResponse(updated)
+}
+
+// DeleteSprint removes a planned sprint and moves its tasks back to the backlog
+func (s *SprintService) DeleteSprint(sprintID, userID uuid.UUID) error {
+	sprint, err := s.findSprint(sprintID)
+	if err != nil {
+		return err
+	}
+	if err := s.checkOwner(sprint.ProjectID, userID); err != nil {
+		return err
+	}
+	if err := s.checkNotArchived(sprint.ProjectID); err != nil {
+		return err
+	}
+
+	// active and closed sprints are kept for velocity and reporting
+	if sprint.State != models.SprintStatePlanned {
+		return apperrors.ErrInvalidSprintState
+	}
+
+	if err := s.SprintRepo.Delete(sprintID); err != nil {
+		return err
+	}
+
+	// Log activity
+	if s.ActivityLogService != nil {
+		s.ActivityLogService.LogActivity(sprint.ProjectID, userID, "sprint.deleted", map[string]interface{}{
+			"sprint_id": sprintID.String(),
+			"name":      sprint.Name,
+		})
+	}
+
+	return nil
+}
+
+// StartSprint activates a planned sprint; a project can only have one active sprint
+func (s *SprintService) StartSprint(sprintID, userID uuid.UUID) (*dto.SprintResponse, error) {
+	sprint, err := s.findSprint(sprintID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.checkOwner(sprint.ProjectID, userID); err != nil {
+		return nil, err
+	}
+	if err := s.checkNotArchived(sprint.ProjectID); err != nil {
+		return nil, err
+	}
+	if sprint.State != models.SprintStatePlanned {
+		return nil, apperrors.ErrInvalidSprintState
+	}
+
+	if _, err := s.findActiveSprint(sprint.ProjectID); err == nil {
+		return nil, apperrors.ErrActiveSprintExists
+	} else if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, err
+	}
+
+	// the partial unique index rejects a sprint started concurrently
+	if err := s.SprintRepo.Start(sprint); err != nil {
+		if _, findErr := s.findActiveSprint(sprint.ProjectID); findErr == nil {
+			return nil, apperrors.ErrActiveSprintExists
+		}
+		return nil, err
+	}
+
+	// Log activity
+	if s.ActivityLogService != nil {
+		s.ActivityLogService.LogActivity(sprint.ProjectID, userID, "sprint.started", map[string]interface{}{
+			"sprint_id":        sprintID.String(),
+			"name":             sprint.Name,
+			"committed_points": sprint.CommittedPoints,
+		})
+	}
+
+	return s.buildSprintResponse(sprint)
+}
+
+// CloseSprint closes an active sprint, records its velocity and rolls unfinished tasks into
+// the requested sprint, the next planned sprint, or the backlog if there is none
+func (s *SprintService) CloseSprint(sprintID, userID uuid.UUID, req *dto.CloseSprintRequest) (*dto.CloseSprintResponse, error) {
+	sprint, err := s.findSprint(sprintID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.checkOwner(sprint.ProjectID, userID); err != nil {
+		return nil, err
+	}
+	if err := s.checkNotArchived(sprint.ProjectID); err != nil {
+		return nil, err
+	}
+	if sprint.State != models.SprintStateActive {
+		return nil, apperrors.ErrInvalidSprintState
+	}
+
+	var next *models.Sprint
+	if req.NextSprintID != nil && *req.NextSprintID != "" {
+		id, err := uuid.Parse(*req.NextSprintID)
+		if err != nil || id == sprintID {
+			return nil, apperrors.ErrInvalidSprintData
+		}
+		next, err = s.findSprint(id)
+		if err != nil {
+			return nil, err
+		}
+		if next.ProjectID != sprint.ProjectID || next.State != models.SprintStatePlanned {
+			return nil, apperrors.ErrInvalidSprintState
+		}
+	} else {
+		next, err = s.SprintRepo.FindNextPlanned(sprint.ProjectID, sprintID)
+		if err != nil {
+			if !errors.Is(err, gorm.ErrRecordNotFound) {
+				return nil, err
+			}
+			next = nil
+		}
+	}
+
+	var nextID *uuid.UUID
+	if next != nil {
+		nextID = &next.ID
+	}
+
+	moved, err := s.SprintRepo.Close(sprint, nextID, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	// Log activity
+	if s.ActivityLogService != nil {
+		details := map[string]interface{}{
+			"sprint_id":        sprintID.String(),
+			"name":             sprint.Name,
+			"committed_points": sprint.CommittedPoints,
+			"completed_points": sprint.CompletedPoints,
+			"moved_tasks":      moved,
+		}
+		if nextID != nil {
+			details["next_sprint_id"] = nextID.String()
+		}
+		s.ActivityLogService.LogActivity(sprint.ProjectID, userID, "sprint.closed", details)
+	}
+
+	sprintResp, err := s.buildSprintResponse(sprint)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &dto.CloseSprintResponse{Sprint: *sprintResp, MovedTasks: moved}
+	if nextID != nil {
+		idStr := nextID.String()
+		resp.NextSprintID = &idStr
+	}
+	return resp, nil
+}
+
+// AddTasks plans tasks of the project into a sprint
+func (s *SprintService) AddTasks(sprintID, userID uuid.UUID, req *dto.SprintTasksRequest) (*dto.SprintResponse, error) {
+	sprint, err := s.findSprint(sprintID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.checkMember(sprint.ProjectID, userID); err != nil {
+		return nil, err
+	}
+	if err := s.checkNotArchived(sprint.ProjectID); err != nil {
+		return nil, err
+	}
+	if sprint.State == models.SprintStateClosed {
+		return nil, apperrors.ErrInvalidSprintState
+	}
+
+	if len(req.TaskIDs) == 0 {
+		return nil, apperrors.ErrInvalidSprintData
+	}
+
+	var taskIDs []uuid.UUID
+	seen := map[uuid.UUID]bool{}
+	for _, raw := range req.TaskIDs {
+		id, err := uuid.Parse(raw)
+		if err != nil {
+			return nil, apperrors.ErrInvalidSprintData
+		}
+		if !seen[id] {
+			seen[id] = true
+			taskIDs = append(taskIDs, id)
+		}
+	}
+
+	count, err := s.SprintRepo.CountProjectTasks(sprint.ProjectID, taskIDs)
+	if err != nil {
+		return nil, err
+	}
+	if count != int64(len(taskIDs)) {
+		return nil, apperrors.ErrTaskNotInProject
+	}
+
+	if err := s.SprintRepo.AssignTasks(sprintID, taskIDs); err != nil {
+		return nil, err
+	}
+
+	// Log activity
+	if s.ActivityLogService != nil {
+		s.ActivityLogService.LogActivity(sprint.ProjectID, userID, "sprint.tasks_added", map[string]interface{}{
+			"sprint_id": sprintID.String(),
+			"task_ids":  uuidStrings(taskIDs),
+		})
+	}
+
+	return s.buildSprintResponse(sprint)
+}
+
+// RemoveTask moves a task of the sprint back to the backlog
+func (s *SprintService) RemoveTask(sprintID, taskID, userID uuid.UUID) error {
+	sprint, err := s.findSprint(sprintID)
+	if err != nil {
+		return err
+	}
+	if err := s.checkMember(sprint.ProjectID, userID); err != nil {
+		return err
+	}
+	if err := s.checkNotArchived(sprint.ProjectID); err != nil {
+		return err
+	}
+	if sprint.State == models.SprintStateClosed {
+		return apperrors.ErrInvalidSprintState
+	}
+
+	removed, err := s.SprintRepo.RemoveTask(sprintID, taskID)
+	if err != nil {
+		return err
+	}
+	if !removed {
+		return apperrors.ErrTaskNotFound
+	}
+
+	// Log activity
+	if s.ActivityLogService != nil {
+		s.ActivityLogService.LogActivity(sprint.ProjectID, userID, "sprint.task_removed", map[string]interface{}{
+			"sprint_id": sprintID.String(),
+			"task_id":   taskID.String(),
+		})
+	}
+
+	return nil
+}
+
+// GetSprintBoard retrieves the tasks of a sprint grouped into status columns
+func (s *SprintService) GetSprintBoard(sprintID, userID uuid.UUID) (*dto.SprintBoardResponse, error) {
+	sprint, err := s.findSprint(sprintID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.checkMember(sprint.ProjectID, userID); err != nil {
+		return nil, err
+	}
+
+	tasks, err := s.TaskRepo.FindFiltered(repository.TaskFilter{
+		ProjectID: &sprint.ProjectID,
+		SprintID:  &sprintID,
+		SortBy:    "priority",
+		Desc:      true,
+		Limit:     -1,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	sprintResp, err := s.buildSprintResponse(sprint)
+	if err != nil {
+		return nil, err
+	}
+
+	columns := map[string]*dto.SprintBoardColumn{}
+	resp := &dto.SprintBoardResponse{Sprint: *sprintResp}
+	for _, status := range sprintBoardStatuses {
+		resp.Columns = append(resp.Columns, dto.SprintBoardColumn{Status: status, Tasks: []dto.TaskResponse{}})
+	}
+	for i := range resp.Columns {
+		columns[resp.Columns[i].Status] = &resp.Columns[i]
+	}
+
+	for _, task := range tasks {
+		column, ok := columns[task.Status]
+		if !ok {
+			continue
+		}
+		column.Tasks = append(column.Tasks, *s.TaskService.buildTaskResponse(&task))
+		if task.StoryPoints != nil {
+			column.Points += *task.StoryPoints
+		}
+	}
+
+	return resp, nil
+}
+
+// GetVelocity retrieves the committed and completed points of the closed sprints of a project
+func (s *SprintService) GetVelocity(projectID, userID uuid.UUID) (*dto.VelocityResponse, error) {
+	if err := s.checkMember(projectID, userID); err != nil {
+		return nil, err
+	}
+
+	sprints, err := s.SprintRepo.FindClosedByProjectID(projectID)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &dto.VelocityResponse{Sprints: []dto.VelocityEntry{}}
+	total := 0
+	for _, sprint := range sprints {
+		resp.Sprints = append(resp.Sprints, dto.VelocityEntry{
+			SprintID:        sprint.ID.String(),
+			Name:            sprint.Name,
+			CommittedPoints: sprint.CommittedPoints,
+			CompletedPoints: sprint.CompletedPoints,
+			ClosedAt:        sprint.ClosedAt,
+		})
+		total += sprint.CompletedPoints
+	}
+	if len(sprints) > 0 {
+		resp.AverageVelocity = float64(total) / float64(len(sprints))
+	}
+
+	return resp, nil
+}
+
+// findSprint loads a sprint, translating a missing record into ErrSprintNotFound
+func (s *SprintService) findSprint(sprintID uuid.UUID) (*models.Sprint, error) {
+	sprint, err := s.SprintRepo.FindByID(sprintID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, apperrors.ErrSprintNotFound
+		}
+		return nil, err
+	}
+	return sprint, nil
+}
+
+// findActiveSprint retrieves the active sprint of a project
+func (s *SprintService) findActiveSprint(projectID uuid.UUID) (*models.Sprint, error) {
+	sprints, err := s.SprintRepo.FindByProjectID(projectID)
+	if err != nil {
+		return nil, err
+	}
+	for _, sprint := range sprints {
+		if sprint.State == models.SprintStateActive {
+			return &sprint, nil
+		}
+	}
+	return nil, gorm.ErrRecordNotFound
+}
+
+// checkOwner ensures the user owns the project
+func (s *SprintService) checkOwner(projectID, userID uuid.UUID) error {
+	isOwner, err := s.ProjectRepo.IsOwner(projectID, userID)
+	if err != nil {
+		return err
+	}
+	if !isOwner {
+		return apperrors.ErrUnauthorizedOwnerOnly
+	}
+	return nil
+}
+
+// checkNotArchived rejects sprint changes in an archived project
+func (s *SprintService) checkNotArchived(projectID uuid.UUID) error {
+	archived, err := s.ProjectRepo.IsArchived(projectID)
+	if err != nil {
+		return err
+	}
+	if archived {
+		return apperrors.ErrProjectArchived
+	}
+	return nil
+}
+
+// checkMember ensures the user is the owner or a member of the project
+func (s *SprintService) checkMember(projectID, userID uuid.UUID) error {
+	isMember, err := s.ProjectRepo.IsMember(projectID, userID)
+	if err != nil {
+		return err
+	}
+	isOwner, err := s.ProjectRepo.IsOwner(projectID, userID)
+	if err != nil {
+		return err
+	}
+	if !isMember && !isOwner {
+		return apperrors.ErrUnauthorizedProject
+	}
+	return nil
+}
+
+// parseSprintDates parses and validates the start and end dates of a sprint
+func parseSprintDates(start, end string) (time.Time, time.Time, error) {
+	startDate, err := parseDateParam(start, false)
+	if err != nil {
+		return time.Time{}, time.Time{}, apperrors.ErrInvalidSprintData
+	}
+	endDate, err := parseDateParam(end, true)
+	if err != nil {
+		return time.Time{}, time.Time{}, apperrors.ErrInvalidSprintData
+	}
+	if !endDate.After(startDate) {
+		return time.Time{}, time.Time{}, apperrors.ErrInvalidSprintData
+	}
+	return startDate, endDate, nil
+}
+
+// buildSprintResponse converts a sprint model into its response DTO, including point totals
+func (s *SprintService) buildSprintResponse(sprint *models.Sprint) (*dto.SprintResponse, error) {
+	points, err := s.SprintRepo.CountPoints(sprint.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &dto.SprintResponse{
+		ID:              sprint.ID.String(),
+		ProjectID:       sprint.ProjectID.String(),
+		Name:            sprint.Name,
+		Goal:            sprint.Goal,
+		StartDate:       sprint.StartDate,
+		EndDate:         sprint.EndDate,
+		State:           sprint.State,
+		TaskCount:       points.TaskCount,
+		TotalPoints:     points.TotalPoints,
+		DonePoints:      points.DonePoints,
+		CommittedPoints: sprint.CommittedPoints,
+		CompletedPoints: sprint.CompletedPoints,
+		ClosedAt:        sprint.ClosedAt,
+		CreatedBy:       sprint.CreatedBy.String(),
+		CreatedAt:       sprint.CreatedAt,
+		UpdatedAt:       sprint.UpdatedAt,
+	}, nil
+}
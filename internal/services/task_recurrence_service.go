@@ -0,0 +1,389 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/Hann-arc/task-management-backend/internal/dto"
+	apperrors "github.com/Hann-arc/task-management-backend/internal/errors"
+	"github.com/Hann-arc/task-management-backend/internal/models"
+	"github.com/Hann-arc/task-management-backend/internal/repository"
+	"github.com/Hann-arc/task-management-backend/internal/utils"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type TaskRecurrenceService struct {
+	RecurrenceRepo      *repository.TaskRecurrenceRepository
+	TaskRepo            *repository.TaskRepository
+	ProjectRepo         *repository.ProjectRepository
+	ActivityLogService  *ActivityLogService
+	NotificationService *NotificationService
+}
+
+// NewTaskRecurrenceService creates a new instance of TaskRecurrenceService
+func NewTaskRecurrenceService(
+	recurrenceRepo *repository.TaskRecurrenceRepository,
+	taskRepo *repository.TaskRepository,
+	projectRepo *repository.ProjectRepository,
+	activityLogService *ActivityLogService,
+	notificationService *NotificationService,
+) *TaskRecurrenceService {
+	return &TaskRecurrenceService{
+		RecurrenceRepo:      recurrenceRepo,
+		TaskRepo:            taskRepo,
+		ProjectRepo:         projectRepo,
+		ActivityLogService:  activityLogService,
+		NotificationService: notificationService,
+	}
+}
+
+// SetRecurrence makes a task the template of a recurring series, replacing any existing rule
+func (s *TaskRecurrenceService) SetRecurrence(taskID, userID uuid.UUID, req *dto.SetRecurrenceRequest) (*dto.RecurrenceResponse, error) {
+	task, projectID, err := s.findTaskWithAccess(taskID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	archived, err := s.TaskRepo.IsArchived(taskID)
+	if err != nil {
+		return nil, err
+	}
+	if archived {
+		return nil, apperrors.ErrProjectArchived
+	}
+
+	startAt := time.Now()
+	if task.DueDate.Year() >= 1900 {
+		startAt = task.DueDate
+	}
+	if req.StartAt != nil {
+		t, err := time.Parse(time.RFC3339, *req.StartAt)
+		if err != nil {
+			return nil, apperrors.ErrInvalidRecurrence
+		}
+		startAt = t
+	}
+
+	rule, err := buildRecurrenceRule(req, startAt)
+	if err != nil {
+		return nil, err
+	}
+
+	// the template itself is the first instance of the series
+	recurrence := &models.TaskRecurrence{
+		TemplateID: task.ID,
+		BoardID:    task.BoardID,
+		Rule:       rule.String(),
+		StartAt:    startAt,
+		NextRunAt:  startAt,
+		LastTaskID: &task.ID,
+		IsActive:   true,
+		CreatedBy:  userID,
+	}
+
+	if err := s.RecurrenceRepo.Save(recurrence); err != nil {
+		return nil, err
+	}
+
+	saved, err := s.RecurrenceRepo.FindByTemplateID(task.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Log activity
+	if s.ActivityLogService != nil {
+		s.ActivityLogService.LogActivity(projectID, userID, "task.recurrence_set", map[string]interface{}{
+			"task_id":  taskID.String(),
+			"rule":     saved.Rule,
+			"start_at": saved.StartAt,
+		})
+	}
+
+	return buildRecurrenceResponse(saved), nil
+}
+
+// GetRecurrence retrieves the recurrence rule of a template task
+func (s *TaskRecurrenceService) GetRecurrence(taskID, userID uuid.UUID) (*dto.RecurrenceResponse, error) {
+	if _, _, err := s.findTaskWithAccess(taskID, userID); err != nil {
+		return nil, err
+	}
+
+	recurrence, err := s.RecurrenceRepo.FindByTemplateID(taskID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, apperrors.ErrRecurrenceNotFound
+		}
+		return nil, err
+	}
+
+	return buildRecurrenceResponse(recurrence), nil
+}
+
+// DeleteRecurrence stops a recurring series; already created instances are kept
+func (s *TaskRecurrenceService) DeleteRecurrence(taskID, userID uuid.UUID) error {
+	_, projectID, err := s.findTaskWithAccess(taskID, userID)
+	if err != nil {
+		return err
+	}
+
+	archived, err := s.TaskRepo.IsArchived(taskID)
+	if err != nil {
+		return err
+	}
+	if archived {
+		return apperrors.ErrProjectArchived
+	}
+
+	if _, err := s.RecurrenceRepo.FindByTemplateID(taskID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return apperrors.ErrRecurrenceNotFound
+		}
+		return err
+	}
+
+	if err := s.RecurrenceRepo.DeleteByTemplateID(taskID); err != nil {
+		return err
+	}
+
+	// Log activity
+	if s.ActivityLogService != nil {
+		s.ActivityLogService.LogActivity(projectID, userID, "task.recurrence_removed", map[string]interface{}{
+			"task_id": taskID.String(),
+		})
+	}
+
+	return nil
+}
+
+// ProcessDueRecurrences creates the next instance of every series whose occurrence has arrived
+// or whose latest instance has been completed
+func (s *TaskRecurrenceService) ProcessDueRecurrences() error {
+	now := time.Now()
+	var created []models.Task
+
+	err := s.RecurrenceRepo.ProcessDue(now, 100, func(tx *gorm.DB, recurrence *models.TaskRecurrence) error {
+		task, err := s.createNextInstance(tx, recurrence, now)
+		if err != nil {
+			return err
+		}
+		if task != nil {
+			created = append(created, *task)
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	// side effects run only once the transaction has been committed
+	for _, task := range created {
+		var board models.Board
+		if err := s.TaskRepo.DB.Select("project_id").Where("id = ?", task.BoardID).First(&board).Error; err != nil {
+			log.Println("Failed to resolve project of recurring task:", err)
+			continue
+		}
+
+		if s.ActivityLogService != nil {
+			s.ActivityLogService.LogActivity(board.ProjectID, task.CreatedBy, "task.recurred", map[string]interface{}{
+				"task_id":  task.ID.String(),
+				"title":    task.Title,
+				"board_id": task.BoardID.String(),
+				"due_date": task.DueDate,
+				"status":   task.Status,
+			})
+		}
+
+		if s.NotificationService != nil && len(task.Assignees) > 0 {
+			var assigneeIDs []uuid.UUID
+			for _, a := range task.Assignees {
+				assigneeIDs = append(assigneeIDs, a.UserID)
+			}
+			go s.NotificationService.NotifyUsers(
+				assigneeIDs,
+				task.CreatedBy,
+				"task.assigned",
+				"task",
+				task.ID,
+				"A recurring task has been assigned to you",
+			)
+		}
+	}
+
+	return nil
+}
+
+// createNextInstance copies the template into a new task for the following occurrence
+func (s *TaskRecurrenceService) createNextInstance(tx *gorm.DB, recurrence *models.TaskRecurrence, now time.Time) (*models.Task, error) {
+	var template models.Task
+	if err := tx.Preload("Labels").Preload("Assignees").Preload("CustomFieldValues").First(&template, "id = ?", recurrence.TemplateID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			// the template was deleted, stop the series
+			return nil, tx.Model(recurrence).Update("is_active", false).Error
+		}
+		return nil, err
+	}
+
+	rule, err := utils.ParseRRule(recurrence.Rule, recurrence.StartAt)
+	if err != nil {
+		log.Printf("Disabling recurrence %s with invalid rule %q", recurrence.ID, recurrence.Rule)
+		return nil, tx.Model(recurrence).Update("is_active", false).Error
+	}
+
+	// missed occurrences are skipped rather than created in bulk
+	after := recurrence.NextRunAt
+	if now.After(after) {
+		after = now
+	}
+	dueDate := rule.Next(after)
+
+	task := &models.Task{
+		ID:          uuid.New(),
+		BoardID:     recurrence.BoardID,
+		Title:       template.Title,
+		Description: template.Description,
+		Priority:    template.Priority,
+		Status:      models.TaskStatusTodo,
+		DueDate:     dueDate,
+		CreatedBy:   recurrence.CreatedBy,
+	}
+	if err := tx.Create(task).Error; err != nil {
+		return nil, err
+	}
+
+	if len(template.Labels) > 0 {
+		var labels []models.TaskLabel
+		for _, l := range template.Labels {
+			labels = append(labels, models.TaskLabel{
+				ID:     uuid.New(),
+				TaskID: task.ID,
+				Name:   l.Name,
+				Color:  l.Color,
+			})
+		}
+		if err := tx.Create(&labels).Error; err != nil {
+			return nil, err
+		}
+	}
+
+	if len(template.Assignees) > 0 {
+		for _, a := range template.Assignees {
+			task.Assignees = append(task.Assignees, models.TaskAssignee{
+				ID:     uuid.New(),
+				TaskID: task.ID,
+				UserID: a.UserID,
+			})
+		}
+		if err := tx.Create(&task.Assignees).Error; err != nil {
+			return nil, err
+		}
+	}
+
+	if len(template.CustomFieldValues) > 0 {
+		for _, v := range template.CustomFieldValues {
+			task.CustomFieldValues = append(task.CustomFieldValues, models.TaskCustomFieldValue{
+				ID:      uuid.New(),
+				TaskID:  task.ID,
+				FieldID: v.FieldID,
+				Value:   v.Value,
+			})
+		}
+		if err := tx.Create(&task.CustomFieldValues).Error; err != nil {
+			return nil, err
+		}
+	}
+
+	err = tx.Model(recurrence).Updates(map[string]interface{}{
+		"next_run_at":  dueDate,
+		"last_task_id": task.ID,
+	}).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return task, nil
+}
+
+// findTaskWithAccess loads a task and ensures the user belongs to its project
+func (s *TaskRecurrenceService) findTaskWithAccess(taskID, userID uuid.UUID) (*models.Task, uuid.UUID, error) {
+	task, err := s.TaskRepo.FindByID(taskID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, uuid.Nil, apperrors.ErrTaskNotFound
+		}
+		return nil, uuid.Nil, err
+	}
+
+	var board models.Board
+	if err := s.TaskRepo.DB.Select("project_id").Where("id = ?", task.BoardID).First(&board).Error; err != nil {
+		return nil, uuid.Nil, err
+	}
+
+	isMember, err := s.ProjectRepo.IsMember(board.ProjectID, userID)
+	if err != nil {
+		return nil, uuid.Nil, err
+	}
+	isOwner, err := s.ProjectRepo.IsOwner(board.ProjectID, userID)
+	if err != nil {
+		return nil, uuid.Nil, err
+	}
+	if !isMember && !isOwner {
+		return nil, uuid.Nil, apperrors.ErrUnauthorizedTask
+	}
+
+	return task, board.ProjectID, nil
+}
+
+// buildRecurrenceRule converts either a raw RRULE or the simple frequency fields into a rule
+func buildRecurrenceRule(req *dto.SetRecurrenceRequest, startAt time.Time) (*utils.RecurrenceRule, error) {
+	raw := req.RRule
+	if raw == "" {
+		if req.Frequency == "" {
+			return nil, apperrors.ErrInvalidRecurrence
+		}
+
+		parts := []string{"FREQ=" + strings.ToUpper(req.Frequency)}
+		if req.Interval > 0 {
+			parts = append(parts, fmt.Sprintf("INTERVAL=%d", req.Interval))
+		}
+		if len(req.Weekdays) > 0 {
+			parts = append(parts, "BYDAY="+strings.Join(req.Weekdays, ","))
+		}
+		if req.MonthDay > 0 {
+			parts = append(parts, fmt.Sprintf("BYMONTHDAY=%d", req.MonthDay))
+		}
+		raw = strings.Join(parts, ";")
+	}
+
+	rule, err := utils.ParseRRule(raw, startAt)
+	if err != nil {
+		return nil, apperrors.ErrInvalidRecurrence
+	}
+	return rule, nil
+}
+
+// buildRecurrenceResponse converts a recurrence model into its response DTO
+func buildRecurrenceResponse(recurrence *models.TaskRecurrence) *dto.RecurrenceResponse {
+	resp := &dto.RecurrenceResponse{
+		ID:         recurrence.ID.String(),
+		TemplateID: recurrence.TemplateID.String(),
+		BoardID:    recurrence.BoardID.String(),
+		Rule:       recurrence.Rule,
+		StartAt:    recurrence.StartAt,
+		NextRunAt:  recurrence.NextRunAt,
+		IsActive:   recurrence.IsActive,
+		CreatedBy:  recurrence.CreatedBy.String(),
+		CreatedAt:  recurrence.CreatedAt,
+		UpdatedAt:  recurrence.UpdatedAt,
+	}
+
+	if recurrence.LastTaskID != nil {
+		idStr := recurrence.LastTaskID.String()
+		resp.LastTaskID = &idStr
+	}
+
+	return resp
+}
@@ -0,0 +1,142 @@
+package services
+
+import (
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/Hann-arc/task-management-backend/internal/models"
+	"github.com/Hann-arc/task-management-backend/internal/repository"
+	"github.com/google/uuid"
+)
+
+// overdueWindow bounds how long after its due date a task still gets an overdue notification,
+// so a scheduler that was down for a while does not flood users with stale reminders
+const overdueWindow = 24 * time.Hour
+
+type TaskReminderService struct {
+	ReminderRepo        *repository.TaskReminderRepository
+	NotificationService *NotificationService
+	LeadTimes           []time.Duration
+}
+
+// NewTaskReminderService creates a new instance of TaskReminderService
+func NewTaskReminderService(reminderRepo *repository.TaskReminderRepository, notificationService *NotificationService, leadTimes []time.Duration) *TaskReminderService {
+	sorted := append([]time.Duration(nil), leadTimes...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+
+	return &TaskReminderService{ReminderRepo: reminderRepo, NotificationService: notificationService, LeadTimes: sorted}
+}
+
+// ProcessReminders sends due-soon reminders for every configured lead time and overdue notifications
+func (s *TaskReminderService) ProcessReminders() error {
+	now := time.Now()
+
+	// each lead time owns the window between the next shorter lead time and itself,
+	// so a task due in 30 minutes only gets the 1h reminder and not the 24h one as well
+	var lower time.Duration
+	for _, lead := range s.LeadTimes {
+		tasks, err := s.ReminderRepo.FindOpenTasksDueBetween(now.Add(lower), now.Add(lead))
+		if err != nil {
+			return err
+		}
+		for _, task := range tasks {
+			message := fmt.Sprintf("Task \"%s\" is due in %s", task.Title, formatLeadTime(lead))
+			if err := s.sendReminder(&task, models.ReminderKindDueSoon, lead, "task.due_soon", message); err != nil {
+				return err
+			}
+		}
+		lower = lead
+	}
+
+	tasks, err := s.ReminderRepo.FindOpenTasksDueBetween(now.Add(-overdueWindow), now)
+	if err != nil {
+		return err
+	}
+	for _, task := range tasks {
+		message := fmt.Sprintf("Task \"%s\" is overdue", task.Title)
+		if err := s.sendReminder(&task, models.ReminderKindOverdue, 0, "task.overdue", message); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// sendReminder records the reminder and notifies the assignee and creator if it was not sent before
+func (s *TaskReminderService) sendReminder(task *models.Task, kind string, lead time.Duration, action, message string) error {
+	recorded, err := s.ReminderRepo.MarkSent(&models.TaskReminder{
+		TaskID:      task.ID,
+		Kind:        kind,
+		LeadMinutes: int(lead.Minutes()),
+		DueDate:     task.DueDate,
+		SentAt:      time.Now(),
+	})
+	if err != nil {
+		return err
+	}
+	if !recorded || s.NotificationService == nil {
+		return nil
+	}
+
+	recipients := []uuid.UUID{task.CreatedBy}
+	for _, a := range task.Assignees {
+		recipients = append(recipients, a.UserID)
+	}
+
+	// reminders have no human actor, so the creator is used and must not be skipped
+	seen := map[uuid.UUID]bool{}
+	for _, userID := range recipients {
+		if seen[userID] {
+			continue
+		}
+		seen[userID] = true
+
+		if err := s.NotificationService.CreateNotification(userID, task.CreatedBy, action, "task", task.ID, message); err != nil {
+			log.Printf("Failed to send %s notification for task %s: %v", action, task.ID, err)
+		}
+	}
+	return nil
+}
+
+// ParseLeadTimes parses a comma separated list of durations such as "24h,1h"
+func ParseLeadTimes(value string) ([]time.Duration, error) {
+	var leadTimes []time.Duration
+	for _, part := range strings.Split(value, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		d, err := time.ParseDuration(part)
+		if err != nil {
+			return nil, err
+		}
+		if d <= 0 {
+			return nil, fmt.Errorf("lead time must be positive: %s", part)
+		}
+		leadTimes = append(leadTimes, d)
+	}
+	return leadTimes, nil
+}
+
+// formatLeadTime renders a lead time as a short human readable string
+func formatLeadTime(d time.Duration) string {
+	switch {
+	case d%(24*time.Hour) == 0:
+		days := int(d / (24 * time.Hour))
+		if days == 1 {
+			return "1 day"
+		}
+		return fmt.Sprintf("%d days", days)
+	case d%time.Hour == 0:
+		hours := int(d / time.Hour)
+		if hours == 1 {
+			return "1 hour"
+		}
+		return fmt.Sprintf("%d hours", hours)
+	default:
+		return fmt.Sprintf("%d minutes", int(d.Minutes()))
+	}
+}
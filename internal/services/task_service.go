@@ -1,7 +1,13 @@
 package services
 
 import (
+	"encoding/base64"
+	"encoding/json"
 	"errors"
+	"log"
+	"sort"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/Hann-arc/task-management-backend/internal/dto"
@@ -17,8 +23,31 @@ type TaskService struct {
 	TaskRepo            *repository.TaskRepository
 	ProjectRepo         *repository.ProjectRepository
 	UserRepo            *repository.UserRepository
+	CustomFieldRepo     *repository.CustomFieldRepository
 	ActivityLogService  *ActivityLogService
 	NotificationService *NotificationService
+	MentionService      *MentionService
+}
+
+var validPriorities = map[string]bool{
+	"low":    true,
+	"medium": true,
+	"high":   true,
+	"urgent": true,
+}
+
+var validStatuses = map[string]bool{
+	models.TaskStatusTodo:       true,
+	models.TaskStatusInProgress: true,
+	models.TaskStatusDone:       true,
+}
+
+// priorityRank orders priorities from lowest to highest, matching repository.TaskSortColumns
+var priorityRank = map[string]int{
+	"low":    1,
+	"medium": 2,
+	"high":   3,
+	"urgent": 4,
 }
 
 // NewTaskService creates a new instance of TaskService
@@ -26,15 +55,19 @@ func NewTaskService(
 	taskRepo *repository.TaskRepository,
 	projectRepo *repository.ProjectRepository,
 	userRepo *repository.UserRepository,
+	customFieldRepo *repository.CustomFieldRepository,
 	activityLogService *ActivityLogService,
 	notificationService *NotificationService,
+	mentionService *MentionService,
 ) *TaskService {
 	return &TaskService{
 		TaskRepo:            taskRepo,
 		ProjectRepo:         projectRepo,
 		UserRepo:            userRepo,
+		CustomFieldRepo:     customFieldRepo,
 		ActivityLogService:  activityLogService,
 		NotificationService: notificationService,
+		MentionService:      mentionService,
 	}
 }
 
@@ -42,14 +75,15 @@ func NewTaskService(
 func (s *TaskService) CreateTask(req *dto.CreateTaskRequest, boardID, userID uuid.UUID) (*dto.TaskResponse, error) {
 
 	// validate priority
-	validPriorities := map[string]bool{
-		"low":    true,
-		"medium": true,
-		"high":   true,
-		"urgent": true,
+	if !validPriorities[req.Priority] {
+		return nil, apperrors.ErrInvalidTaskData
 	}
 
-	if !validPriorities[req.Priority] {
+	status := req.Status
+	if status == "" {
+		status = models.TaskStatusTodo
+	}
+	if !validStatuses[status] {
 		return nil, apperrors.ErrInvalidTaskData
 	}
 
@@ -81,21 +115,22 @@ func (s *TaskService) CreateTask(req *dto.CreateTaskRequest, boardID, userID uui
 		return nil, apperrors.ErrUnauthorizedTask
 	}
 
-	//  Validate assignee if provided
-	var assigneeID *uuid.UUID
+	archived, err := s.ProjectRepo.IsBoardArchived(boardID)
+	if err != nil {
+		return nil, err
+	}
+	if archived {
+		return nil, apperrors.ErrProjectArchived
+	}
+
+	//  Validate assignees if provided
+	rawAssignees := req.AssigneeIDs
 	if req.AssigneeID != nil {
-		id, err := uuid.Parse(*req.AssigneeID)
-		if err != nil {
-			return nil, apperrors.ErrInvalidTaskData
-		}
-		exists, err := s.TaskRepo.UserExists(id)
-		if err != nil {
-			return nil, err
-		}
-		if !exists {
-			return nil, apperrors.ErrAssigneeNotFound
-		}
-		assigneeID = &id
+		rawAssignees = append(rawAssignees, *req.AssigneeID)
+	}
+	assigneeIDs, err := s.resolveAssignees(projectID, rawAssignees)
+	if err != nil {
+		return nil, err
 	}
 
 	// Parse due date
@@ -114,15 +149,38 @@ func (s *TaskService) CreateTask(req *dto.CreateTaskRequest, boardID, userID uui
 		Title:       req.Title,
 		Description: req.Description,
 		Priority:    req.Priority,
+		Status:      status,
 		DueDate:     dueDate,
-		AssigneeID:  assigneeID,
 		CreatedBy:   userID,
 	}
 
+	if req.EstimateMinutes != nil && *req.EstimateMinutes > 0 {
+		task.EstimateMinutes = req.EstimateMinutes
+	}
+	if req.StoryPoints != nil && *req.StoryPoints > 0 {
+		task.StoryPoints = req.StoryPoints
+	}
+
+	if status == models.TaskStatusDone {
+		now := time.Now()
+		task.CompletedAt = &now
+	}
+
+	customValues, _, err := s.resolveCustomFieldValues(projectID, task.ID, req.CustomFields, true)
+	if err != nil {
+		return nil, err
+	}
+
 	if err := s.TaskRepo.Create(task); err != nil {
 		return nil, err
 	}
 
+	if len(customValues) > 0 {
+		if err := s.TaskRepo.SaveCustomFieldValues(task.ID, customValues, nil); err != nil {
+			return nil, err
+		}
+	}
+
 	// Handle labels
 	if len(req.Labels) > 0 {
 		var labels []models.TaskLabel
@@ -139,6 +197,12 @@ func (s *TaskService) CreateTask(req *dto.CreateTaskRequest, boardID, userID uui
 		}
 	}
 
+	if len(assigneeIDs) > 0 {
+		if err := s.TaskRepo.ReplaceAssignees(task.ID, assigneeIDs); err != nil {
+			return nil, err
+		}
+	}
+
 	// Log activity
 	if s.ActivityLogService != nil {
 		details := map[string]interface{}{
@@ -146,65 +210,212 @@ func (s *TaskService) CreateTask(req *dto.CreateTaskRequest, boardID, userID uui
 			"title":      req.Title,
 			"board_id":   boardID.String(),
 			"project_id": projectID.String(),
+			"status":     status,
 		}
-		if req.AssigneeID != nil {
-			details["assignee_id"] = *req.AssigneeID
+		if len(assigneeIDs) > 0 {
+			details["assignee_ids"] = uuidStrings(assigneeIDs)
+		}
+		if len(customValues) > 0 {
+			details["custom_fields"] = req.CustomFields
 		}
 		s.ActivityLogService.LogActivity(projectID, userID, "task.created", details)
 	}
 
-	// Send notification to assignee
-	if s.NotificationService != nil && req.AssigneeID != nil {
-		assigneeID, _ := uuid.Parse(*req.AssigneeID)
-		if assigneeID != userID {
-			go s.NotificationService.CreateNotification(
-				assigneeID,
-				userID,
-				"task.assigned",
-				"task",
-				task.ID,
-				"You have been assigned to a new task",
-			)
-		}
+	// Send notification to assignees
+	if s.NotificationService != nil && len(assigneeIDs) > 0 {
+		go s.NotificationService.NotifyUsers(
+			assigneeIDs,
+			userID,
+			"task.assigned",
+			"task",
+			task.ID,
+			"You have been assigned to a new task",
+		)
 	}
 
-	return s.buildTaskResponse(task), nil
+	s.syncMentions(task, projectID, userID)
+
+	createdTask, err := s.TaskRepo.FindByID(task.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.buildTaskResponse(createdTask), nil
 }
 
-// GetTasksByBoard retrieves all tasks for a specific board, ensuring the user has access
-func (s *TaskService) GetTasksByBoard(boardID, userID uuid.UUID) ([]dto.TaskResponse, error) {
+// GetTasksByBoard retrieves the tasks of a board matching the query, ensuring the user has access
+func (s *TaskService) GetTasksByBoard(boardID, userID uuid.UUID, query *dto.TaskListQuery) (*dto.TaskListResponse, error) {
 
 	var board models.Board
 	if err := s.TaskRepo.DB.Select("project_id").Where("id = ?", boardID).First(&board).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, apperrors.ErrBoardNotFound
+		}
 		return nil, err
 	}
 
-	projectID := board.ProjectID
+	if err := s.checkProjectAccess(board.ProjectID, userID); err != nil {
+		return nil, err
+	}
 
-	isMember, err := s.ProjectRepo.IsMember(projectID, userID)
+	filter, err := buildTaskFilter(query)
 	if err != nil {
 		return nil, err
 	}
-	isOwner, err := s.ProjectRepo.IsOwner(projectID, userID)
+	filter.BoardID = &boardID
+	if filter.CustomFields, err = s.buildCustomFieldConditions(board.ProjectID, query.CustomFields); err != nil {
+		return nil, err
+	}
+
+	return s.buildTaskListResponse(filter)
+}
+
+// GetTasksByProject retrieves the tasks of every board in a project matching the query
+func (s *TaskService) GetTasksByProject(projectID, userID uuid.UUID, query *dto.TaskListQuery) (*dto.TaskListResponse, error) {
+	if err := s.checkProjectAccess(projectID, userID); err != nil {
+		return nil, err
+	}
+
+	filter, err := buildTaskFilter(query)
 	if err != nil {
 		return nil, err
 	}
-	if !isMember && !isOwner {
-		return nil, apperrors.ErrUnauthorizedTask
+	filter.ProjectID = &projectID
+	if filter.CustomFields, err = s.buildCustomFieldConditions(projectID, query.CustomFields); err != nil {
+		return nil, err
 	}
 
-	tasks, err := s.TaskRepo.FindByBoardID(boardID)
+	return s.buildTaskListResponse(filter)
+}
+
+// GetMyTasks retrieves the tasks assigned to or created by the user across all of their projects
+func (s *TaskService) GetMyTasks(userID uuid.UUID, query *dto.MyTaskListQuery) (*dto.MyTaskListResponse, error) {
+	filter, err := buildTaskFilter(&dto.TaskListQuery{
+		Priority: query.Priority,
+		Status:   query.Status,
+		Sort:     query.Sort,
+		Order:    query.Order,
+		Cursor:   query.Cursor,
+		Limit:    query.Limit,
+	})
 	if err != nil {
 		return nil, err
 	}
 
-	var result []dto.TaskResponse
+	switch query.Role {
+	case "", "assigned", "created":
+		filter.InvolvedRole = query.Role
+	default:
+		return nil, apperrors.ErrInvalidTaskQuery
+	}
+
+	loc := time.UTC
+	if query.TimeZone != "" {
+		loc, err = time.LoadLocation(query.TimeZone)
+		if err != nil {
+			return nil, apperrors.ErrInvalidTaskQuery
+		}
+	}
+
+	now := time.Now().In(loc)
+	startOfDay := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, loc)
+
+	switch query.Due {
+	case "":
+	case "overdue":
+		filter.Overdue = true
+	case "today":
+		end := startOfDay.AddDate(0, 0, 1).Add(-time.Nanosecond)
+		filter.DueFrom = &startOfDay
+		filter.DueTo = &end
+	case "week":
+		// weeks start on Monday
+		offset := (int(startOfDay.Weekday()) + 6) % 7
+		start := startOfDay.AddDate(0, 0, -offset)
+		end := start.AddDate(0, 0, 7).Add(-time.Nanosecond)
+		filter.DueFrom = &start
+		filter.DueTo = &end
+	default:
+		return nil, apperrors.ErrInvalidTaskQuery
+	}
+
+	filter.InvolvedUserID = &userID
+	filter.IncludeContext = true
+
+	tasks, nextCursor, err := s.listTasks(filter)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &dto.MyTaskListResponse{Tasks: []dto.MyTaskResponse{}, NextCursor: nextCursor}
 	for _, t := range tasks {
-		result = append(result, *s.buildTaskResponse(&t))
+		result.Tasks = append(result.Tasks, dto.MyTaskResponse{
+			TaskResponse: *s.buildTaskResponse(&t),
+			Board: dto.BoardSummary{
+				ID:         t.Board.ID.String(),
+				Name:       t.Board.Name,
+				OrderIndex: t.Board.OrderIndex,
+			},
+			Project: dto.ProjectSummary{
+				ID:   t.Board.Project.ID.String(),
+				Name: t.Board.Project.Name,
+			},
+		})
+	}
+	return result, nil
+}
+
+// buildTaskListResponse runs a filtered task query and converts it into a paginated response
+func (s *TaskService) buildTaskListResponse(filter *repository.TaskFilter) (*dto.TaskListResponse, error) {
+	tasks, nextCursor, err := s.listTasks(filter)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &dto.TaskListResponse{Tasks: []dto.TaskResponse{}, NextCursor: nextCursor}
+	for _, t := range tasks {
+		result.Tasks = append(result.Tasks, *s.buildTaskResponse(&t))
 	}
 	return result, nil
 }
 
+// listTasks runs a filtered task query and builds the next page cursor
+func (s *TaskService) listTasks(filter *repository.TaskFilter) ([]models.Task, *string, error) {
+	limit := filter.Limit
+
+	// fetch one extra row to know whether there is a next page
+	filter.Limit = limit + 1
+	tasks, err := s.TaskRepo.FindFiltered(*filter)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var nextCursor *string
+	if len(tasks) > limit {
+		tasks = tasks[:limit]
+		cursor := encodeTaskCursor(&tasks[len(tasks)-1], filter.SortBy, filter.Desc)
+		nextCursor = &cursor
+	}
+
+	return tasks, nextCursor, nil
+}
+
+// checkProjectAccess ensures the user is the owner or a member of the project
+func (s *TaskService) checkProjectAccess(projectID, userID uuid.UUID) error {
+	isMember, err := s.ProjectRepo.IsMember(projectID, userID)
+	if err != nil {
+		return err
+	}
+	isOwner, err := s.ProjectRepo.IsOwner(projectID, userID)
+	if err != nil {
+		return err
+	}
+	if !isMember && !isOwner {
+		return apperrors.ErrUnauthorizedTask
+	}
+	return nil
+}
+
 // UpdateTask modifies the details of an existing task
 func (s *TaskService) UpdateTask(taskID, userID uuid.UUID, req *dto.UpdateTaskRequest) (*dto.TaskResponse, error) {
 	task, err := s.TaskRepo.FindByID(taskID)
@@ -238,6 +449,14 @@ func (s *TaskService) UpdateTask(taskID, userID uuid.UUID, req *dto.UpdateTaskRe
 		return nil, apperrors.ErrUnauthorizedTask
 	}
 
+	archived, err := s.TaskRepo.IsArchived(taskID)
+	if err != nil {
+		return nil, err
+	}
+	if archived {
+		return nil, apperrors.ErrProjectArchived
+	}
+
 	data := map[string]interface{}{}
 
 	if req.Title != nil {
@@ -249,6 +468,19 @@ func (s *TaskService) UpdateTask(taskID, userID uuid.UUID, req *dto.UpdateTaskRe
 	if req.Priority != nil {
 		data["priority"] = *req.Priority
 	}
+	if req.Status != nil {
+		if !validStatuses[*req.Status] {
+			return nil, apperrors.ErrInvalidTaskData
+		}
+		data["status"] = *req.Status
+		if *req.Status != task.Status {
+			if *req.Status == models.TaskStatusDone {
+				data["completed_at"] = time.Now()
+			} else {
+				data["completed_at"] = nil
+			}
+		}
+	}
 
 	if req.DueDate != nil {
 		if *req.DueDate == "" {
@@ -262,26 +494,55 @@ func (s *TaskService) UpdateTask(taskID, userID uuid.UUID, req *dto.UpdateTaskRe
 		}
 	}
 
-	if req.AssigneeID != nil {
-		if *req.AssigneeID == "" {
-			data["assignee_id"] = nil
-		} else {
-			id, err := uuid.Parse(*req.AssigneeID)
-			if err != nil {
-				return nil, apperrors.ErrInvalidTaskData
-			}
-			exists, err := s.TaskRepo.UserExists(id)
+	// an estimate of 0 removes it
+	if req.EstimateMinutes != nil {
+		switch {
+		case *req.EstimateMinutes < 0:
+			return nil, apperrors.ErrInvalidTaskData
+		case *req.EstimateMinutes == 0:
+			data["estimate_minutes"] = nil
+		default:
+			data["estimate_minutes"] = *req.EstimateMinutes
+		}
+	}
+
+	if req.StoryPoints != nil {
+		switch {
+		case *req.StoryPoints < 0:
+			return nil, apperrors.ErrInvalidTaskData
+		case *req.StoryPoints == 0:
+			data["story_points"] = nil
+		default:
+			data["story_points"] = *req.StoryPoints
+		}
+	}
+
+	// assignee_ids replaces the whole set, the legacy assignee_id sets a single assignee ("" clears)
+	var newAssignees []uuid.UUID
+	assigneesChanged := false
+	if req.AssigneeIDs != nil {
+		newAssignees, err = s.resolveAssignees(projectID, *req.AssigneeIDs)
+		if err != nil {
+			return nil, err
+		}
+		assigneesChanged = true
+	} else if req.AssigneeID != nil {
+		if *req.AssigneeID != "" {
+			newAssignees, err = s.resolveAssignees(projectID, []string{*req.AssigneeID})
 			if err != nil {
 				return nil, err
 			}
-			if !exists {
-				return nil, apperrors.ErrAssigneeNotFound
-			}
-			data["assignee_id"] = id
 		}
+		assigneesChanged = true
+	}
+
+	customValues, removedFieldIDs, err := s.resolveCustomFieldValues(projectID, taskID, req.CustomFields, false)
+	if err != nil {
+		return nil, err
 	}
+	customFieldsChanged := len(customValues) > 0 || len(removedFieldIDs) > 0
 
-	if len(data) == 0 && req.Labels == nil {
+	if len(data) == 0 && req.Labels == nil && !assigneesChanged && !customFieldsChanged {
 		return nil, apperrors.ErrInvalidTaskData
 	}
 
@@ -306,6 +567,23 @@ func (s *TaskService) UpdateTask(taskID, userID uuid.UUID, req *dto.UpdateTaskRe
 		}
 	}
 
+	if assigneesChanged {
+		if err := s.TaskRepo.ReplaceAssignees(taskID, newAssignees); err != nil {
+			return nil, err
+		}
+	}
+
+	if customFieldsChanged {
+		if err := s.TaskRepo.SaveCustomFieldValues(taskID, customValues, removedFieldIDs); err != nil {
+			return nil, err
+		}
+	}
+
+	if req.Description != nil && *req.Description != task.Description {
+		task.Description = *req.Description
+		s.syncMentions(task, projectID, userID)
+	}
+
 	updatedTask, err := s.TaskRepo.FindByID(taskID)
 	if err != nil {
 		return nil, err
@@ -329,26 +607,50 @@ func (s *TaskService) UpdateTask(taskID, userID uuid.UUID, req *dto.UpdateTaskRe
 			details["due_date"] = *req.DueDate
 		}
 
-		if req.AssigneeID != nil {
-			details["assignee_id"] = *req.AssigneeID
+		if assigneesChanged {
+			details["assignee_ids"] = uuidStrings(newAssignees)
 		}
 
 		if req.Priority != nil {
 			details["priority"] = *req.Priority
 		}
 
+		if req.EstimateMinutes != nil {
+			details["estimate_minutes"] = *req.EstimateMinutes
+		}
+
+		if req.StoryPoints != nil {
+			details["story_points"] = *req.StoryPoints
+		}
+
+		if customFieldsChanged {
+			details["custom_fields"] = req.CustomFields
+		}
+
+		if req.Status != nil && *req.Status != task.Status {
+			details["status"] = *req.Status
+			details["previous_status"] = task.Status
+		}
+
 		s.ActivityLogService.LogActivity(projectID, userID, "task.updated", details)
 	}
 
-	// Send notification to assignee
-	if s.NotificationService != nil && req.AssigneeID != nil {
-		oldAssignee := task.AssigneeID
-		newAssignee, _ := uuid.Parse(*req.AssigneeID)
+	if s.NotificationService != nil {
+		previous := map[uuid.UUID]bool{}
+		for _, a := range task.Assignees {
+			previous[a.UserID] = true
+		}
 
-		// Notify to new assignee if changed
-		if newAssignee != userID && (oldAssignee == nil || *oldAssignee != newAssignee) {
-			go s.NotificationService.CreateNotification(
-				newAssignee,
+		// Notify newly added assignees
+		var added []uuid.UUID
+		for _, id := range newAssignees {
+			if !previous[id] {
+				added = append(added, id)
+			}
+		}
+		if len(added) > 0 {
+			go s.NotificationService.NotifyUsers(
+				added,
 				userID,
 				"task.assigned",
 				"task",
@@ -356,6 +658,29 @@ func (s *TaskService) UpdateTask(taskID, userID uuid.UUID, req *dto.UpdateTaskRe
 				"You have been assigned to a task",
 			)
 		}
+
+		// Notify everyone following the task about the update
+		audience, err := s.TaskRepo.FindAudienceIDs(taskID)
+		if err == nil {
+			addedSet := map[uuid.UUID]bool{}
+			for _, id := range added {
+				addedSet[id] = true
+			}
+			var recipients []uuid.UUID
+			for _, id := range audience {
+				if !addedSet[id] {
+					recipients = append(recipients, id)
+				}
+			}
+			go s.NotificationService.NotifyUsers(
+				recipients,
+				userID,
+				"task.updated",
+				"task",
+				taskID,
+				"A task you follow has been updated",
+			)
+		}
 	}
 
 	return s.buildTaskResponse(updatedTask), nil
@@ -394,6 +719,14 @@ func (s *TaskService) DeleteTask(taskID, userID uuid.UUID) error {
 		return apperrors.ErrUnauthorizedTask
 	}
 
+	archived, err := s.TaskRepo.IsArchived(taskID)
+	if err != nil {
+		return err
+	}
+	if archived {
+		return apperrors.ErrProjectArchived
+	}
+
 	if err := s.TaskRepo.SoftDelete(taskID); err != nil {
 		return err
 	}
@@ -408,35 +741,298 @@ func (s *TaskService) DeleteTask(taskID, userID uuid.UUID) error {
 	return nil
 }
 
+// WatchTask subscribes the user to notifications about a task
+func (s *TaskService) WatchTask(taskID, userID uuid.UUID) error {
+	projectID, err := s.findTaskProjectWithAccess(taskID, userID)
+	if err != nil {
+		return err
+	}
+
+	archived, err := s.TaskRepo.IsArchived(taskID)
+	if err != nil {
+		return err
+	}
+	if archived {
+		return apperrors.ErrProjectArchived
+	}
+
+	if err := s.TaskRepo.AddWatcher(taskID, userID); err != nil {
+		return err
+	}
+
+	// Log activity
+	if s.ActivityLogService != nil {
+		s.ActivityLogService.LogActivity(projectID, userID, "task.watched", map[string]interface{}{
+			"task_id": taskID.String(),
+		})
+	}
+
+	return nil
+}
+
+// UnwatchTask unsubscribes the user from notifications about a task
+func (s *TaskService) UnwatchTask(taskID, userID uuid.UUID) error {
+	projectID, err := s.findTaskProjectWithAccess(taskID, userID)
+	if err != nil {
+		return err
+	}
+
+	archived, err := s.TaskRepo.IsArchived(taskID)
+	if err != nil {
+		return err
+	}
+	if archived {
+		return apperrors.ErrProjectArchived
+	}
+
+	if err := s.TaskRepo.RemoveWatcher(taskID, userID); err != nil {
+		return err
+	}
+
+	// Log activity
+	if s.ActivityLogService != nil {
+		s.ActivityLogService.LogActivity(projectID, userID, "task.unwatched", map[string]interface{}{
+			"task_id": taskID.String(),
+		})
+	}
+
+	return nil
+}
+
+// findTaskProjectWithAccess resolves the project of a task and ensures the user belongs to it
+func (s *TaskService) findTaskProjectWithAccess(taskID, userID uuid.UUID) (uuid.UUID, error) {
+	task, err := s.TaskRepo.FindByID(taskID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return uuid.Nil, apperrors.ErrTaskNotFound
+		}
+		return uuid.Nil, err
+	}
+
+	var board models.Board
+	if err := s.TaskRepo.DB.Select("project_id").Where("id = ?", task.BoardID).First(&board).Error; err != nil {
+		return uuid.Nil, err
+	}
+
+	if err := s.checkProjectAccess(board.ProjectID, userID); err != nil {
+		return uuid.Nil, err
+	}
+	return board.ProjectID, nil
+}
+
+// resolveAssignees parses and deduplicates assignee IDs, ensuring every user exists
+// and is the owner or a member of the project
+func (s *TaskService) resolveAssignees(projectID uuid.UUID, rawIDs []string) ([]uuid.UUID, error) {
+	var ids []uuid.UUID
+	seen := map[uuid.UUID]bool{}
+	for _, raw := range rawIDs {
+		id, err := uuid.Parse(raw)
+		if err != nil {
+			return nil, apperrors.ErrInvalidTaskData
+		}
+		if seen[id] {
+			continue
+		}
+		seen[id] = true
+
+		exists, err := s.TaskRepo.UserExists(id)
+		if err != nil {
+			return nil, err
+		}
+		if !exists {
+			return nil, apperrors.ErrAssigneeNotFound
+		}
+
+		isMember, err := s.ProjectRepo.IsMember(projectID, id)
+		if err != nil {
+			return nil, err
+		}
+		isOwner, err := s.ProjectRepo.IsOwner(projectID, id)
+		if err != nil {
+			return nil, err
+		}
+		if !isMember && !isOwner {
+			return nil, apperrors.ErrAssigneeNotMember
+		}
+
+		ids = append(ids, id)
+	}
+	return ids, nil
+}
+
+// resolveCustomFieldValues validates custom field input keyed by field ID against the project's
+// definitions, returning the values to upsert and the fields to clear (null or empty values)
+func (s *TaskService) resolveCustomFieldValues(projectID, taskID uuid.UUID, input map[string]interface{}, creating bool) ([]models.TaskCustomFieldValue, []uuid.UUID, error) {
+	if len(input) == 0 && !creating {
+		return nil, nil, nil
+	}
+
+	fields, err := s.CustomFieldRepo.FindByProjectID(projectID)
+	if err != nil {
+		return nil, nil, err
+	}
+	fieldsByID := map[uuid.UUID]*models.CustomField{}
+	for i := range fields {
+		fieldsByID[fields[i].ID] = &fields[i]
+	}
+
+	isProjectUser := func(id uuid.UUID) (bool, error) {
+		isMember, err := s.ProjectRepo.IsMember(projectID, id)
+		if err != nil || isMember {
+			return isMember, err
+		}
+		return s.ProjectRepo.IsOwner(projectID, id)
+	}
+
+	var values []models.TaskCustomFieldValue
+	var removed []uuid.UUID
+	provided := map[uuid.UUID]bool{}
+	for rawID, raw := range input {
+		fieldID, err := uuid.Parse(rawID)
+		if err != nil {
+			return nil, nil, apperrors.ErrInvalidCustomFieldValue
+		}
+		field, ok := fieldsByID[fieldID]
+		if !ok {
+			return nil, nil, apperrors.ErrInvalidCustomFieldValue
+		}
+
+		if raw == nil || raw == "" {
+			if field.Required {
+				return nil, nil, apperrors.ErrInvalidCustomFieldValue
+			}
+			removed = append(removed, fieldID)
+			continue
+		}
+
+		value, err := normalizeCustomFieldValue(field, raw, isProjectUser)
+		if err != nil {
+			return nil, nil, err
+		}
+		encoded, err := json.Marshal(value)
+		if err != nil {
+			return nil, nil, err
+		}
+		values = append(values, models.TaskCustomFieldValue{
+			ID:      uuid.New(),
+			TaskID:  taskID,
+			FieldID: fieldID,
+			Value:   encoded,
+		})
+		provided[fieldID] = true
+	}
+
+	// required fields must be filled in when the task is created
+	if creating {
+		for _, f := range fields {
+			if f.Required && !provided[f.ID] {
+				return nil, nil, apperrors.ErrInvalidCustomFieldValue
+			}
+		}
+	}
+
+	return values, removed, nil
+}
+
+// buildCustomFieldConditions converts cf.<field_id> query filters into repository conditions
+func (s *TaskService) buildCustomFieldConditions(projectID uuid.UUID, filters map[string]string) ([]repository.CustomFieldCondition, error) {
+	if len(filters) == 0 {
+		return nil, nil
+	}
+
+	var conditions []repository.CustomFieldCondition
+	for rawID, raw := range filters {
+		fieldID, err := uuid.Parse(rawID)
+		if err != nil {
+			return nil, apperrors.ErrInvalidTaskQuery
+		}
+		field, err := s.CustomFieldRepo.FindByID(fieldID)
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return nil, apperrors.ErrInvalidTaskQuery
+			}
+			return nil, err
+		}
+		if field.ProjectID != projectID {
+			return nil, apperrors.ErrInvalidTaskQuery
+		}
+
+		value, err := parseCustomFieldFilter(field, raw)
+		if err != nil {
+			return nil, err
+		}
+		conditions = append(conditions, repository.CustomFieldCondition{
+			FieldID: fieldID,
+			Type:    field.Type,
+			Value:   value,
+		})
+	}
+	return conditions, nil
+}
+
+// syncMentions stores and notifies the members mentioned in a task description; a failure only
+// costs the mention links, so it is logged instead of failing the request
+func (s *TaskService) syncMentions(task *models.Task, projectID, userID uuid.UUID) {
+	if s.MentionService == nil {
+		return
+	}
+
+	if err := s.MentionService.SyncTaskMentions(task, projectID, userID); err != nil {
+		log.Println("Failed to save task mentions:", err)
+	}
+}
+
 // Helper to converts a task model to a task response DTO
 func (s *TaskService) buildTaskResponse(task *models.Task) *dto.TaskResponse {
 	resp := &dto.TaskResponse{
-		ID:          task.ID.String(),
-		BoardID:     task.BoardID.String(),
-		Title:       task.Title,
-		Description: task.Description,
-		Priority:    task.Priority,
-		CreatedBy:   task.CreatedBy.String(),
-		CreatedAt:   task.CreatedAt,
-		UpdatedAt:   task.UpdatedAt,
-		DeletedAt:   utils.ToTimePtr(task.DeletedAt),
+		ID:              task.ID.String(),
+		BoardID:         task.BoardID.String(),
+		Title:           task.Title,
+		Description:     task.Description,
+		Priority:        task.Priority,
+		Status:          task.Status,
+		CompletedAt:     task.CompletedAt,
+		EstimateMinutes: task.EstimateMinutes,
+		StoryPoints:     task.StoryPoints,
+		CreatedBy:       task.CreatedBy.String(),
+		CreatedAt:       task.CreatedAt,
+		UpdatedAt:       task.UpdatedAt,
+		DeletedAt:       utils.ToTimePtr(task.DeletedAt),
 	}
 
 	if task.DueDate != (time.Time{}) {
 		resp.DueDate = &task.DueDate
 	}
 
-	if task.AssigneeID != nil {
-		idStr := task.AssigneeID.String()
-		resp.AssigneeID = &idStr
-		if task.Assignee.ID != uuid.Nil {
-			resp.Assignee = &dto.UserBasic{
-				ID:   task.Assignee.ID.String(),
-				Name: task.Assignee.Name,
-			}
-		}
+	if task.SprintID != nil {
+		sprintID := task.SprintID.String()
+		resp.SprintID = &sprintID
 	}
 
+	resp.AssigneeIDs = []string{}
+	for _, a := range task.Assignees {
+		resp.AssigneeIDs = append(resp.AssigneeIDs, a.UserID.String())
+		resp.Assignees = append(resp.Assignees, dto.UserBasic{
+			ID:   a.UserID.String(),
+			Name: a.User.Name,
+		})
+	}
+
+	// the first assignee is kept in the single-assignee fields for older clients
+	if len(resp.Assignees) > 0 {
+		resp.AssigneeID = &resp.AssigneeIDs[0]
+		resp.Assignee = &resp.Assignees[0]
+	}
+
+	for _, w := range task.Watchers {
+		resp.Watchers = append(resp.Watchers, dto.UserBasic{
+			ID:   w.UserID.String(),
+			Name: w.User.Name,
+		})
+	}
+
+	resp.Mentions = buildTaskMentionResponses(task.Mentions)
+
 	for _, l := range task.Labels {
 		resp.Labels = append(resp.Labels, dto.LabelDTO{
 			ID:    l.ID.String(),
@@ -445,5 +1041,218 @@ func (s *TaskService) buildTaskResponse(task *models.Task) *dto.TaskResponse {
 		})
 	}
 
+	values := append([]models.TaskCustomFieldValue(nil), task.CustomFieldValues...)
+	sort.SliceStable(values, func(i, j int) bool {
+		return values[i].Field.Position < values[j].Field.Position
+	})
+	for _, v := range values {
+		resp.CustomFields = append(resp.CustomFields, dto.CustomFieldValueResponse{
+			FieldID: v.FieldID.String(),
+			Name:    v.Field.Name,
+			Type:    v.Field.Type,
+			Value:   json.RawMessage(v.Value),
+		})
+	}
+
 	return resp
 }
+
+// buildTaskFilter validates the listing query and converts it into a repository filter
+func buildTaskFilter(query *dto.TaskListQuery) (*repository.TaskFilter, error) {
+	filter := &repository.TaskFilter{
+		Label:   strings.TrimSpace(query.Label),
+		Overdue: query.Overdue,
+		Search:  strings.TrimSpace(query.Search),
+		SortBy:  "created_at",
+		Limit:   20,
+	}
+
+	if query.AssigneeID != "" {
+		id, err := uuid.Parse(query.AssigneeID)
+		if err != nil {
+			return nil, apperrors.ErrInvalidTaskQuery
+		}
+		filter.AssigneeID = &id
+	}
+
+	// sprint_id=backlog lists the tasks that are not planned into any sprint
+	if query.SprintID == "backlog" {
+		filter.Backlog = true
+	} else if query.SprintID != "" {
+		id, err := uuid.Parse(query.SprintID)
+		if err != nil {
+			return nil, apperrors.ErrInvalidTaskQuery
+		}
+		filter.SprintID = &id
+	}
+
+	if query.CreatedBy != "" {
+		id, err := uuid.Parse(query.CreatedBy)
+		if err != nil {
+			return nil, apperrors.ErrInvalidTaskQuery
+		}
+		filter.CreatedBy = &id
+	}
+
+	if query.Priority != "" {
+		for _, p := range strings.Split(query.Priority, ",") {
+			p = strings.TrimSpace(p)
+			if !validPriorities[p] {
+				return nil, apperrors.ErrInvalidTaskQuery
+			}
+			filter.Priorities = append(filter.Priorities, p)
+		}
+	}
+
+	if query.Status != "" {
+		for _, st := range strings.Split(query.Status, ",") {
+			st = strings.TrimSpace(st)
+			if !validStatuses[st] {
+				return nil, apperrors.ErrInvalidTaskQuery
+			}
+			filter.Statuses = append(filter.Statuses, st)
+		}
+	}
+
+	if query.DueFrom != "" {
+		t, err := parseDateParam(query.DueFrom, false)
+		if err != nil {
+			return nil, apperrors.ErrInvalidTaskQuery
+		}
+		filter.DueFrom = &t
+	}
+
+	if query.DueTo != "" {
+		t, err := parseDateParam(query.DueTo, true)
+		if err != nil {
+			return nil, apperrors.ErrInvalidTaskQuery
+		}
+		filter.DueTo = &t
+	}
+
+	if query.Sort != "" {
+		if _, ok := repository.TaskSortColumns[query.Sort]; !ok {
+			return nil, apperrors.ErrInvalidTaskQuery
+		}
+		filter.SortBy = query.Sort
+	}
+
+	switch strings.ToLower(query.Order) {
+	case "", "asc":
+	case "desc":
+		filter.Desc = true
+	default:
+		return nil, apperrors.ErrInvalidTaskQuery
+	}
+
+	if query.Limit < 0 {
+		return nil, apperrors.ErrInvalidTaskQuery
+	}
+	if query.Limit > 0 {
+		filter.Limit = query.Limit
+	}
+	if filter.Limit > 100 {
+		filter.Limit = 100
+	}
+
+	if query.Cursor != "" {
+		value, id, err := decodeTaskCursor(query.Cursor, filter.SortBy, filter.Desc)
+		if err != nil {
+			return nil, apperrors.ErrInvalidCursor
+		}
+		filter.CursorValue = value
+		filter.CursorID = &id
+	}
+
+	return filter, nil
+}
+
+// parseDateParam accepts either RFC3339 or a plain date, optionally moved to the end of that day
+func parseDateParam(value string, endOfDay bool) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t, nil
+	}
+	t, err := time.Parse("2006-01-02", value)
+	if err != nil {
+		return time.Time{}, err
+	}
+	if endOfDay {
+		t = t.Add(24*time.Hour - time.Nanosecond)
+	}
+	return t, nil
+}
+
+// encodeTaskCursor builds an opaque cursor from the sort key, order, sort value and ID of a task
+func encodeTaskCursor(task *models.Task, sortBy string, desc bool) string {
+	var value string
+	switch sortBy {
+	case "due_date":
+		if task.DueDate.Year() < 1900 {
+			value = "infinity"
+		} else {
+			value = task.DueDate.Format(time.RFC3339Nano)
+		}
+	case "priority":
+		value = strconv.Itoa(priorityRank[task.Priority])
+	case "updated_at":
+		value = task.UpdatedAt.Format(time.RFC3339Nano)
+	default:
+		value = task.CreatedAt.Format(time.RFC3339Nano)
+	}
+	return base64.RawURLEncoding.EncodeToString([]byte(strings.Join([]string{sortBy, cursorOrder(desc), value, task.ID.String()}, "|")))
+}
+
+// decodeTaskCursor extracts the sort value and task ID from a cursor, rejecting cursors made for
+// another sort key or order and values that do not fit the sort column
+func decodeTaskCursor(cursor, sortBy string, desc bool) (string, uuid.UUID, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return "", uuid.Nil, apperrors.ErrInvalidCursor
+	}
+
+	parts := strings.Split(string(raw), "|")
+	if len(parts) != 4 || parts[0] != sortBy || parts[1] != cursorOrder(desc) {
+		return "", uuid.Nil, apperrors.ErrInvalidCursor
+	}
+
+	value := parts[2]
+	switch sortBy {
+	case "priority":
+		rank, err := strconv.Atoi(value)
+		if err != nil || rank < 0 || rank > len(priorityRank) {
+			return "", uuid.Nil, apperrors.ErrInvalidCursor
+		}
+	case "due_date":
+		if value == "infinity" {
+			break
+		}
+		fallthrough
+	default:
+		if _, err := time.Parse(time.RFC3339Nano, value); err != nil {
+			return "", uuid.Nil, apperrors.ErrInvalidCursor
+		}
+	}
+
+	id, err := uuid.Parse(parts[3])
+	if err != nil {
+		return "", uuid.Nil, apperrors.ErrInvalidCursor
+	}
+	return value, id, nil
+}
+
+// cursorOrder names the sort direction recorded in a cursor
+func cursorOrder(desc bool) string {
+	if desc {
+		return "desc"
+	}
+	return "asc"
+}
+
+// uuidStrings converts a list of UUIDs into strings for activity log details
+func uuidStrings(ids []uuid.UUID) []string {
+	result := make([]string, 0, len(ids))
+	for _, id := range ids {
+		result = append(result, id.String())
+	}
+	return result
+}
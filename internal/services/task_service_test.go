@@ -0,0 +1,88 @@
+package services
+
+import (
+	"encoding/base64"
+	"errors"
+	"testing"
+	"time"
+
+	apperrors "github.com/Hann-arc/task-management-backend/internal/errors"
+	"github.com/Hann-arc/task-management-backend/internal/models"
+	"github.com/google/uuid"
+)
+
+func TestTaskCursorRoundTrip(t *testing.T) {
+	created := time.Date(2025, time.March, 1, 10, 30, 0, 123456789, time.UTC)
+	task := &models.Task{
+		ID:       uuid.New(),
+		Priority: "high",
+		DueDate:  time.Date(2025, time.April, 2, 23, 59, 59, 0, time.UTC),
+	}
+	task.CreatedAt = created
+	task.UpdatedAt = created.Add(time.Hour)
+
+	noDueDate := &models.Task{ID: uuid.New(), Priority: "low"}
+
+	tests := []struct {
+		name   string
+		task   *models.Task
+		sortBy string
+		desc   bool
+		want   string
+	}{
+		{"created at", task, "created_at", false, "2025-03-01T10:30:00.123456789Z"},
+		{"updated at descending", task, "updated_at", true, "2025-03-01T11:30:00.123456789Z"},
+		{"due date", task, "due_date", false, "2025-04-02T23:59:59Z"},
+		{"missing due date", noDueDate, "due_date", true, "infinity"},
+		{"priority", task, "priority", true, "3"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cursor := encodeTaskCursor(tt.task, tt.sortBy, tt.desc)
+
+			value, id, err := decodeTaskCursor(cursor, tt.sortBy, tt.desc)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if value != tt.want {
+				t.Errorf("expected value %q, got %q", tt.want, value)
+			}
+			if id != tt.task.ID {
+				t.Errorf("expected ID %s, got %s", tt.task.ID, id)
+			}
+		})
+	}
+}
+
+func TestDecodeTaskCursorRejectsInvalid(t *testing.T) {
+	id := uuid.NewString()
+	encode := func(raw string) string {
+		return base64.RawURLEncoding.EncodeToString([]byte(raw))
+	}
+
+	tests := []struct {
+		name   string
+		cursor string
+		sortBy string
+		desc   bool
+	}{
+		{"not base64", "not a cursor!", "created_at", false},
+		{"too few parts", encode("created_at|asc|" + id), "created_at", false},
+		{"another sort key", encode("updated_at|asc|2025-03-01T10:30:00Z|" + id), "created_at", false},
+		{"another order", encode("created_at|desc|2025-03-01T10:30:00Z|" + id), "created_at", false},
+		{"bad timestamp", encode("created_at|asc|yesterday|" + id), "created_at", false},
+		{"infinity outside due date", encode("created_at|asc|infinity|" + id), "created_at", false},
+		{"bad priority", encode("priority|asc|high|" + id), "priority", false},
+		{"priority out of range", encode("priority|asc|9|" + id), "priority", false},
+		{"bad ID", encode("created_at|asc|2025-03-01T10:30:00Z|42"), "created_at", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, _, err := decodeTaskCursor(tt.cursor, tt.sortBy, tt.desc); !errors.Is(err, apperrors.ErrInvalidCursor) {
+				t.Errorf("expected ErrInvalidCursor, got %v", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,544 @@
+package services
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/Hann-arc/task-management-backend/internal/dto"
+	apperrors "github.com/Hann-arc/task-management-backend/internal/errors"
+	"github.com/Hann-arc/task-management-backend/internal/models"
+	"github.com/Hann-arc/task-management-backend/internal/repository"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// templateVariablePattern matches {{name}} placeholders, allowing spaces inside the braces
+var templateVariablePattern = regexp.MustCompile(`\{\{\s*([a-zA-Z0-9_]+)\s*\}\}`)
+
+type TaskTemplateService struct {
+	TaskTemplateRepo   *repository.TaskTemplateRepository
+	BoardRepo          *repository.BoardRepository
+	ProjectRepo        *repository.ProjectRepository
+	UserRepo           *repository.UserRepository
+	TaskService        *TaskService
+	ActivityLogService *ActivityLogService
+}
+
+// NewTaskTemplateService creates a new instance of TaskTemplateService
+func NewTaskTemplateService(
+	taskTemplateRepo *repository.TaskTemplateRepository,
+	boardRepo *repository.BoardRepository,
+	projectRepo *repository.ProjectRepository,
+	userRepo *repository.UserRepository,
+	taskService *TaskService,
+	activityLogService *ActivityLogService,
+) *TaskTemplateService {
+	return &TaskTemplateService{
+		TaskTemplateRepo:   taskTemplateRepo,
+		BoardRepo:          boardRepo,
+		ProjectRepo:        projectRepo,
+		UserRepo:           userRepo,
+		TaskService:        taskService,
+		ActivityLogService: activityLogService,
+	}
+}
+
+// CreateTemplate saves a new task template in a project
+func (s *TaskTemplateService) CreateTemplate(projectID, userID uuid.UUID, req *dto.CreateTaskTemplateRequest) (*dto.TaskTemplateResponse, error) {
+	if err := s.checkMember(projectID, userID); err != nil {
+		return nil, err
+	}
+	if err := s.checkNotArchived(projectID); err != nil {
+		return nil, err
+	}
+
+	name := strings.TrimSpace(req.Name)
+	if name == "" || strings.TrimSpace(req.TitlePattern) == "" {
+		return nil, apperrors.ErrInvalidTaskTemplate
+	}
+
+	priority := req.Priority
+	if priority == "" {
+		priority = "medium"
+	}
+	if !validPriorities[priority] {
+		return nil, apperrors.ErrInvalidTaskTemplate
+	}
+
+	exists, err := s.TaskTemplateRepo.NameExists(projectID, name, nil)
+	if err != nil {
+		return nil, err
+	}
+	if exists {
+		return nil, apperrors.ErrTaskTemplateExists
+	}
+
+	labels, err := encodeTemplateLabels(req.Labels)
+	if err != nil {
+		return nil, err
+	}
+	checklist, err := encodeTemplateChecklist(req.Checklist)
+	if err != nil {
+		return nil, err
+	}
+
+	template := &models.TaskTemplate{
+		ID:           uuid.New(),
+		ProjectID:    projectID,
+		Name:         name,
+		TitlePattern: req.TitlePattern,
+		Description:  req.Description,
+		Priority:     priority,
+		Labels:       labels,
+		Checklist:    checklist,
+		CreatedBy:    userID,
+	}
+
+	if req.DefaultAssigneeID != nil && *req.DefaultAssigneeID != "" {
+		assigneeID, err := s.resolveDefaultAssignee(projectID, *req.DefaultAssigneeID)
+		if err != nil {
+			return nil, err
+		}
+		template.DefaultAssigneeID = &assigneeID
+	}
+
+	if err := s.TaskTemplateRepo.Create(template); err != nil {
+		return nil, err
+	}
+
+	// Log activity
+	if s.ActivityLogService != nil {
+		s.ActivityLogService.LogActivity(projectID, userID, "task_template.created", map[string]interface{}{
+			"template_id": template.ID.String(),
+			"name":        template.Name,
+		})
+	}
+
+	created, err := s.TaskTemplateRepo.FindByID(template.ID)
+	if err != nil {
+		return nil, err
+	}
+	return buildTaskTemplateResponse(created), nil
+}
+
+// GetTemplates retrieves the task templates of a project
+func (s *TaskTemplateService) GetTemplates(projectID, userID uuid.UUID) ([]dto.TaskTemplateResponse, error) {
+	if err := s.checkMember(projectID, userID); err != nil {
+		return nil, err
+	}
+
+	templates, err := s.TaskTemplateRepo.FindByProjectID(projectID)
+	if err != nil {
+		return nil, err
+	}
+
+	result := []dto.TaskTemplateResponse{}
+	for _, t := range templates {
+		result = append(result, *buildTaskTemplateResponse(&t))
+	}
+	return result, nil
+}
+
+// GetTemplate retrieves a single task template
+func (s *TaskTemplateService) GetTemplate(templateID, userID uuid.UUID) (*dto.TaskTemplateResponse, error) {
+	template, err := s.findTemplateWithAccess(templateID, userID)
+	if err != nil {
+		return nil, err
+	}
+	return buildTaskTemplateResponse(template), nil
+}
+
+// UpdateTemplate modifies a task template
+func (s *TaskTemplateService) UpdateTemplate(templateID, userID uuid.UUID, req *dto.UpdateTaskTemplateRequest) (*dto.TaskTemplateResponse, error) {
+	template, err := s.findTemplateWithAccess(templateID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.checkNotArchived(template.ProjectID); err != nil {
+		return nil, err
+	}
+
+	data := map[string]interface{}{}
+	if req.Name != nil {
+		name := strings.TrimSpace(*req.Name)
+		if name == "" {
+			return nil, apperrors.ErrInvalidTaskTemplate
+		}
+		exists, err := s.TaskTemplateRepo.NameExists(template.ProjectID, name, &template.ID)
+		if err != nil {
+			return nil, err
+		}
+		if exists {
+			return nil, apperrors.ErrTaskTemplateExists
+		}
+		data["name"] = name
+	}
+	if req.TitlePattern != nil {
+		if strings.TrimSpace(*req.TitlePattern) == "" {
+			return nil, apperrors.ErrInvalidTaskTemplate
+		}
+		data["title_pattern"] = *req.TitlePattern
+	}
+	if req.Description != nil {
+		data["description"] = *req.Description
+	}
+	if req.Priority != nil {
+		if !validPriorities[*req.Priority] {
+			return nil, apperrors.ErrInvalidTaskTemplate
+		}
+		data["priority"] = *req.Priority
+	}
+	if req.Labels != nil {
+		labels, err := encodeTemplateLabels(*req.Labels)
+		if err != nil {
+			return nil, err
+		}
+		data["labels"] = labels
+	}
+	if req.Checklist != nil {
+		checklist, err := encodeTemplateChecklist(*req.Checklist)
+		if err != nil {
+			return nil, err
+		}
+		data["checklist"] = checklist
+	}
+	// an empty default_assignee_id removes the default assignee
+	if req.DefaultAssigneeID != nil {
+		if *req.DefaultAssigneeID == "" {
+			data["default_assignee_id"] = nil
+		} else {
+			assigneeID, err := s.resolveDefaultAssignee(template.ProjectID, *req.DefaultAssigneeID)
+			if err != nil {
+				return nil, err
+			}
+			data["default_assignee_id"] = assigneeID
+		}
+	}
+
+	if len(data) == 0 {
+		return nil, apperrors.ErrNoFieldsToUpdate
+	}
+
+	if err := s.TaskTemplateRepo.Update(templateID, data); err != nil {
+		return nil, err
+	}
+
+	// Log activity
+	if s.ActivityLogService != nil {
+		s.ActivityLogService.LogActivity(template.ProjectID, userID, "task_template.updated", map[string]interface{}{
+			"template_id": templateID.String(),
+			"name":        template.Name,
+		})
+	}
+
+	updated, err := s.TaskTemplateRepo.FindByID(templateID)
+	if err != nil {
+		return nil, err
+	}
+	return buildTaskTemplateResponse(updated), nil
+}
+
+// DeleteTemplate removes a task template; tasks created from it are kept
+func (s *TaskTemplateService) DeleteTemplate(templateID, userID uuid.UUID) error {
+	template, err := s.findTemplateWithAccess(templateID, userID)
+	if err != nil {
+		return err
+	}
+	if err := s.checkNotArchived(template.ProjectID); err != nil {
+		return err
+	}
+
+	if err := s.TaskTemplateRepo.Delete(templateID); err != nil {
+		return err
+	}
+
+	// Log activity
+	if s.ActivityLogService != nil {
+		s.ActivityLogService.LogActivity(template.ProjectID, userID, "task_template.deleted", map[string]interface{}{
+			"template_id": templateID.String(),
+			"name":        template.Name,
+		})
+	}
+
+	return nil
+}
+
+// CreateTaskFromTemplate creates a task on a board from a template of the board's project,
+// expanding template variables; the task goes through the regular TaskService.CreateTask validation
+func (s *TaskTemplateService) CreateTaskFromTemplate(boardID, userID uuid.UUID, req *dto.CreateTaskFromTemplateRequest) (*dto.TaskResponse, error) {
+	templateID, err := uuid.Parse(req.TemplateID)
+	if err != nil {
+		return nil, apperrors.ErrInvalidTaskTemplate
+	}
+
+	board, err := s.BoardRepo.FindByID(boardID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, apperrors.ErrBoardNotFound
+		}
+		return nil, err
+	}
+
+	if err := s.checkMember(board.ProjectID, userID); err != nil {
+		return nil, apperrors.ErrUnauthorizedTask
+	}
+
+	template, err := s.TaskTemplateRepo.FindByID(templateID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, apperrors.ErrTaskTemplateNotFound
+		}
+		return nil, err
+	}
+	if template.ProjectID != board.ProjectID {
+		return nil, apperrors.ErrTaskTemplateNotFound
+	}
+
+	loc := time.UTC
+	if req.TimeZone != "" {
+		loc, err = time.LoadLocation(req.TimeZone)
+		if err != nil {
+			return nil, apperrors.ErrInvalidTaskData
+		}
+	}
+
+	vars, err := s.buildTemplateVariables(board, userID, time.Now().In(loc))
+	if err != nil {
+		return nil, err
+	}
+	for k, v := range req.Variables {
+		vars[k] = v
+	}
+
+	title := expandTemplateVariables(template.TitlePattern, vars)
+	if req.Title != nil && strings.TrimSpace(*req.Title) != "" {
+		title = *req.Title
+	}
+	if strings.TrimSpace(title) == "" {
+		return nil, apperrors.ErrInvalidTaskData
+	}
+
+	// tasks have no checklist of their own, so the items are appended as a markdown task list
+	description := expandTemplateVariables(template.Description, vars)
+	checklist := decodeTemplateChecklist(template.Checklist)
+	if len(checklist) > 0 {
+		var sb strings.Builder
+		sb.WriteString(description)
+		if description != "" {
+			sb.WriteString("\n\n")
+		}
+		for i, item := range checklist {
+			if i > 0 {
+				sb.WriteString("\n")
+			}
+			sb.WriteString("- [ ] " + expandTemplateVariables(item, vars))
+		}
+		description = sb.String()
+	}
+
+	assigneeIDs := req.AssigneeIDs
+	if len(assigneeIDs) == 0 && template.DefaultAssigneeID != nil {
+		// a default assignee who has since left the project is skipped
+		if err := s.checkMember(template.ProjectID, *template.DefaultAssigneeID); err == nil {
+			assigneeIDs = []string{template.DefaultAssigneeID.String()}
+		}
+	}
+
+	task, err := s.TaskService.CreateTask(&dto.CreateTaskRequest{
+		Title:        title,
+		Description:  description,
+		Priority:     template.Priority,
+		Status:       req.Status,
+		DueDate:      req.DueDate,
+		AssigneeIDs:  assigneeIDs,
+		Labels:       decodeTemplateLabels(template.Labels),
+		CustomFields: req.CustomFields,
+	}, boardID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Log activity
+	if s.ActivityLogService != nil {
+		s.ActivityLogService.LogActivity(template.ProjectID, userID, "task_template.used", map[string]interface{}{
+			"template_id": template.ID.String(),
+			"task_id":     task.ID,
+		})
+	}
+
+	return task, nil
+}
+
+// buildTemplateVariables returns the built-in template variables for the given moment
+func (s *TaskTemplateService) buildTemplateVariables(board *models.Board, userID uuid.UUID, now time.Time) (map[string]string, error) {
+	project, err := s.ProjectRepo.FindByID(board.ProjectID)
+	if err != nil {
+		return nil, err
+	}
+	user, err := s.UserRepo.FindByID(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return map[string]string{
+		"date":     now.Format("2006-01-02"),
+		"time":     now.Format("15:04"),
+		"datetime": now.Format(time.RFC3339),
+		"weekday":  now.Weekday().String(),
+		"week":     isoWeekString(now),
+		"month":    now.Format("January"),
+		"year":     now.Format("2006"),
+		"user":     user.Name,
+		"project":  project.Name,
+		"board":    board.Name,
+	}, nil
+}
+
+// checkMember ensures the user is the owner or a member of the project
+func (s *TaskTemplateService) checkMember(projectID, userID uuid.UUID) error {
+	isMember, err := s.ProjectRepo.IsMember(projectID, userID)
+	if err != nil {
+		return err
+	}
+	if isMember {
+		return nil
+	}
+	isOwner, err := s.ProjectRepo.IsOwner(projectID, userID)
+	if err != nil {
+		return err
+	}
+	if !isOwner {
+		return apperrors.ErrUnauthorizedProject
+	}
+	return nil
+}
+
+// checkNotArchived rejects template changes in an archived project
+func (s *TaskTemplateService) checkNotArchived(projectID uuid.UUID) error {
+	archived, err := s.ProjectRepo.IsArchived(projectID)
+	if err != nil {
+		return err
+	}
+	if archived {
+		return apperrors.ErrProjectArchived
+	}
+	return nil
+}
+
+// findTemplateWithAccess loads a template and ensures the user belongs to its project
+func (s *TaskTemplateService) findTemplateWithAccess(templateID, userID uuid.UUID) (*models.TaskTemplate, error) {
+	template, err := s.TaskTemplateRepo.FindByID(templateID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, apperrors.ErrTaskTemplateNotFound
+		}
+		return nil, err
+	}
+
+	if err := s.checkMember(template.ProjectID, userID); err != nil {
+		return nil, err
+	}
+	return template, nil
+}
+
+// resolveDefaultAssignee parses a default assignee ID and ensures the user belongs to the project
+func (s *TaskTemplateService) resolveDefaultAssignee(projectID uuid.UUID, raw string) (uuid.UUID, error) {
+	assigneeID, err := uuid.Parse(raw)
+	if err != nil {
+		return uuid.Nil, apperrors.ErrInvalidTaskTemplate
+	}
+	if err := s.checkMember(projectID, assigneeID); err != nil {
+		if errors.Is(err, apperrors.ErrUnauthorizedProject) {
+			return uuid.Nil, apperrors.ErrAssigneeNotMember
+		}
+		return uuid.Nil, err
+	}
+	return assigneeID, nil
+}
+
+// expandTemplateVariables replaces {{name}} placeholders with their values, leaving unknown ones untouched
+func expandTemplateVariables(text string, vars map[string]string) string {
+	return templateVariablePattern.ReplaceAllStringFunc(text, func(match string) string {
+		name := templateVariablePattern.FindStringSubmatch(match)[1]
+		if value, ok := vars[name]; ok {
+			return value
+		}
+		return match
+	})
+}
+
+// isoWeekString formats the ISO week of a date, e.g. 2024-W07
+func isoWeekString(t time.Time) string {
+	year, week := t.ISOWeek()
+	return fmt.Sprintf("%d-W%02d", year, week)
+}
+
+// encodeTemplateLabels validates the labels of a template and encodes them as JSON
+func encodeTemplateLabels(labels []dto.LabelDTO) ([]byte, error) {
+	cleaned := []dto.LabelDTO{}
+	for _, l := range labels {
+		name := strings.TrimSpace(l.Name)
+		if name == "" {
+			return nil, apperrors.ErrInvalidTaskTemplate
+		}
+		cleaned = append(cleaned, dto.LabelDTO{Name: name, Color: l.Color})
+	}
+	return json.Marshal(cleaned)
+}
+
+// encodeTemplateChecklist drops blank checklist items and encodes the rest as JSON
+func encodeTemplateChecklist(items []string) ([]byte, error) {
+	cleaned := []string{}
+	for _, item := range items {
+		if item = strings.TrimSpace(item); item != "" {
+			cleaned = append(cleaned, item)
+		}
+	}
+	return json.Marshal(cleaned)
+}
+
+// decodeTemplateLabels decodes the JSON labels of a template
+func decodeTemplateLabels(data []byte) []dto.LabelDTO {
+	labels := []dto.LabelDTO{}
+	if len(data) > 0 {
+		_ = json.Unmarshal(data, &labels)
+	}
+	return labels
+}
+
+// decodeTemplateChecklist decodes the JSON checklist of a template
+func decodeTemplateChecklist(data []byte) []string {
+	items := []string{}
+	if len(data) > 0 {
+		_ = json.Unmarshal(data, &items)
+	}
+	return items
+}
+
+// buildTaskTemplateResponse converts a task template model into its response DTO
+func buildTaskTemplateResponse(template *models.TaskTemplate) *dto.TaskTemplateResponse {
+	resp := &dto.TaskTemplateResponse{
+		ID:           template.ID.String(),
+		ProjectID:    template.ProjectID.String(),
+		Name:         template.Name,
+		TitlePattern: template.TitlePattern,
+		Description:  template.Description,
+		Priority:     template.Priority,
+		Labels:       decodeTemplateLabels(template.Labels),
+		Checklist:    decodeTemplateChecklist(template.Checklist),
+		CreatedBy:    template.CreatedBy.String(),
+		CreatedAt:    template.CreatedAt,
+		UpdatedAt:    template.UpdatedAt,
+	}
+	if template.DefaultAssignee != nil {
+		resp.DefaultAssignee = &dto.UserBasic{
+			ID:   template.DefaultAssignee.ID.String(),
+			Name: template.DefaultAssignee.Name,
+		}
+	}
+	return resp
+}
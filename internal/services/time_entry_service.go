@@ -0,0 +1,442 @@
+package services
+
+import (
+	"errors"
+	"time"
+
+	"github.com/Hann-arc/task-management-backend/internal/dto"
+	apperrors "github.com/Hann-arc/task-management-backend/internal/errors"
+	"github.com/Hann-arc/task-management-backend/internal/models"
+	"github.com/Hann-arc/task-management-backend/internal/repository"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type TimeEntryService struct {
+	TimeEntryRepo      *repository.TimeEntryRepository
+	TaskRepo           *repository.TaskRepository
+	ProjectRepo        *repository.ProjectRepository
+	ActivityLogService *ActivityLogService
+}
+
+// NewTimeEntryService creates a new instance of TimeEntryService
+func NewTimeEntryService(
+	timeEntryRepo *repository.TimeEntryRepository,
+	taskRepo *repository.TaskRepository,
+	projectRepo *repository.ProjectRepository,
+	activityLogService *ActivityLogService,
+) *TimeEntryService {
+	return &TimeEntryService{
+		TimeEntryRepo:      timeEntryRepo,
+		TaskRepo:           taskRepo,
+		ProjectRepo:        projectRepo,
+		ActivityLogService: activityLogService,
+	}
+}
+
+// StartTimer starts a running timer on a task; a user can only have one running timer
+func (s *TimeEntryService) StartTimer(taskID, userID uuid.UUID, req *dto.StartTimerRequest) (*dto.TimeEntryResponse, error) {
+	projectID, err := s.findTaskProjectWithAccess(taskID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.ensureTaskNotArchived(taskID); err != nil {
+		return nil, err
+	}
+
+	if _, err := s.TimeEntryRepo.FindRunningByUser(userID); err == nil {
+		return nil, apperrors.ErrTimerAlreadyRunning
+	} else if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, err
+	}
+
+	entry := &models.TimeEntry{
+		ID:        uuid.New(),
+		TaskID:    taskID,
+		UserID:    userID,
+		StartedAt: time.Now(),
+		Note:      req.Note,
+	}
+
+	// the partial unique index still rejects a timer started concurrently
+	if err := s.TimeEntryRepo.Create(entry); err != nil {
+		if _, findErr := s.TimeEntryRepo.FindRunningByUser(userID); findErr == nil {
+			return nil, apperrors.ErrTimerAlreadyRunning
+		}
+		return nil, err
+	}
+
+	// Log activity
+	if s.ActivityLogService != nil {
+		s.ActivityLogService.LogActivity(projectID, userID, "time.timer_started", map[string]interface{}{
+			"task_id":       taskID.String(),
+			"time_entry_id": entry.ID.String(),
+		})
+	}
+
+	saved, err := s.TimeEntryRepo.FindByID(entry.ID)
+	if err != nil {
+		return nil, err
+	}
+	return buildTimeEntryResponse(saved), nil
+}
+
+// StopTimer stops the timer currently running for the user
+func (s *TimeEntryService) StopTimer(userID uuid.UUID) (*dto.TimeEntryResponse, error) {
+	entry, err := s.TimeEntryRepo.FindRunningByUser(userID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, apperrors.ErrNoRunningTimer
+		}
+		return nil, err
+	}
+
+	stopped, err := s.TimeEntryRepo.Stop(entry, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	if !stopped {
+		return nil, apperrors.ErrNoRunningTimer
+	}
+
+	// Log activity
+	if s.ActivityLogService != nil {
+		if projectID, err := s.findTaskProject(entry.TaskID); err == nil {
+			s.ActivityLogService.LogActivity(projectID, userID, "time.timer_stopped", map[string]interface{}{
+				"task_id":          entry.TaskID.String(),
+				"time_entry_id":    entry.ID.String(),
+				"duration_seconds": entry.DurationSeconds,
+			})
+		}
+	}
+
+	return buildTimeEntryResponse(entry), nil
+}
+
+// GetRunningTimer retrieves the timer currently running for the user
+func (s *TimeEntryService) GetRunningTimer(userID uuid.UUID) (*dto.TimeEntryResponse, error) {
+	entry, err := s.TimeEntryRepo.FindRunningByUser(userID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, apperrors.ErrNoRunningTimer
+		}
+		return nil, err
+	}
+	return buildTimeEntryResponse(entry), nil
+}
+
+// CreateTimeEntry records a finished entry from either a start and end time or a start time and duration
+func (s *TimeEntryService) CreateTimeEntry(taskID, userID uuid.UUID, req *dto.CreateTimeEntryRequest) (*dto.TimeEntryResponse, error) {
+	projectID, err := s.findTaskProjectWithAccess(taskID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.ensureTaskNotArchived(taskID); err != nil {
+		return nil, err
+	}
+
+	startedAt, err := time.Parse(time.RFC3339, req.StartedAt)
+	if err != nil {
+		return nil, apperrors.ErrInvalidTimeEntry
+	}
+
+	var endedAt time.Time
+	switch {
+	case req.EndedAt != nil && req.DurationMinutes == nil:
+		endedAt, err = time.Parse(time.RFC3339, *req.EndedAt)
+		if err != nil {
+			return nil, apperrors.ErrInvalidTimeEntry
+		}
+	case req.DurationMinutes != nil && req.EndedAt == nil:
+		endedAt = startedAt.Add(time.Duration(*req.DurationMinutes) * time.Minute)
+	default:
+		return nil, apperrors.ErrInvalidTimeEntry
+	}
+	if !endedAt.After(startedAt) || endedAt.After(time.Now()) {
+		return nil, apperrors.ErrInvalidTimeEntry
+	}
+
+	entry := &models.TimeEntry{
+		ID:              uuid.New(),
+		TaskID:          taskID,
+		UserID:          userID,
+		StartedAt:       startedAt,
+		EndedAt:         &endedAt,
+		DurationSeconds: int64(endedAt.Sub(startedAt).Seconds()),
+		Note:            req.Note,
+	}
+
+	if err := s.TimeEntryRepo.Create(entry); err != nil {
+		return nil, err
+	}
+
+	// Log activity
+	if s.ActivityLogService != nil {
+		s.ActivityLogService.LogActivity(projectID, userID, "time.logged", map[string]interface{}{
+			"task_id":          taskID.String(),
+			"time_entry_id":    entry.ID.String(),
+			"duration_seconds": entry.DurationSeconds,
+		})
+	}
+
+	saved, err := s.TimeEntryRepo.FindByID(entry.ID)
+	if err != nil {
+		return nil, err
+	}
+	return buildTimeEntryResponse(saved), nil
+}
+
+// GetTaskTimeEntries retrieves the time entries of a task together with its estimate and total
+func (s *TimeEntryService) GetTaskTimeEntries(taskID, userID uuid.UUID, query *dto.TimeReportQuery) (*dto.TaskTimeEntriesResponse, error) {
+	if _, err := s.findTaskProjectWithAccess(taskID, userID); err != nil {
+		return nil, err
+	}
+
+	task, err := s.TaskRepo.FindByID(taskID)
+	if err != nil {
+		return nil, err
+	}
+
+	from, to, err := parseReportRange(query)
+	if err != nil {
+		return nil, err
+	}
+
+	entries, err := s.TimeEntryRepo.FindByTaskID(taskID, from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &dto.TaskTimeEntriesResponse{
+		TaskID:          taskID.String(),
+		EstimateMinutes: task.EstimateMinutes,
+		Entries:         []dto.TimeEntryResponse{},
+	}
+	for _, e := range entries {
+		resp.Entries = append(resp.Entries, *buildTimeEntryResponse(&e))
+		resp.TotalSeconds += e.DurationSeconds
+	}
+	return resp, nil
+}
+
+// DeleteTimeEntry removes a time entry; only its author or the project owner may do so
+func (s *TimeEntryService) DeleteTimeEntry(entryID, userID uuid.UUID) error {
+	entry, err := s.TimeEntryRepo.FindByID(entryID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return apperrors.ErrTimeEntryNotFound
+		}
+		return err
+	}
+
+	projectID, err := s.findTaskProject(entry.TaskID)
+	if err != nil {
+		return err
+	}
+
+	if entry.UserID != userID {
+		isOwner, err := s.ProjectRepo.IsOwner(projectID, userID)
+		if err != nil {
+			return err
+		}
+		if !isOwner {
+			return apperrors.ErrUnauthorizedTimeEntry
+		}
+	}
+
+	if err := s.ensureTaskNotArchived(entry.TaskID); err != nil {
+		return err
+	}
+
+	if err := s.TimeEntryRepo.Delete(entryID); err != nil {
+		return err
+	}
+
+	// Log activity
+	if s.ActivityLogService != nil {
+		s.ActivityLogService.LogActivity(projectID, userID, "time.deleted", map[string]interface{}{
+			"task_id":          entry.TaskID.String(),
+			"time_entry_id":    entry.ID.String(),
+			"duration_seconds": entry.DurationSeconds,
+		})
+	}
+
+	return nil
+}
+
+// GetProjectReport aggregates the time logged in a project by task or by user
+func (s *TimeEntryService) GetProjectReport(projectID, userID uuid.UUID, query *dto.TimeReportQuery) (*dto.TimeReportResponse, error) {
+	isMember, err := s.ProjectRepo.IsMember(projectID, userID)
+	if err != nil {
+		return nil, err
+	}
+	isOwner, err := s.ProjectRepo.IsOwner(projectID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if !isMember && !isOwner {
+		return nil, apperrors.ErrUnauthorizedProject
+	}
+
+	if query.GroupBy == "" {
+		query.GroupBy = "task"
+	}
+	if query.GroupBy != "task" && query.GroupBy != "user" {
+		return nil, apperrors.ErrInvalidReportQuery
+	}
+
+	filter := repository.TimeReportFilter{ProjectID: &projectID, GroupBy: query.GroupBy}
+	if query.UserID != "" {
+		id, err := uuid.Parse(query.UserID)
+		if err != nil {
+			return nil, apperrors.ErrInvalidReportQuery
+		}
+		filter.UserID = &id
+	}
+
+	return s.buildReport(filter, query)
+}
+
+// GetUserReport aggregates the time the user logged across all projects by project or by task
+func (s *TimeEntryService) GetUserReport(userID uuid.UUID, query *dto.TimeReportQuery) (*dto.TimeReportResponse, error) {
+	if query.GroupBy == "" {
+		query.GroupBy = "project"
+	}
+	if query.GroupBy != "project" && query.GroupBy != "task" {
+		return nil, apperrors.ErrInvalidReportQuery
+	}
+
+	return s.buildReport(repository.TimeReportFilter{UserID: &userID, GroupBy: query.GroupBy}, query)
+}
+
+// buildReport applies the date range of the query and runs the aggregation
+func (s *TimeEntryService) buildReport(filter repository.TimeReportFilter, query *dto.TimeReportQuery) (*dto.TimeReportResponse, error) {
+	from, to, err := parseReportRange(query)
+	if err != nil {
+		return nil, err
+	}
+	filter.From = from
+	filter.To = to
+
+	rows, err := s.TimeEntryRepo.Report(filter)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &dto.TimeReportResponse{
+		From:    from,
+		To:      to,
+		GroupBy: filter.GroupBy,
+		Groups:  []dto.TimeReportGroup{},
+	}
+	for _, row := range rows {
+		resp.Groups = append(resp.Groups, dto.TimeReportGroup{
+			ID:              row.GroupID.String(),
+			Name:            row.Name,
+			TotalSeconds:    row.TotalSeconds,
+			EntryCount:      row.EntryCount,
+			EstimateMinutes: row.EstimateMinutes,
+		})
+		resp.TotalSeconds += row.TotalSeconds
+	}
+	return resp, nil
+}
+
+// findTaskProject resolves the project a task belongs to
+func (s *TimeEntryService) findTaskProject(taskID uuid.UUID) (uuid.UUID, error) {
+	task, err := s.TaskRepo.FindByID(taskID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return uuid.Nil, apperrors.ErrTaskNotFound
+		}
+		return uuid.Nil, err
+	}
+
+	var board models.Board
+	if err := s.TaskRepo.DB.Select("project_id").Where("id = ?", task.BoardID).First(&board).Error; err != nil {
+		return uuid.Nil, err
+	}
+	return board.ProjectID, nil
+}
+
+// findTaskProjectWithAccess resolves the project of a task and ensures the user belongs to it
+func (s *TimeEntryService) findTaskProjectWithAccess(taskID, userID uuid.UUID) (uuid.UUID, error) {
+	projectID, err := s.findTaskProject(taskID)
+	if err != nil {
+		return uuid.Nil, err
+	}
+
+	isMember, err := s.ProjectRepo.IsMember(projectID, userID)
+	if err != nil {
+		return uuid.Nil, err
+	}
+	isOwner, err := s.ProjectRepo.IsOwner(projectID, userID)
+	if err != nil {
+		return uuid.Nil, err
+	}
+	if !isMember && !isOwner {
+		return uuid.Nil, apperrors.ErrUnauthorizedTask
+	}
+	return projectID, nil
+}
+
+// ensureTaskNotArchived rejects changes to the time entries of tasks in an archived project or board
+func (s *TimeEntryService) ensureTaskNotArchived(taskID uuid.UUID) error {
+	archived, err := s.TaskRepo.IsArchived(taskID)
+	if err != nil {
+		return err
+	}
+	if archived {
+		return apperrors.ErrProjectArchived
+	}
+	return nil
+}
+
+// parseReportRange parses the optional from/to bounds of a report query
+func parseReportRange(query *dto.TimeReportQuery) (*time.Time, *time.Time, error) {
+	var from, to *time.Time
+	if query.From != "" {
+		t, err := parseDateParam(query.From, false)
+		if err != nil {
+			return nil, nil, apperrors.ErrInvalidReportQuery
+		}
+		from = &t
+	}
+	if query.To != "" {
+		t, err := parseDateParam(query.To, true)
+		if err != nil {
+			return nil, nil, apperrors.ErrInvalidReportQuery
+		}
+		to = &t
+	}
+	if from != nil && to != nil && to.Before(*from) {
+		return nil, nil, apperrors.ErrInvalidReportQuery
+	}
+	return from, to, nil
+}
+
+// buildTimeEntryResponse converts a time entry model into its response DTO
+func buildTimeEntryResponse(entry *models.TimeEntry) *dto.TimeEntryResponse {
+	resp := &dto.TimeEntryResponse{
+		ID:     entry.ID.String(),
+		TaskID: entry.TaskID.String(),
+		User: dto.UserBasic{
+			ID:   entry.UserID.String(),
+			Name: entry.User.Name,
+		},
+		StartedAt:       entry.StartedAt,
+		EndedAt:         entry.EndedAt,
+		DurationSeconds: entry.DurationSeconds,
+		Note:            entry.Note,
+		IsRunning:       entry.EndedAt == nil,
+		CreatedAt:       entry.CreatedAt,
+	}
+
+	// a running timer reports the time elapsed so far
+	if resp.IsRunning {
+		resp.DurationSeconds = int64(time.Since(entry.StartedAt).Seconds())
+	}
+	return resp
+}
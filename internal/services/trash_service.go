@@ -0,0 +1,368 @@
+package services
+
+import (
+	"errors"
+	"log"
+	"sort"
+	"time"
+
+	"github.com/Hann-arc/task-management-backend/config"
+	"github.com/Hann-arc/task-management-backend/internal/dto"
+	apperrors "github.com/Hann-arc/task-management-backend/internal/errors"
+	"github.com/Hann-arc/task-management-backend/internal/models"
+	"github.com/Hann-arc/task-management-backend/internal/repository"
+	"github.com/Hann-arc/task-management-backend/internal/storage"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// trashPurgeBatch limits how many items of each kind a single purge run removes
+const trashPurgeBatch = 100
+
+type TrashService struct {
+	TrashRepo          *repository.TrashRepository
+	ProjectRepo        *repository.ProjectRepository
+	Storage            storage.Storage
+	ActivityLogService *ActivityLogService
+}
+
+// NewTrashService creates a new instance of TrashService
+func NewTrashService(trashRepo *repository.TrashRepository, projectRepo *repository.ProjectRepository, store storage.Storage, activityLogService *ActivityLogService) *TrashService {
+	return &TrashService{TrashRepo: trashRepo, ProjectRepo: projectRepo, Storage: store, ActivityLogService: activityLogService}
+}
+
+// GetProjectTrash lists the deleted boards, tasks and comments of a project, most recent first
+func (s *TrashService) GetProjectTrash(projectID, userID uuid.UUID) ([]dto.TrashItemResponse, error) {
+	if err := s.checkMember(projectID, userID); err != nil {
+		return nil, err
+	}
+
+	boards, err := s.TrashRepo.FindDeletedBoards(projectID)
+	if err != nil {
+		return nil, err
+	}
+	tasks, err := s.TrashRepo.FindDeletedTasks(projectID)
+	if err != nil {
+		return nil, err
+	}
+	comments, err := s.TrashRepo.FindDeletedComments(projectID)
+	if err != nil {
+		return nil, err
+	}
+
+	projectIDStr := projectID.String()
+	items := make([]dto.TrashItemResponse, 0, len(boards)+len(tasks)+len(comments))
+	for _, b := range boards {
+		items = append(items, buildTrashItem(b.ID, "board", b.Name, projectIDStr, b.DeletedAt.Time))
+	}
+	for _, t := range tasks {
+		item := buildTrashItem(t.ID, "task", t.Title, projectIDStr, t.DeletedAt.Time)
+		boardID := t.BoardID.String()
+		item.BoardID = &boardID
+		items = append(items, item)
+	}
+	for _, c := range comments {
+		item := buildTrashItem(c.ID, "comment", truncateTrashName(c.Content), projectIDStr, c.DeletedAt.Time)
+		taskID := c.TaskID.String()
+		item.TaskID = &taskID
+		items = append(items, item)
+	}
+
+	sort.SliceStable(items, func(i, j int) bool {
+		return items[i].DeletedAt.After(items[j].DeletedAt)
+	})
+
+	return items, nil
+}
+
+// GetDeletedProjects lists the deleted projects owned by the user
+func (s *TrashService) GetDeletedProjects(userID uuid.UUID) ([]dto.TrashItemResponse, error) {
+	projects, err := s.TrashRepo.FindDeletedProjectsByOwner(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	items := make([]dto.TrashItemResponse, 0, len(projects))
+	for _, p := range projects {
+		items = append(items, buildTrashItem(p.ID, "project", p.Name, p.ID.String(), p.DeletedAt.Time))
+	}
+
+	return items, nil
+}
+
+// RestoreProject restores a deleted project along with the content deleted with it
+func (s *TrashService) RestoreProject(projectID, userID uuid.UUID) error {
+	project, err := s.TrashRepo.FindDeletedProject(projectID)
+	if err != nil {
+		return trashLookupError(err)
+	}
+	if project.OwnerID != userID {
+		return apperrors.ErrUnauthorizedOwnerOnly
+	}
+
+	if err := s.TrashRepo.RestoreProject(project); err != nil {
+		return err
+	}
+
+	// Log activity
+	if s.ActivityLogService != nil {
+		s.ActivityLogService.LogActivity(projectID, userID, "project.restored", map[string]interface{}{
+			"project_id": projectID.String(),
+			"name":       project.Name,
+		})
+	}
+
+	return nil
+}
+
+// RestoreBoard restores a deleted board along with the tasks deleted with it
+func (s *TrashService) RestoreBoard(boardID, userID uuid.UUID) error {
+	board, err := s.TrashRepo.FindDeletedBoard(boardID)
+	if err != nil {
+		return trashLookupError(err)
+	}
+
+	if err := s.checkParent(&models.Project{}, board.ProjectID); err != nil {
+		return err
+	}
+
+	isOwner, err := s.ProjectRepo.IsOwner(board.ProjectID, userID)
+	if err != nil {
+		return err
+	}
+	if !isOwner {
+		return apperrors.ErrUnauthorizedBoardAction
+	}
+
+	if err := checkRestoreArchived(s.ProjectRepo.IsArchived(board.ProjectID)); err != nil {
+		return err
+	}
+
+	if err := s.TrashRepo.RestoreBoard(board); err != nil {
+		return err
+	}
+
+	// Log activity
+	if s.ActivityLogService != nil {
+		s.ActivityLogService.LogActivity(board.ProjectID, userID, "board.restored", map[string]interface{}{
+			"board_id": boardID.String(),
+			"name":     board.Name,
+		})
+	}
+
+	return nil
+}
+
+// RestoreTask restores a deleted task along with the comments deleted with it
+func (s *TrashService) RestoreTask(taskID, userID uuid.UUID) error {
+	task, err := s.TrashRepo.FindDeletedTask(taskID)
+	if err != nil {
+		return trashLookupError(err)
+	}
+
+	projectID, err := s.TrashRepo.BoardProjectID(task.BoardID)
+	if err != nil {
+		return err
+	}
+	if err := s.checkParent(&models.Project{}, projectID); err != nil {
+		return err
+	}
+	if err := s.checkParent(&models.Board{}, task.BoardID); err != nil {
+		return err
+	}
+
+	if err := s.checkMember(projectID, userID); err != nil {
+		return apperrors.ErrUnauthorizedTask
+	}
+
+	if err := checkRestoreArchived(s.ProjectRepo.IsBoardArchived(task.BoardID)); err != nil {
+		return err
+	}
+
+	if err := s.TrashRepo.RestoreTask(task); err != nil {
+		return err
+	}
+
+	// Log activity
+	if s.ActivityLogService != nil {
+		s.ActivityLogService.LogActivity(projectID, userID, "task.restored", map[string]interface{}{
+			"task_id":  taskID.String(),
+			"title":    task.Title,
+			"board_id": task.BoardID.String(),
+		})
+	}
+
+	return nil
+}
+
+// RestoreComment restores a deleted comment along with the replies deleted with it
+func (s *TrashService) RestoreComment(commentID, userID uuid.UUID) error {
+	comment, err := s.TrashRepo.FindDeletedComment(commentID)
+	if err != nil {
+		return trashLookupError(err)
+	}
+
+	boardID, projectID, err := s.TrashRepo.TaskProjectID(comment.TaskID)
+	if err != nil {
+		return err
+	}
+	if err := s.checkParent(&models.Project{}, projectID); err != nil {
+		return err
+	}
+	if err := s.checkParent(&models.Board{}, boardID); err != nil {
+		return err
+	}
+	if err := s.checkParent(&models.Task{}, comment.TaskID); err != nil {
+		return err
+	}
+	if comment.ParentID != nil {
+		if err := s.checkParent(&models.Comment{}, *comment.ParentID); err != nil {
+			return err
+		}
+	}
+
+	isOwner, err := s.ProjectRepo.IsOwner(projectID, userID)
+	if err != nil {
+		return err
+	}
+	if !isOwner && comment.UserID != userID {
+		return apperrors.ErrUnauthorizedOwnerOnly
+	}
+
+	if err := checkRestoreArchived(s.ProjectRepo.IsBoardArchived(boardID)); err != nil {
+		return err
+	}
+
+	if err := s.TrashRepo.RestoreComment(comment); err != nil {
+		return err
+	}
+
+	// Log activity
+	if s.ActivityLogService != nil {
+		s.ActivityLogService.LogActivity(projectID, userID, "comment.restored", map[string]interface{}{
+			"comment_id": commentID.String(),
+			"task_id":    comment.TaskID.String(),
+		})
+	}
+
+	return nil
+}
+
+// PurgeExpired permanently deletes items that stayed in the trash longer than the retention
+// period, removing the files of their attachments from storage first
+func (s *TrashService) PurgeExpired() error {
+	cutoff := time.Now().Add(-config.TrashRetention)
+
+	if err := s.purgeBatch(&models.Comment{}, cutoff, s.TrashRepo.FindCommentAttachmentURLs, s.TrashRepo.PurgeComments); err != nil {
+		return err
+	}
+	if err := s.TrashRepo.PurgeRows(&models.Notification{}, cutoff); err != nil {
+		return err
+	}
+
+	if err := s.purgeBatch(&models.Task{}, cutoff, func(ids []uuid.UUID) ([]string, error) {
+		return s.TrashRepo.FindAttachmentURLs(ids, nil, nil)
+	}, s.TrashRepo.PurgeTasks); err != nil {
+		return err
+	}
+
+	if err := s.purgeBatch(&models.Board{}, cutoff, func(ids []uuid.UUID) ([]string, error) {
+		return s.TrashRepo.FindAttachmentURLs(nil, ids, nil)
+	}, s.TrashRepo.PurgeBoards); err != nil {
+		return err
+	}
+
+	return s.purgeBatch(&models.Project{}, cutoff, func(ids []uuid.UUID) ([]string, error) {
+		return s.TrashRepo.FindAttachmentURLs(nil, nil, ids)
+	}, s.TrashRepo.PurgeProjects)
+}
+
+// purgeBatch removes the stored files of one batch of expired items and then deletes the rows;
+// if a file cannot be removed the rows are kept so the next run can retry
+func (s *TrashService) purgeBatch(model interface{}, cutoff time.Time, findURLs func([]uuid.UUID) ([]string, error), purge func([]uuid.UUID) error) error {
+	ids, err := s.TrashRepo.FindExpiredIDs(model, cutoff, trashPurgeBatch)
+	if err != nil || len(ids) == 0 {
+		return err
+	}
+
+	urls, err := findURLs(ids)
+	if err != nil {
+		return err
+	}
+	for _, url := range urls {
+		if err := s.Storage.Delete(url); err != nil {
+			log.Println("Failed to delete attachment from storage:", err)
+			return err
+		}
+	}
+
+	return purge(ids)
+}
+
+// checkMember ensures the user is the owner or a member of the project
+func (s *TrashService) checkMember(projectID, userID uuid.UUID) error {
+	isMember, err := s.ProjectRepo.IsMember(projectID, userID)
+	if err != nil {
+		return err
+	}
+	isOwner, err := s.ProjectRepo.IsOwner(projectID, userID)
+	if err != nil {
+		return err
+	}
+	if !isMember && !isOwner {
+		return apperrors.ErrUnauthorizedProject
+	}
+	return nil
+}
+
+// checkParent rejects a restore while the item's container is still in the trash
+func (s *TrashService) checkParent(model interface{}, id uuid.UUID) error {
+	deleted, err := s.TrashRepo.IsDeleted(model, id)
+	if err != nil {
+		return err
+	}
+	if deleted {
+		return apperrors.ErrTrashParentDeleted
+	}
+	return nil
+}
+
+// checkRestoreArchived rejects restoring content into an archived project or board
+func checkRestoreArchived(archived bool, err error) error {
+	if err != nil {
+		return err
+	}
+	if archived {
+		return apperrors.ErrProjectArchived
+	}
+	return nil
+}
+
+// trashLookupError converts a missing record into ErrTrashItemNotFound
+func trashLookupError(err error) error {
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return apperrors.ErrTrashItemNotFound
+	}
+	return err
+}
+
+// truncateTrashName shortens long content so it can be shown as an item name
+func truncateTrashName(content string) string {
+	runes := []rune(content)
+	if len(runes) <= 80 {
+		return content
+	}
+	return string(runes[:80]) + "..."
+}
+
+// buildTrashItem creates a trash entry with its expiry derived from the retention period
+func buildTrashItem(id uuid.UUID, itemType, name, projectID string, deletedAt time.Time) dto.TrashItemResponse {
+	return dto.TrashItemResponse{
+		ID:        id.String(),
+		Type:      itemType,
+		Name:      name,
+		ProjectID: projectID,
+		DeletedAt: deletedAt,
+		ExpiresAt: deletedAt.Add(config.TrashRetention),
+	}
+}
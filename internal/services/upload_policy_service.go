@@ -0,0 +1,295 @@
+package services
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+
+	"github.com/Hann-arc/task-management-backend/config"
+	"github.com/Hann-arc/task-management-backend/internal/dto"
+	apperrors "github.com/Hann-arc/task-management-backend/internal/errors"
+	"github.com/Hann-arc/task-management-backend/internal/models"
+	"github.com/Hann-arc/task-management-backend/internal/repository"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type UploadPolicyService struct {
+	UploadPolicyRepo   *repository.UploadPolicyRepository
+	ProjectRepo        *repository.ProjectRepository
+	ActivityLogService *ActivityLogService
+}
+
+// NewUploadPolicyService creates a new instance of UploadPolicyService
+func NewUploadPolicyService(uploadPolicyRepo *repository.UploadPolicyRepository, projectRepo *repository.ProjectRepository, activityLogService *ActivityLogService) *UploadPolicyService {
+	return &UploadPolicyService{UploadPolicyRepo: uploadPolicyRepo, ProjectRepo: projectRepo, ActivityLogService: activityLogService}
+}
+
+// GetPolicy retrieves the effective upload limits and storage usage of a project
+func (s *UploadPolicyService) GetPolicy(projectID, userID uuid.UUID) (*dto.UploadPolicyResponse, error) {
+	isMember, err := s.ProjectRepo.IsMember(projectID, userID)
+	if err != nil {
+		return nil, err
+	}
+	isOwner, err := s.ProjectRepo.IsOwner(projectID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if !isMember && !isOwner {
+		return nil, apperrors.ErrUnauthorizedProject
+	}
+
+	return s.buildPolicyResponse(projectID)
+}
+
+// UpdatePolicy changes the upload limits of a project; only the owner may do so
+func (s *UploadPolicyService) UpdatePolicy(projectID, userID uuid.UUID, req *dto.UpdateUploadPolicyRequest) (*dto.UploadPolicyResponse, error) {
+	isOwner, err := s.ProjectRepo.IsOwner(projectID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if !isOwner {
+		return nil, apperrors.ErrUnauthorizedOwnerOnly
+	}
+
+	archived, err := s.ProjectRepo.IsArchived(projectID)
+	if err != nil {
+		return nil, err
+	}
+	if archived {
+		return nil, apperrors.ErrProjectArchived
+	}
+
+	policy, err := s.findPolicy(projectID)
+	if err != nil {
+		return nil, err
+	}
+	if policy == nil {
+		policy = &models.ProjectUploadPolicy{ProjectID: projectID}
+	}
+
+	if req.MaxFileSize != nil {
+		if *req.MaxFileSize < 0 || *req.MaxFileSize > config.UploadBodyLimit {
+			return nil, apperrors.ErrInvalidUploadPolicy
+		}
+		policy.MaxFileSize = *req.MaxFileSize
+	}
+	if req.StorageQuota != nil {
+		if *req.StorageQuota < 0 {
+			return nil, apperrors.ErrInvalidUploadPolicy
+		}
+		policy.StorageQuota = *req.StorageQuota
+	}
+	if req.AllowedMimeTypes != nil {
+		if policy.AllowedMimeTypes, err = encodeMimePatterns(*req.AllowedMimeTypes); err != nil {
+			return nil, err
+		}
+	}
+	if req.DeniedMimeTypes != nil {
+		if policy.DeniedMimeTypes, err = encodeMimePatterns(*req.DeniedMimeTypes); err != nil {
+			return nil, err
+		}
+	}
+	policy.UpdatedBy = userID
+
+	if err := s.UploadPolicyRepo.Save(policy); err != nil {
+		return nil, err
+	}
+
+	resp, err := s.buildPolicyResponse(projectID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Log activity
+	if s.ActivityLogService != nil {
+		s.ActivityLogService.LogActivity(projectID, userID, "project.upload_policy_updated", map[string]interface{}{
+			"max_file_size":      resp.MaxFileSize,
+			"storage_quota":      resp.StorageQuota,
+			"allowed_mime_types": resp.AllowedMimeTypes,
+			"denied_mime_types":  resp.DeniedMimeTypes,
+		})
+	}
+
+	return resp, nil
+}
+
+// CheckTaskUpload ensures a file of the given size and sniffed MIME type fits the policy of
+// the project the task belongs to; the quota is checked again when the attachment is saved
+func (s *UploadPolicyService) CheckTaskUpload(taskID uuid.UUID, size int64, mimeType string) error {
+	projectID, err := s.UploadPolicyRepo.FindTaskProjectID(taskID)
+	if err != nil {
+		return err
+	}
+
+	policy, err := s.findPolicy(projectID)
+	if err != nil {
+		return err
+	}
+
+	maxFileSize, quota := effectiveUploadLimits(policy)
+	if size > maxFileSize {
+		return apperrors.ErrFileTooLarge
+	}
+
+	if policy != nil {
+		if matchesMimePattern(decodeMimePatterns(policy.DeniedMimeTypes), mimeType) {
+			return apperrors.ErrMimeTypeNotAllowed
+		}
+		if allowed := decodeMimePatterns(policy.AllowedMimeTypes); len(allowed) > 0 && !matchesMimePattern(allowed, mimeType) {
+			return apperrors.ErrMimeTypeNotAllowed
+		}
+	}
+
+	return s.checkStorage(projectID, size, quota)
+}
+
+// CheckTaskStorage ensures size more bytes fit in the storage quota of the project a task
+// belongs to
+func (s *UploadPolicyService) CheckTaskStorage(taskID uuid.UUID, size int64) error {
+	projectID, err := s.UploadPolicyRepo.FindTaskProjectID(taskID)
+	if err != nil {
+		return err
+	}
+
+	policy, err := s.findPolicy(projectID)
+	if err != nil {
+		return err
+	}
+
+	_, quota := effectiveUploadLimits(policy)
+	return s.checkStorage(projectID, size, quota)
+}
+
+// TaskStorageQuota returns the storage quota of the project a task belongs to; zero means unlimited
+func (s *UploadPolicyService) TaskStorageQuota(taskID uuid.UUID) (int64, error) {
+	projectID, err := s.UploadPolicyRepo.FindTaskProjectID(taskID)
+	if err != nil {
+		return 0, err
+	}
+
+	policy, err := s.findPolicy(projectID)
+	if err != nil {
+		return 0, err
+	}
+
+	_, quota := effectiveUploadLimits(policy)
+	return quota, nil
+}
+
+// checkStorage compares the storage used by a project plus size against its quota
+func (s *UploadPolicyService) checkStorage(projectID uuid.UUID, size, quota int64) error {
+	if quota <= 0 {
+		return nil
+	}
+
+	usage, err := s.UploadPolicyRepo.GetStorageUsage(projectID)
+	if err != nil {
+		return err
+	}
+	if usage.UsedBytes+size > quota {
+		return apperrors.ErrStorageQuotaExceeded
+	}
+	return nil
+}
+
+// findPolicy loads the policy of a project, returning nil when it uses the defaults
+func (s *UploadPolicyService) findPolicy(projectID uuid.UUID) (*models.ProjectUploadPolicy, error) {
+	policy, err := s.UploadPolicyRepo.FindByProjectID(projectID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return policy, nil
+}
+
+// buildPolicyResponse combines the effective policy of a project with its storage usage
+func (s *UploadPolicyService) buildPolicyResponse(projectID uuid.UUID) (*dto.UploadPolicyResponse, error) {
+	policy, err := s.findPolicy(projectID)
+	if err != nil {
+		return nil, err
+	}
+	usage, err := s.UploadPolicyRepo.GetStorageUsage(projectID)
+	if err != nil {
+		return nil, err
+	}
+
+	maxFileSize, quota := effectiveUploadLimits(policy)
+	resp := &dto.UploadPolicyResponse{
+		ProjectID:        projectID.String(),
+		MaxFileSize:      maxFileSize,
+		StorageQuota:     quota,
+		AllowedMimeTypes: []string{},
+		DeniedMimeTypes:  []string{},
+		IsDefault:        policy == nil,
+		Usage: dto.StorageUsageResponse{
+			UsedBytes:       usage.UsedBytes,
+			AttachmentCount: usage.AttachmentCount,
+		},
+	}
+
+	if policy != nil {
+		resp.AllowedMimeTypes = append(resp.AllowedMimeTypes, decodeMimePatterns(policy.AllowedMimeTypes)...)
+		resp.DeniedMimeTypes = append(resp.DeniedMimeTypes, decodeMimePatterns(policy.DeniedMimeTypes)...)
+		resp.UpdatedAt = &policy.UpdatedAt
+	}
+
+	if quota > 0 {
+		remaining := max(quota-usage.UsedBytes, 0)
+		resp.Usage.RemainingBytes = &remaining
+	}
+
+	return resp, nil
+}
+
+// effectiveUploadLimits applies the server defaults to the unset limits of a policy
+func effectiveUploadLimits(policy *models.ProjectUploadPolicy) (int64, int64) {
+	maxFileSize, quota := config.UploadMaxFileSize, config.UploadStorageQuota
+	if policy != nil {
+		if policy.MaxFileSize > 0 {
+			maxFileSize = policy.MaxFileSize
+		}
+		if policy.StorageQuota > 0 {
+			quota = policy.StorageQuota
+		}
+	}
+	return maxFileSize, quota
+}
+
+// encodeMimePatterns validates and normalizes MIME patterns such as "application/pdf" or "image/*"
+func encodeMimePatterns(patterns []string) ([]byte, error) {
+	normalized := []string{}
+	for _, p := range patterns {
+		p = strings.ToLower(strings.TrimSpace(p))
+		mainType, subType, ok := strings.Cut(p, "/")
+		if !ok || mainType == "" || subType == "" || mainType == "*" || strings.ContainsAny(p, " ;,") {
+			return nil, apperrors.ErrInvalidUploadPolicy
+		}
+		if !containsString(normalized, p) {
+			normalized = append(normalized, p)
+		}
+	}
+	return json.Marshal(normalized)
+}
+
+// decodeMimePatterns reads the MIME patterns stored on a policy
+func decodeMimePatterns(raw []byte) []string {
+	var patterns []string
+	if len(raw) > 0 {
+		json.Unmarshal(raw, &patterns)
+	}
+	return patterns
+}
+
+// matchesMimePattern reports whether the MIME type matches one of the patterns
+func matchesMimePattern(patterns []string, mimeType string) bool {
+	mainType, _, _ := strings.Cut(mimeType, "/")
+	for _, p := range patterns {
+		if p == mimeType || p == mainType+"/*" {
+			return true
+		}
+	}
+	return false
+}
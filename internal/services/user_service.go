@@ -2,8 +2,10 @@ package services
 
 import (
 	"errors"
+	"mime/multipart"
 
 	"github.com/Hann-arc/task-management-backend/internal/dto"
+	"github.com/Hann-arc/task-management-backend/internal/storage"
 	"github.com/Hann-arc/task-management-backend/internal/utils"
 	"gorm.io/gorm"
 
@@ -12,13 +14,43 @@ import (
 	"github.com/google/uuid"
 )
 
+// avatarMaxSize caps the size of uploaded avatar images
+const avatarMaxSize = 5 * 1024 * 1024
+
+// avatarMimeTypes lists the image types accepted as avatars
+var avatarMimeTypes = map[string]bool{
+	"image/jpeg": true,
+	"image/png":  true,
+	"image/gif":  true,
+	"image/webp": true,
+}
+
 type UserService struct {
 	userRepo *repository.UserRepository
+	storage  storage.Storage
 }
 
 // NewUserService creates a new instance of UserService
-func NewUserService(repo *repository.UserRepository) *UserService {
-	return &UserService{userRepo: repo}
+func NewUserService(repo *repository.UserRepository, store storage.Storage) *UserService {
+	return &UserService{userRepo: repo, storage: store}
+}
+
+// UploadAvatar stores an avatar image and returns its URL; the type is sniffed from the content
+// rather than taken from the Content-Type sent by the client
+func (s *UserService) UploadAvatar(file *multipart.FileHeader) (string, error) {
+	if file.Size > avatarMaxSize {
+		return "", apperrors.ErrAvatarTooLarge
+	}
+
+	mimeType, err := utils.DetectMimeType(file)
+	if err != nil {
+		return "", err
+	}
+	if !avatarMimeTypes[mimeType] {
+		return "", apperrors.ErrInvalidAvatarType
+	}
+
+	return storage.UploadFile(s.storage, "avatars", file, mimeType)
 }
 
 // GetUserByID retrieves a user by their ID
@@ -62,7 +94,7 @@ func (s *UserService) UpdateUser(id uuid.UUID, req *dto.UpdateUserRequest) (*dto
 
 	if req.AvatarUrl != nil {
 		if user.AvatarUrl != "" {
-			utils.DeleteFromCloudinary(user.AvatarUrl)
+			s.storage.Delete(user.AvatarUrl)
 		}
 		data["avatar_url"] = *req.AvatarUrl
 	}
@@ -0,0 +1,198 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/cloudinary/cloudinary-go/v2"
+	"github.com/cloudinary/cloudinary-go/v2/api"
+	"github.com/cloudinary/cloudinary-go/v2/api/admin"
+	"github.com/cloudinary/cloudinary-go/v2/api/uploader"
+)
+
+const (
+	// cloudinaryTimeout bounds a whole download, including reading the body
+	cloudinaryTimeout = 2 * time.Minute
+	// cloudinaryLinkTTL is how long the signed links Open downloads through stay valid
+	cloudinaryLinkTTL = 5 * time.Minute
+	// cloudinaryPublicFolder holds the files that are linked to directly, such as avatars
+	cloudinaryPublicFolder = "avatars"
+)
+
+// CloudinaryStorage keeps files in a Cloudinary folder. Files are uploaded with the
+// authenticated delivery type, so their URLs cannot be fetched without a signature; only
+// avatars stay public because their URLs are handed to clients as they are
+type CloudinaryStorage struct {
+	Cld    *cloudinary.Cloudinary
+	Folder string
+	Client *http.Client
+}
+
+// NewCloudinaryStorage creates a new instance of CloudinaryStorage
+func NewCloudinaryStorage(cld *cloudinary.Cloudinary, folder string) *CloudinaryStorage {
+	return &CloudinaryStorage{
+		Cld:    cld,
+		Folder: folder,
+		Client: &http.Client{Timeout: cloudinaryTimeout},
+	}
+}
+
+// Put uploads the content to Cloudinary; the key only contributes its folder, since
+// Cloudinary assigns the public ID itself
+func (s *CloudinaryStorage) Put(key string, content io.Reader, size int64, contentType string) (string, error) {
+	folder := s.Folder
+	if i := strings.LastIndex(key, "/"); i > 0 {
+		folder += "/" + key[:i]
+	}
+
+	deliveryType := api.DeliveryType(api.Authenticated)
+	if strings.HasPrefix(key, cloudinaryPublicFolder+"/") {
+		deliveryType = api.Upload
+	}
+
+	uploadResult, err := s.Cld.Upload.Upload(
+		context.Background(),
+		content,
+		uploader.UploadParams{
+			Folder: folder,
+			Type:   deliveryType,
+		},
+	)
+	if err != nil {
+		return "", err
+	}
+
+	return uploadResult.SecureURL, nil
+}
+
+// Open downloads the asset behind a Cloudinary delivery URL through a short-lived signed
+// download link, which works whatever the delivery type of the asset
+func (s *CloudinaryStorage) Open(fileURL string) (io.ReadCloser, error) {
+	asset, ok := parseCloudinaryURL(fileURL)
+	if !ok {
+		return nil, os.ErrNotExist
+	}
+
+	expiresAt := time.Now().Add(cloudinaryLinkTTL)
+	downloadURL, err := s.Cld.Upload.PrivateDownloadURL(uploader.PrivateDownloadURLParams{
+		PublicID:     asset.PublicID,
+		Format:       asset.Format,
+		DeliveryType: asset.DeliveryType,
+		ExpiresAt:    &expiresAt,
+		ResourceType: api.AssetType(asset.ResourceType),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := s.Client.Get(downloadURL)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode == http.StatusNotFound {
+		resp.Body.Close()
+		return nil, os.ErrNotExist
+	}
+	if resp.StatusCode != http.StatusOK {
+		resp.Body.Close()
+		return nil, fmt.Errorf("cloudinary download failed with status %d", resp.StatusCode)
+	}
+	return resp.Body, nil
+}
+
+// Delete removes the asset behind a Cloudinary delivery URL
+func (s *CloudinaryStorage) Delete(fileURL string) error {
+	asset, ok := parseCloudinaryURL(fileURL)
+	if !ok {
+		return nil
+	}
+
+	_, err := s.Cld.Admin.DeleteAssets(
+		context.Background(),
+		admin.DeleteAssetsParams{
+			AssetType:    api.AssetType(asset.ResourceType),
+			DeliveryType: api.DeliveryType(asset.DeliveryType),
+			PublicIDs:    []string{asset.PublicID},
+		},
+	)
+	return err
+}
+
+// MakePrivate moves an asset uploaded with the public upload delivery type to the
+// authenticated type and returns its new URL; other URLs are returned unchanged
+func (s *CloudinaryStorage) MakePrivate(fileURL string) (string, error) {
+	asset, ok := parseCloudinaryURL(fileURL)
+	if !ok || asset.DeliveryType != string(api.Upload) {
+		return fileURL, nil
+	}
+
+	result, err := s.Cld.Upload.Rename(context.Background(), uploader.RenameParams{
+		FromPublicID: asset.PublicID,
+		ToPublicID:   asset.PublicID,
+		Type:         string(api.Upload),
+		ToType:       api.Authenticated,
+		ResourceType: asset.ResourceType,
+		Invalidate:   api.Bool(true),
+	})
+	if err != nil {
+		return "", err
+	}
+	if result.Error != nil {
+		return "", fmt.Errorf("cloudinary rename failed: %v", result.Error)
+	}
+	return result.SecureURL, nil
+}
+
+// cloudinaryAsset identifies an asset by the parts of its delivery URL
+type cloudinaryAsset struct {
+	ResourceType string
+	DeliveryType string
+	PublicID     string
+	Format       string
+}
+
+// parseCloudinaryURL splits a delivery URL of the form
+// https://res.cloudinary.com/<cloud>/<resource type>/<delivery type>/[s--<signature>--/][v<version>/]<public ID>[.<format>]
+func parseCloudinaryURL(fileURL string) (cloudinaryAsset, bool) {
+	u, err := url.Parse(fileURL)
+	if err != nil || u.Host == "" {
+		return cloudinaryAsset{}, false
+	}
+
+	parts := strings.Split(strings.TrimPrefix(u.Path, "/"), "/")
+	if len(parts) < 4 {
+		return cloudinaryAsset{}, false
+	}
+	asset := cloudinaryAsset{ResourceType: parts[1], DeliveryType: parts[2]}
+
+	rest := parts[3:]
+	if len(rest) > 1 && strings.HasPrefix(rest[0], "s--") && strings.HasSuffix(rest[0], "--") {
+		rest = rest[1:]
+	}
+	if len(rest) > 1 && strings.HasPrefix(rest[0], "v") {
+		if _, err := strconv.ParseUint(rest[0][1:], 10, 64); err == nil {
+			rest = rest[1:]
+		}
+	}
+
+	// raw assets keep their extension as part of the public ID
+	asset.PublicID = strings.Join(rest, "/")
+	if asset.ResourceType != string(api.File) {
+		name := rest[len(rest)-1]
+		if i := strings.LastIndex(name, "."); i > 0 {
+			asset.Format = name[i+1:]
+			asset.PublicID = strings.TrimSuffix(asset.PublicID, "."+asset.Format)
+		}
+	}
+	if asset.PublicID == "" {
+		return cloudinaryAsset{}, false
+	}
+	return asset, true
+}
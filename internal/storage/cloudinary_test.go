@@ -0,0 +1,58 @@
+package storage
+
+import "testing"
+
+func TestParseCloudinaryURL(t *testing.T) {
+	tests := []struct {
+		name   string
+		url    string
+		want   cloudinaryAsset
+		wantOK bool
+	}{
+		{
+			name:   "public image",
+			url:    "https://res.cloudinary.com/demo/image/upload/v1712345678/attachments/abc123.png",
+			want:   cloudinaryAsset{ResourceType: "image", DeliveryType: "upload", PublicID: "attachments/abc123", Format: "png"},
+			wantOK: true,
+		},
+		{
+			name:   "signed authenticated image",
+			url:    "https://res.cloudinary.com/demo/image/authenticated/s--AbCd1234--/v1712345678/attachments/abc123.jpg",
+			want:   cloudinaryAsset{ResourceType: "image", DeliveryType: "authenticated", PublicID: "attachments/abc123", Format: "jpg"},
+			wantOK: true,
+		},
+		{
+			name:   "raw file keeps its extension",
+			url:    "https://res.cloudinary.com/demo/raw/authenticated/v1/attachments/report.pdf",
+			want:   cloudinaryAsset{ResourceType: "raw", DeliveryType: "authenticated", PublicID: "attachments/report.pdf"},
+			wantOK: true,
+		},
+		{
+			name:   "without version",
+			url:    "https://res.cloudinary.com/demo/video/upload/clip.mp4",
+			want:   cloudinaryAsset{ResourceType: "video", DeliveryType: "upload", PublicID: "clip", Format: "mp4"},
+			wantOK: true,
+		},
+		{
+			name:   "folder named like a version",
+			url:    "https://res.cloudinary.com/demo/image/upload/v2/vintage/photo.png",
+			want:   cloudinaryAsset{ResourceType: "image", DeliveryType: "upload", PublicID: "vintage/photo", Format: "png"},
+			wantOK: true,
+		},
+		{name: "local path", url: "/v1/api/files/attachments/abc123.png"},
+		{name: "too short", url: "https://res.cloudinary.com/demo/image/upload"},
+		{name: "malformed", url: "://res.cloudinary.com"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := parseCloudinaryURL(tt.url)
+			if ok != tt.wantOK {
+				t.Fatalf("expected ok %v, got %v", tt.wantOK, ok)
+			}
+			if got != tt.want {
+				t.Errorf("expected %+v, got %+v", tt.want, got)
+			}
+		})
+	}
+}
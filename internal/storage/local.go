@@ -0,0 +1,108 @@
+package storage
+
+import (
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// ErrInvalidKey is returned for keys that would resolve outside of the storage directory
+var ErrInvalidKey = errors.New("invalid storage key")
+
+// LocalStorage keeps files in a directory on disk; they are served by an authenticated
+// download route mounted at BaseURL
+type LocalStorage struct {
+	Dir     string
+	BaseURL string
+}
+
+// NewLocalStorage creates a new instance of LocalStorage, creating the directory if needed
+func NewLocalStorage(dir, baseURL string) (*LocalStorage, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, err
+	}
+	return &LocalStorage{Dir: dir, BaseURL: strings.TrimSuffix(baseURL, "/")}, nil
+}
+
+// Put writes the content to a file under the storage directory
+func (s *LocalStorage) Put(key string, content io.Reader, size int64, contentType string) (string, error) {
+	filePath, err := s.Path(key)
+	if err != nil {
+		return "", err
+	}
+	if err := os.MkdirAll(filepath.Dir(filePath), 0o755); err != nil {
+		return "", err
+	}
+
+	dst, err := os.Create(filePath)
+	if err != nil {
+		return "", err
+	}
+	if _, err := io.Copy(dst, content); err != nil {
+		dst.Close()
+		os.Remove(filePath)
+		return "", err
+	}
+	if err := dst.Close(); err != nil {
+		os.Remove(filePath)
+		return "", err
+	}
+
+	return s.URL(key), nil
+}
+
+// Open opens the file behind a URL
+func (s *LocalStorage) Open(fileURL string) (io.ReadCloser, error) {
+	key, ok := s.Key(fileURL)
+	if !ok {
+		return nil, os.ErrNotExist
+	}
+	filePath, err := s.Path(key)
+	if err != nil {
+		return nil, err
+	}
+	return os.Open(filePath)
+}
+
+// Delete removes the file behind a URL; files that are already gone are ignored
+func (s *LocalStorage) Delete(fileURL string) error {
+	key, ok := s.Key(fileURL)
+	if !ok {
+		return nil
+	}
+	filePath, err := s.Path(key)
+	if err != nil {
+		return err
+	}
+	if err := os.Remove(filePath); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	return nil
+}
+
+// URL returns the download URL of a key
+func (s *LocalStorage) URL(key string) string {
+	return s.BaseURL + "/" + key
+}
+
+// Key extracts the key from a download URL, reporting false for URLs of other backends
+func (s *LocalStorage) Key(fileURL string) (string, bool) {
+	if !strings.HasPrefix(fileURL, s.BaseURL+"/") {
+		return "", false
+	}
+	return strings.TrimPrefix(fileURL, s.BaseURL+"/"), true
+}
+
+// Path resolves a key to its file path, rejecting keys that escape the storage directory
+func (s *LocalStorage) Path(key string) (string, error) {
+	if key == "" || strings.Contains(key, "\\") {
+		return "", ErrInvalidKey
+	}
+	cleaned := filepath.Clean("/" + key)
+	if cleaned == "/" || cleaned != "/"+key {
+		return "", ErrInvalidKey
+	}
+	return filepath.Join(s.Dir, filepath.FromSlash(cleaned)), nil
+}
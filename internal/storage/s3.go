@@ -0,0 +1,106 @@
+package storage
+
+import (
+	"context"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/minio/minio-go/v7"
+	"github.com/minio/minio-go/v7/pkg/credentials"
+)
+
+// S3Storage keeps files in a bucket of an S3-compatible service such as MinIO
+type S3Storage struct {
+	Client  *minio.Client
+	Bucket  string
+	BaseURL string
+}
+
+// S3Config holds the connection settings of an S3-compatible service
+type S3Config struct {
+	Endpoint  string
+	AccessKey string
+	SecretKey string
+	Bucket    string
+	Region    string
+	UseSSL    bool
+	// PublicURL is the base URL objects are reachable at; it defaults to the bucket on the endpoint
+	PublicURL string
+}
+
+// NewS3Storage creates a new instance of S3Storage, creating the bucket if it does not exist
+func NewS3Storage(cfg S3Config) (*S3Storage, error) {
+	client, err := minio.New(cfg.Endpoint, &minio.Options{
+		Creds:  credentials.NewStaticV4(cfg.AccessKey, cfg.SecretKey, ""),
+		Secure: cfg.UseSSL,
+		Region: cfg.Region,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	ctx := context.Background()
+	exists, err := client.BucketExists(ctx, cfg.Bucket)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		if err := client.MakeBucket(ctx, cfg.Bucket, minio.MakeBucketOptions{Region: cfg.Region}); err != nil {
+			return nil, err
+		}
+	}
+
+	baseURL := cfg.PublicURL
+	if baseURL == "" {
+		scheme := "http"
+		if cfg.UseSSL {
+			scheme = "https"
+		}
+		baseURL = scheme + "://" + cfg.Endpoint + "/" + cfg.Bucket
+	}
+
+	return &S3Storage{Client: client, Bucket: cfg.Bucket, BaseURL: strings.TrimSuffix(baseURL, "/")}, nil
+}
+
+// Put uploads the content as an object under the key
+func (s *S3Storage) Put(key string, content io.Reader, size int64, contentType string) (string, error) {
+	_, err := s.Client.PutObject(context.Background(), s.Bucket, key, content, size, minio.PutObjectOptions{
+		ContentType: contentType,
+	})
+	if err != nil {
+		return "", err
+	}
+	return s.BaseURL + "/" + key, nil
+}
+
+// Open streams the object behind a URL, so the bucket itself can stay private
+func (s *S3Storage) Open(fileURL string) (io.ReadCloser, error) {
+	if !strings.HasPrefix(fileURL, s.BaseURL+"/") {
+		return nil, os.ErrNotExist
+	}
+	key := strings.TrimPrefix(fileURL, s.BaseURL+"/")
+
+	object, err := s.Client.GetObject(context.Background(), s.Bucket, key, minio.GetObjectOptions{})
+	if err != nil {
+		return nil, err
+	}
+	// GetObject is lazy, so make sure the object exists before handing it out
+	if _, err := object.Stat(); err != nil {
+		object.Close()
+		if minio.ToErrorResponse(err).Code == "NoSuchKey" {
+			return nil, os.ErrNotExist
+		}
+		return nil, err
+	}
+	return object, nil
+}
+
+// Delete removes the object behind a URL; URLs outside of the bucket are ignored
+func (s *S3Storage) Delete(fileURL string) error {
+	if !strings.HasPrefix(fileURL, s.BaseURL+"/") {
+		return nil
+	}
+	key := strings.TrimPrefix(fileURL, s.BaseURL+"/")
+	return s.Client.RemoveObject(context.Background(), s.Bucket, key, minio.RemoveObjectOptions{})
+}
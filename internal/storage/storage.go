@@ -0,0 +1,37 @@
+package storage
+
+import (
+	"io"
+	"mime/multipart"
+	"path"
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+// Storage is a backend that attachment files are written to; files are identified by the
+// URL returned from Put, which is what gets stored on the attachment
+type Storage interface {
+	// Put stores the content under the key and returns the URL of the file
+	Put(key string, content io.Reader, size int64, contentType string) (string, error)
+	// Open streams the file behind a URL previously returned by Put
+	Open(fileURL string) (io.ReadCloser, error)
+	// Delete removes the file behind a URL previously returned by Put
+	Delete(fileURL string) error
+}
+
+// NewKey builds a unique object key in the folder, keeping the extension of the original filename
+func NewKey(folder, filename string) string {
+	return folder + "/" + uuid.New().String() + strings.ToLower(path.Ext(filename))
+}
+
+// UploadFile stores an uploaded multipart file under a fresh key in the folder
+func UploadFile(store Storage, folder string, file *multipart.FileHeader, contentType string) (string, error) {
+	src, err := file.Open()
+	if err != nil {
+		return "", err
+	}
+	defer src.Close()
+
+	return store.Put(NewKey(folder, file.Filename), src, file.Size, contentType)
+}
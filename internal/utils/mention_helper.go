@@ -0,0 +1,38 @@
+package utils
+
+import (
+	"regexp"
+	"strings"
+	"unicode"
+)
+
+// mentionPattern matches @handle and @email mentions that are not part of a longer word or address
+var mentionPattern = regexp.MustCompile(`(?:^|[^\w@.])@([A-Za-z0-9._%+-]+(?:@[A-Za-z0-9.-]+\.[A-Za-z]{2,})?)`)
+
+// ParseMentions returns the distinct handles mentioned in a text, without the leading "@"
+func ParseMentions(text string) []string {
+	var handles []string
+	seen := map[string]bool{}
+	for _, match := range mentionPattern.FindAllStringSubmatch(text, -1) {
+		handle := strings.TrimRight(match[1], "._-")
+		key := strings.ToLower(handle)
+		if handle == "" || seen[key] {
+			continue
+		}
+		seen[key] = true
+		handles = append(handles, handle)
+	}
+	return handles
+}
+
+// NormalizeMentionHandle lowercases a handle or name and drops everything but letters and
+// digits, so "@janedoe" and "@jane.doe" both match "Jane Doe"
+func NormalizeMentionHandle(value string) string {
+	var b strings.Builder
+	for _, r := range strings.ToLower(value) {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
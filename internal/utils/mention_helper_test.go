@@ -0,0 +1,53 @@
+package utils
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseMentions(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+		want []string
+	}{
+		{"no mentions", "nothing to see here", nil},
+		{"single handle", "hi @jane", []string{"jane"}},
+		{"several handles", "@ann,@bob and (@carol)", []string{"ann", "bob", "carol"}},
+		{"trailing punctuation", "thanks @jane.doe.", []string{"jane.doe"}},
+		{"email mention", "cc @bob@example.com please", []string{"bob@example.com"}},
+		{"plain email address", "mail bob@example.com", nil},
+		{"duplicates ignore case", "@Jane and @jane", []string{"Jane"}},
+		{"double at sign", "@@jane", nil},
+		{"punctuation only", "@_ and @.", nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ParseMentions(tt.text); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("expected %q, got %q", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestNormalizeMentionHandle(t *testing.T) {
+	tests := []struct {
+		value string
+		want  string
+	}{
+		{"janedoe", "janedoe"},
+		{"Jane Doe", "janedoe"},
+		{"jane.doe-2", "janedoe2"},
+		{"José", "josé"},
+		{"._-", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			if got := NormalizeMentionHandle(tt.value); got != tt.want {
+				t.Errorf("expected %q, got %q", tt.want, got)
+			}
+		})
+	}
+}
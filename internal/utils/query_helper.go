@@ -0,0 +1,11 @@
+package utils
+
+import "strings"
+
+// likeEscaper escapes the wildcard characters of LIKE patterns
+var likeEscaper = strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`)
+
+// EscapeLikePattern makes a value match literally inside a LIKE pattern that uses ESCAPE '\'
+func EscapeLikePattern(value string) string {
+	return likeEscaper.Replace(value)
+}
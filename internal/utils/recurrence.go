@@ -0,0 +1,190 @@
+package utils
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var ErrInvalidRecurrenceRule = errors.New("invalid recurrence rule")
+
+// RecurrenceRule is the supported subset of an RFC 5545 RRULE:
+// FREQ (DAILY, WEEKLY, MONTHLY), INTERVAL, BYDAY (weekly only) and BYMONTHDAY (monthly only)
+type RecurrenceRule struct {
+	Frequency  string
+	Interval   int
+	Weekdays   []time.Weekday
+	MonthDay   int
+	AnchorTime time.Time
+}
+
+var rruleWeekdays = map[string]time.Weekday{
+	"SU": time.Sunday,
+	"MO": time.Monday,
+	"TU": time.Tuesday,
+	"WE": time.Wednesday,
+	"TH": time.Thursday,
+	"FR": time.Friday,
+	"SA": time.Saturday,
+}
+
+// ParseRRule parses an RRULE string such as "FREQ=WEEKLY;INTERVAL=2;BYDAY=MO,FR"
+func ParseRRule(rule string, anchor time.Time) (*RecurrenceRule, error) {
+	r := &RecurrenceRule{Interval: 1, AnchorTime: anchor}
+
+	rule = strings.TrimPrefix(strings.TrimSpace(rule), "RRULE:")
+	for _, part := range strings.Split(rule, ";") {
+		if part == "" {
+			continue
+		}
+		key, value, found := strings.Cut(part, "=")
+		if !found {
+			return nil, ErrInvalidRecurrenceRule
+		}
+
+		switch strings.ToUpper(key) {
+		case "FREQ":
+			r.Frequency = strings.ToUpper(value)
+		case "INTERVAL":
+			n, err := strconv.Atoi(value)
+			if err != nil || n < 1 {
+				return nil, ErrInvalidRecurrenceRule
+			}
+			r.Interval = n
+		case "BYDAY":
+			for _, d := range strings.Split(value, ",") {
+				wd, ok := rruleWeekdays[strings.ToUpper(strings.TrimSpace(d))]
+				if !ok {
+					return nil, ErrInvalidRecurrenceRule
+				}
+				r.Weekdays = append(r.Weekdays, wd)
+			}
+		case "BYMONTHDAY":
+			n, err := strconv.Atoi(value)
+			if err != nil || n < 1 || n > 31 {
+				return nil, ErrInvalidRecurrenceRule
+			}
+			r.MonthDay = n
+		default:
+			return nil, ErrInvalidRecurrenceRule
+		}
+	}
+
+	switch r.Frequency {
+	case "DAILY":
+		if len(r.Weekdays) > 0 || r.MonthDay != 0 {
+			return nil, ErrInvalidRecurrenceRule
+		}
+	case "WEEKLY":
+		if r.MonthDay != 0 {
+			return nil, ErrInvalidRecurrenceRule
+		}
+	case "MONTHLY":
+		if len(r.Weekdays) > 0 {
+			return nil, ErrInvalidRecurrenceRule
+		}
+	default:
+		return nil, ErrInvalidRecurrenceRule
+	}
+
+	return r, nil
+}
+
+// String formats the rule back into its canonical RRULE form
+func (r *RecurrenceRule) String() string {
+	parts := []string{"FREQ=" + r.Frequency}
+	if r.Interval > 1 {
+		parts = append(parts, fmt.Sprintf("INTERVAL=%d", r.Interval))
+	}
+	if len(r.Weekdays) > 0 {
+		days := append([]time.Weekday(nil), r.Weekdays...)
+		sort.Slice(days, func(i, j int) bool { return days[i] < days[j] })
+
+		var names []string
+		for _, d := range days {
+			names = append(names, strings.ToUpper(d.String()[:2]))
+		}
+		parts = append(parts, "BYDAY="+strings.Join(names, ","))
+	}
+	if r.MonthDay != 0 {
+		parts = append(parts, fmt.Sprintf("BYMONTHDAY=%d", r.MonthDay))
+	}
+	return strings.Join(parts, ";")
+}
+
+// Next returns the first occurrence strictly after the given time
+func (r *RecurrenceRule) Next(after time.Time) time.Time {
+	anchor := r.AnchorTime
+	if after.Before(anchor) {
+		return anchor
+	}
+
+	switch r.Frequency {
+	case "DAILY":
+		days := int(after.Sub(anchor).Hours()/24) / r.Interval * r.Interval
+		next := anchor.AddDate(0, 0, days)
+		for !next.After(after) {
+			next = next.AddDate(0, 0, r.Interval)
+		}
+		return next
+
+	case "WEEKLY":
+		weekdays := r.Weekdays
+		if len(weekdays) == 0 {
+			weekdays = []time.Weekday{anchor.Weekday()}
+		}
+		allowed := map[time.Weekday]bool{}
+		for _, d := range weekdays {
+			allowed[d] = true
+		}
+
+		anchorWeek := startOfWeek(anchor)
+		candidate := time.Date(after.Year(), after.Month(), after.Day(),
+			anchor.Hour(), anchor.Minute(), anchor.Second(), 0, anchor.Location())
+		for i := 0; i < 7*(r.Interval+1); i++ {
+			weeks := int(math.Round(startOfWeek(candidate).Sub(anchorWeek).Hours() / (24 * 7)))
+			if candidate.After(after) && allowed[candidate.Weekday()] && weeks%r.Interval == 0 {
+				return candidate
+			}
+			candidate = candidate.AddDate(0, 0, 1)
+		}
+		return candidate
+
+	default: // MONTHLY
+		day := r.MonthDay
+		if day == 0 {
+			day = anchor.Day()
+		}
+		months := (after.Year()-anchor.Year())*12 + int(after.Month()-anchor.Month())
+		months = months / r.Interval * r.Interval
+		for {
+			next := monthlyOccurrence(anchor, months, day)
+			if next.After(after) {
+				return next
+			}
+			months += r.Interval
+		}
+	}
+}
+
+// startOfWeek returns midnight of the Monday starting the week of t
+func startOfWeek(t time.Time) time.Time {
+	offset := (int(t.Weekday()) + 6) % 7
+	return time.Date(t.Year(), t.Month(), t.Day()-offset, 0, 0, 0, 0, t.Location())
+}
+
+// monthlyOccurrence returns the given day of the month that is n months after the anchor,
+// clamped to the last day of shorter months
+func monthlyOccurrence(anchor time.Time, n, day int) time.Time {
+	first := time.Date(anchor.Year(), anchor.Month()+time.Month(n), 1,
+		anchor.Hour(), anchor.Minute(), anchor.Second(), 0, anchor.Location())
+	lastDay := first.AddDate(0, 1, -1).Day()
+	if day > lastDay {
+		day = lastDay
+	}
+	return first.AddDate(0, 0, day-1)
+}
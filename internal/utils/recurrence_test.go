@@ -0,0 +1,90 @@
+package utils
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestParseRRule(t *testing.T) {
+	anchor := time.Date(2025, time.January, 6, 9, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name string
+		rule string
+		want string
+	}{
+		{"daily", "FREQ=DAILY", "FREQ=DAILY"},
+		{"default interval is dropped", "FREQ=DAILY;INTERVAL=1", "FREQ=DAILY"},
+		{"prefix, case and day order are normalised", "RRULE:freq=weekly;byday=fr,mo;interval=2", "FREQ=WEEKLY;INTERVAL=2;BYDAY=MO,FR"},
+		{"monthly by day", "FREQ=MONTHLY;BYMONTHDAY=31", "FREQ=MONTHLY;BYMONTHDAY=31"},
+		{"empty parts are ignored", "FREQ=DAILY;;INTERVAL=3;", "FREQ=DAILY;INTERVAL=3"},
+		{"empty", "", ""},
+		{"missing value", "FREQ", ""},
+		{"unsupported frequency", "FREQ=YEARLY", ""},
+		{"unsupported part", "FREQ=DAILY;COUNT=3", ""},
+		{"zero interval", "FREQ=DAILY;INTERVAL=0", ""},
+		{"unknown weekday", "FREQ=WEEKLY;BYDAY=XX", ""},
+		{"month day out of range", "FREQ=MONTHLY;BYMONTHDAY=32", ""},
+		{"weekdays on a daily rule", "FREQ=DAILY;BYDAY=MO", ""},
+		{"weekdays on a monthly rule", "FREQ=MONTHLY;BYDAY=MO", ""},
+		{"month day on a weekly rule", "FREQ=WEEKLY;BYMONTHDAY=3", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r, err := ParseRRule(tt.rule, anchor)
+			if tt.want == "" {
+				if !errors.Is(err, ErrInvalidRecurrenceRule) {
+					t.Fatalf("expected ErrInvalidRecurrenceRule, got %v", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got := r.String(); got != tt.want {
+				t.Errorf("expected %q, got %q", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestRecurrenceRuleNext(t *testing.T) {
+	// a Monday
+	anchor := time.Date(2025, time.January, 6, 9, 0, 0, 0, time.UTC)
+	at := func(month time.Month, day, hour int) time.Time {
+		return time.Date(2025, month, day, hour, 0, 0, 0, time.UTC)
+	}
+
+	tests := []struct {
+		name  string
+		rule  string
+		after time.Time
+		want  time.Time
+	}{
+		{"before the anchor", "FREQ=DAILY", at(time.January, 1, 0), anchor},
+		{"at the anchor", "FREQ=DAILY", anchor, at(time.January, 7, 9)},
+		{"daily", "FREQ=DAILY", at(time.January, 6, 10), at(time.January, 7, 9)},
+		{"every third day", "FREQ=DAILY;INTERVAL=3", at(time.January, 7, 9), at(time.January, 9, 9)},
+		{"weekly on the anchor weekday", "FREQ=WEEKLY", at(time.January, 6, 10), at(time.January, 13, 9)},
+		{"weekly on listed days", "FREQ=WEEKLY;BYDAY=MO,FR", at(time.January, 6, 10), at(time.January, 10, 9)},
+		{"every other week", "FREQ=WEEKLY;INTERVAL=2;BYDAY=MO", at(time.January, 6, 10), at(time.January, 20, 9)},
+		{"monthly on the anchor day", "FREQ=MONTHLY", at(time.January, 6, 10), at(time.February, 6, 9)},
+		{"every other month", "FREQ=MONTHLY;INTERVAL=2", at(time.January, 6, 10), at(time.March, 6, 9)},
+		{"month day later in the month", "FREQ=MONTHLY;BYMONTHDAY=31", at(time.January, 6, 10), at(time.January, 31, 9)},
+		{"month day clamped to a shorter month", "FREQ=MONTHLY;BYMONTHDAY=31", at(time.January, 31, 10), at(time.February, 28, 9)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r, err := ParseRRule(tt.rule, anchor)
+			if err != nil {
+				t.Fatalf("failed to parse %q: %v", tt.rule, err)
+			}
+			if got := r.Next(tt.after); !got.Equal(tt.want) {
+				t.Errorf("expected %v, got %v", tt.want, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,75 @@
+package utils
+
+import (
+	"errors"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+func TestVerifyDownloadSignature(t *testing.T) {
+	t.Setenv("DOWNLOAD_URL_SECRET", "test-secret")
+
+	resourceID := uuid.New()
+	userID := uuid.New()
+
+	sign := func(expiresAt time.Time) url.Values {
+		t.Helper()
+		signed := SignDownloadURL("/attachments/"+resourceID.String()+"/download", resourceID, "file", userID, expiresAt)
+		_, rawQuery, _ := strings.Cut(signed, "?")
+		query, err := url.ParseQuery(rawQuery)
+		if err != nil {
+			t.Fatalf("failed to parse signed URL %q: %v", signed, err)
+		}
+		return query
+	}
+	valid := sign(time.Now().Add(time.Minute))
+	expired := sign(time.Now().Add(-time.Minute))
+
+	tests := []struct {
+		name       string
+		resourceID uuid.UUID
+		variant    string
+		uid        string
+		expires    string
+		sig        string
+		secret     string
+		wantErr    bool
+	}{
+		{"valid", resourceID, "file", valid.Get("uid"), valid.Get("expires"), valid.Get("sig"), "", false},
+		{"expired", resourceID, "file", expired.Get("uid"), expired.Get("expires"), expired.Get("sig"), "", true},
+		{"other resource", uuid.New(), "file", valid.Get("uid"), valid.Get("expires"), valid.Get("sig"), "", true},
+		{"other variant", resourceID, "thumbnail", valid.Get("uid"), valid.Get("expires"), valid.Get("sig"), "", true},
+		{"other user", resourceID, "file", uuid.NewString(), valid.Get("expires"), valid.Get("sig"), "", true},
+		{"extended expiry", resourceID, "file", valid.Get("uid"), "9999999999", valid.Get("sig"), "", true},
+		{"tampered signature", resourceID, "file", valid.Get("uid"), valid.Get("expires"), strings.Repeat("0", 64), "", true},
+		{"malformed user", resourceID, "file", "someone", valid.Get("expires"), valid.Get("sig"), "", true},
+		{"malformed expiry", resourceID, "file", valid.Get("uid"), "soon", valid.Get("sig"), "", true},
+		{"rotated secret", resourceID, "file", valid.Get("uid"), valid.Get("expires"), valid.Get("sig"), "another-secret", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.secret != "" {
+				t.Setenv("DOWNLOAD_URL_SECRET", tt.secret)
+			}
+
+			got, err := VerifyDownloadSignature(tt.resourceID, tt.variant, tt.uid, tt.expires, tt.sig)
+			if tt.wantErr {
+				if !errors.Is(err, ErrInvalidSignature) {
+					t.Errorf("expected ErrInvalidSignature, got %v", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got != userID {
+				t.Errorf("expected user %s, got %s", userID, got)
+			}
+		})
+	}
+}